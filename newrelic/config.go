@@ -14,6 +14,7 @@ import (
 
 	insights "github.com/newrelic/go-insights/client"
 	nr "github.com/newrelic/newrelic-client-go/newrelic"
+	"github.com/newrelic/newrelic-client-go/pkg/region"
 )
 
 const serviceName = "terraform-provider-newrelic"
@@ -33,7 +34,9 @@ type Config struct {
 	InsightsQueryKey     string
 	InsightsQueryURL     string
 	NerdGraphAPIURL      string
+	RateLimits           map[string]RateLimit
 	SyntheticsAPIURL     string
+	HTTPTransport        http.RoundTripper
 	userAgent            string
 }
 
@@ -49,31 +52,21 @@ func (c *Config) Client() (*nr.NewRelic, error) {
 		nr.ConfigRegion(c.Region),
 	)
 
-	tlsCfg := &tls.Config{}
-	var t = http.DefaultTransport
+	if logging.LogLevel() != "" {
+		options = append(options, nr.ConfigLogLevel(logging.LogLevel()))
+	}
 
-	if c.CACertFile != "" {
-		caCert, _, err := pathorcontents.Read(c.CACertFile)
-		if err != nil {
-			log.Printf("Error reading CA Cert: %s", err)
+	t := c.HTTPTransport
+	if t == nil {
+		var err error
+		if t, err = c.Transport(); err != nil {
 			return nil, err
 		}
-		caCertPool := x509.NewCertPool()
-		caCertPool.AppendCertsFromPEM([]byte(caCert))
-		tlsCfg.RootCAs = caCertPool
-
-		t = &http.Transport{TLSClientConfig: tlsCfg}
-	} else if c.InsecureSkipVerify {
-		tlsCfg.InsecureSkipVerify = true
-
-		t = &http.Transport{TLSClientConfig: tlsCfg}
-	}
-
-	if logging.LogLevel() != "" {
-		options = append(options, nr.ConfigLogLevel(logging.LogLevel()))
-		t = logging.NewTransport("newrelic", t)
 	}
 
+	// The client retries 429 and 5xx responses a fixed number of times, which
+	// this version of the client offers no option for, so the retry policy of
+	// the provider only applies once those retries are used up.
 	options = append(options, nr.ConfigHTTPTransport(t))
 
 	if c.APIURL != "" {
@@ -103,6 +96,72 @@ func (c *Config) Client() (*nr.NewRelic, error) {
 	return client, nil
 }
 
+// hasAPIURLOverrides reports whether the URL of any API is overridden.
+func (c *Config) hasAPIURLOverrides() bool {
+	return c.APIURL != "" || c.InfrastructureAPIURL != "" || c.NerdGraphAPIURL != "" || c.SyntheticsAPIURL != ""
+}
+
+// Transport returns a transport for the clients, throttling their requests
+// according to the rate limits. Clients sharing the transport share the rate
+// limits as well.
+func (c *Config) Transport() (*http.Transport, error) {
+	tlsCfg := &tls.Config{}
+	var t = http.DefaultTransport
+
+	if c.CACertFile != "" {
+		caCert, _, err := pathorcontents.Read(c.CACertFile)
+		if err != nil {
+			log.Printf("Error reading CA Cert: %s", err)
+			return nil, err
+		}
+		caCertPool := x509.NewCertPool()
+		caCertPool.AppendCertsFromPEM([]byte(caCert))
+		tlsCfg.RootCAs = caCertPool
+
+		t = &http.Transport{TLSClientConfig: tlsCfg}
+	} else if c.InsecureSkipVerify {
+		tlsCfg.InsecureSkipVerify = true
+
+		t = &http.Transport{TLSClientConfig: tlsCfg}
+	}
+
+	if logging.LogLevel() != "" {
+		t = logging.NewTransport("newrelic", t)
+	}
+
+	return newThrottledTransport(t, c.apiBaseURLs(), c.RateLimits), nil
+}
+
+// apiBaseURLs returns the base URLs of each throttled API, as used by the
+// clients in any region.
+func (c *Config) apiBaseURLs() map[string][]string {
+	baseURLs := map[string][]string{}
+
+	for _, reg := range region.Regions {
+		r := *reg
+
+		r.SetRestBaseURL(c.APIURL)
+		r.SetInfrastructureBaseURL(c.InfrastructureAPIURL)
+		r.SetNerdGraphBaseURL(c.NerdGraphAPIURL)
+		r.SetSyntheticsBaseURL(c.SyntheticsAPIURL)
+
+		regionURLs := map[string]string{
+			apiREST:           r.RestURL(),
+			apiInfrastructure: r.InfrastructureURL(),
+			apiNerdGraph:      r.NerdGraphURL(),
+			apiSynthetics:     r.SyntheticsURL(),
+		}
+
+		for api, u := range regionURLs {
+			if !stringInSlice(baseURLs[api], u) {
+				baseURLs[api] = append(baseURLs[api], u)
+			}
+		}
+	}
+
+	return baseURLs
+}
+
 // ClientInsightsInsert returns a new Insights insert client
 func (c *Config) ClientInsightsInsert() (*insights.InsertClient, error) {
 	client := insights.NewInsertClient(c.InsightsInsertKey, c.InsightsAccountID)
@@ -135,8 +194,24 @@ type ProviderConfig struct {
 	InsightsInsertClient *insights.InsertClient
 	AccountID            int
 	PersonalAPIKey       string
+	RetryPolicy          retryPolicy
+
+	// accountClients holds the clients of the accounts configured with their
+	// own credentials.
+	accountClients map[int]*nr.NewRelic
+	nrqlConditions *nrqlConditionCache
 }
 
 func (c *ProviderConfig) hasNerdGraphCredentials() bool {
 	return c.AccountID > 0 && c.PersonalAPIKey != ""
 }
+
+// client returns the client to use for an account. Accounts without their own
+// credentials are reached with the client of the provider.
+func (c *ProviderConfig) client(accountID int) *nr.NewRelic {
+	if client, ok := c.accountClients[accountID]; ok {
+		return client
+	}
+
+	return c.NewClient
+}
@@ -37,7 +37,7 @@ func dataSourceNewRelicAccount() *schema.Resource {
 }
 
 func dataSourceNewRelicAccountRead(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*ProviderConfig).NewClient
+	client := accountClient(meta.(*ProviderConfig), d)
 
 	log.Printf("[INFO] Reading New Relic accounts")
 
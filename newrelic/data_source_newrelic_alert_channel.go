@@ -141,7 +141,7 @@ func dataSourceNewRelicAlertChannel() *schema.Resource {
 }
 
 func dataSourceNewRelicAlertChannelRead(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*ProviderConfig).NewClient
+	client := accountClient(meta.(*ProviderConfig), d)
 
 	log.Printf("[INFO] Reading New Relic Alert Channels")
 
@@ -36,7 +36,7 @@ func dataSourceNewRelicApplication() *schema.Resource {
 }
 
 func dataSourceNewRelicApplicationRead(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*ProviderConfig).NewClient
+	client := accountClient(meta.(*ProviderConfig), d)
 
 	log.Printf("[INFO] Reading New Relic applications")
 
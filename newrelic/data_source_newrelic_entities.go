@@ -0,0 +1,223 @@
+package newrelic
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/hashcode"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/newrelic/newrelic-client-go/pkg/entities"
+)
+
+var entitiesQueryBuilderKeys = []string{"name", "domain", "type", "tag", "reporting", "alert_severity"}
+
+func dataSourceNewRelicEntities() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceNewRelicEntitiesRead,
+		Schema: map[string]*schema.Schema{
+			"query": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Description:   "An entity search query, for example \"domain = 'APM' AND reporting = 'true'\". Conflicts with the query builder arguments.",
+				ConflictsWith: entitiesQueryBuilderKeys,
+			},
+			"name": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Description:   "Return the entities whose name contains this value.",
+				ConflictsWith: []string{"query"},
+			},
+			"domain": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Description:   "The entities' domain. Valid values are APM, BROWSER, INFRA, MOBILE, SYNTH, and VIZ.",
+				ValidateFunc:  validation.StringInSlice([]string{"APM", "BROWSER", "INFRA", "MOBILE", "SYNTH", "VIZ"}, true),
+				ConflictsWith: []string{"query"},
+			},
+			"type": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Description:   "The entities' type. Valid values are APPLICATION, DASHBOARD, HOST, MONITOR, and WORKLOAD.",
+				ValidateFunc:  validation.StringInSlice([]string{"APPLICATION", "DASHBOARD", "HOST", "MONITOR", "WORKLOAD"}, true),
+				ConflictsWith: []string{"query"},
+			},
+			"tag": {
+				Type:          schema.TypeList,
+				Optional:      true,
+				Description:   "A tag applied to the entities. Entities must have every tag given.",
+				ConflictsWith: []string{"query"},
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"key": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "The tag key.",
+						},
+						"value": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "The tag value.",
+						},
+					},
+				},
+			},
+			"reporting": {
+				Type:          schema.TypeBool,
+				Optional:      true,
+				Description:   "Whether to return the entities which are reporting data, or the ones which are not.",
+				ConflictsWith: []string{"query"},
+			},
+			"alert_severity": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Description:   "The alert severity of the entities. Valid values are CRITICAL, NOT_ALERTING, NOT_CONFIGURED, and WARNING.",
+				ValidateFunc:  validation.StringInSlice([]string{"CRITICAL", "NOT_ALERTING", "NOT_CONFIGURED", "WARNING"}, true),
+				ConflictsWith: []string{"query"},
+			},
+			"guids": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The GUIDs of the matching entities.",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"entities": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The matching entities.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"guid": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "A unique entity identifier.",
+						},
+						"name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The name of the entity.",
+						},
+						"account_id": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The New Relic account ID of the entity.",
+						},
+						"type": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The entity's type.",
+						},
+						"domain": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The entity's domain.",
+						},
+						"tags": {
+							Type:        schema.TypeList,
+							Computed:    true,
+							Description: "The tags applied to the entity.",
+							Elem:        entityTagsElem(),
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceNewRelicEntitiesRead(d *schema.ResourceData, meta interface{}) error {
+	client := accountClient(meta.(*ProviderConfig), d)
+
+	log.Printf("[INFO] Reading New Relic entities")
+
+	query := d.Get("query").(string)
+	builder := expandEntitiesQueryBuilder(d)
+
+	if query == "" && builder == nil {
+		return fmt.Errorf("one of query or the search arguments (%s) is required to search New Relic entities", strings.Join(entitiesQueryBuilderKeys, ", "))
+	}
+
+	found, err := searchEntitiesWithBuilder(client, query, builder)
+	if err != nil {
+		return err
+	}
+
+	search, err := json.Marshal(builder)
+	if err != nil {
+		return err
+	}
+
+	d.SetId(strconv.Itoa(hashcode.String(query + string(search))))
+
+	return flattenEntitiesData(found, d)
+}
+
+// expandEntitiesQueryBuilder returns the query builder of the configured
+// search arguments, or nil when none is set.
+func expandEntitiesQueryBuilder(d *schema.ResourceData) *entitySearchQueryBuilder {
+	var builder entitySearchQueryBuilder
+	set := false
+
+	if v, ok := d.GetOk("name"); ok {
+		builder.Name = v.(string)
+		set = true
+	}
+
+	if v, ok := d.GetOk("domain"); ok {
+		builder.Domain = entities.EntitySearchQueryBuilderDomain(strings.ToUpper(v.(string)))
+		set = true
+	}
+
+	if v, ok := d.GetOk("type"); ok {
+		builder.Type = entities.EntitySearchQueryBuilderType(strings.ToUpper(v.(string)))
+		set = true
+	}
+
+	if v, ok := d.GetOk("tag"); ok {
+		builder.Tags = expandEntityTag(v.([]interface{}))
+		set = true
+	}
+
+	if v, ok := d.GetOkExists("reporting"); ok {
+		reporting := v.(bool)
+		builder.Reporting = &reporting
+		set = true
+	}
+
+	if v, ok := d.GetOk("alert_severity"); ok {
+		builder.AlertSeverity = entities.EntityAlertSeverity(strings.ToUpper(v.(string)))
+		set = true
+	}
+
+	if !set {
+		return nil
+	}
+
+	return &builder
+}
+
+func flattenEntitiesData(found []entityOutline, d *schema.ResourceData) error {
+	guids := make([]string, len(found))
+	out := make([]interface{}, len(found))
+
+	for i, e := range found {
+		guids[i] = e.GUID
+		out[i] = map[string]interface{}{
+			"guid":       e.GUID,
+			"name":       e.Name,
+			"account_id": e.AccountID,
+			"type":       e.Type,
+			"domain":     e.Domain,
+			"tags":       flattenEntityOutlineTags(e.Tags),
+		}
+	}
+
+	if err := d.Set("guids", guids); err != nil {
+		return err
+	}
+
+	return d.Set("entities", out)
+}
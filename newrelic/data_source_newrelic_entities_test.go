@@ -0,0 +1,41 @@
+// +build integration
+
+package newrelic
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+)
+
+func TestAccNewRelicEntitiesData_Basic(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccNewRelicEntitiesDataConfig(testAccExpectedApplicationName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.newrelic_entities.by_query", "entities.0.name", testAccExpectedApplicationName),
+					resource.TestCheckResourceAttr("data.newrelic_entities.by_builder", "entities.0.domain", "APM"),
+					resource.TestCheckResourceAttrSet("data.newrelic_entities.by_builder", "entities.0.guid"),
+				),
+			},
+		},
+	})
+}
+
+func testAccNewRelicEntitiesDataConfig(name string) string {
+	return fmt.Sprintf(`
+data "newrelic_entities" "by_query" {
+	query = "name = '%[1]s' AND domain = 'APM'"
+}
+
+data "newrelic_entities" "by_builder" {
+	name   = "%[1]s"
+	domain = "APM"
+	type   = "APPLICATION"
+}
+`, name)
+}
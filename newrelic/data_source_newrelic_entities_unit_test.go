@@ -0,0 +1,90 @@
+// +build unit
+
+package newrelic
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+)
+
+func TestAccNewRelicEntitiesData_Offline(t *testing.T) {
+	api := newFakeNewRelicAPI(t)
+	prefix := fmt.Sprintf("tf-test-%s", acctest.RandString(5))
+	api.seedApplication(fmt.Sprintf("tf-test-other-%s", acctest.RandString(5)))
+
+	// More entities match than fit on a page of search results
+	var checks []resource.TestCheckFunc
+	for i := 0; i < 2*fakeEntitySearchPageSize+1; i++ {
+		_, guid := api.seedApplication(fmt.Sprintf("%s-app-%d", prefix, i))
+		checks = append(checks, testAccCheckNewRelicEntityTagsUnitDestroy(api, guid))
+	}
+
+	resource.ParallelTest(t, resource.TestCase{
+		IsUnitTest:   true,
+		Providers:    api.providers(),
+		CheckDestroy: resource.ComposeTestCheckFunc(checks...),
+		Steps: []resource.TestStep{
+			// Test: Read every page of results
+			{
+				Config: api.config(testAccNewRelicEntitiesDataUnitConfig(prefix)),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.newrelic_entities.by_query", "entities.#", "5"),
+					resource.TestCheckResourceAttr("data.newrelic_entities.by_query", "guids.#", "5"),
+					resource.TestCheckResourceAttr("data.newrelic_entities.by_query", "entities.0.domain", "APM"),
+					resource.TestCheckResourceAttr("data.newrelic_entities.by_query", "entities.0.tags.0.key", "account"),
+					resource.TestCheckResourceAttr("data.newrelic_entities.by_builder", "entities.#", "5"),
+					resource.TestCheckResourceAttr("data.newrelic_entities.by_builder", "entities.4.type", "APPLICATION"),
+					resource.TestCheckResourceAttrSet("data.newrelic_entities.by_builder", "entities.4.account_id"),
+					resource.TestCheckResourceAttr("data.newrelic_entities.not_reporting", "entities.#", "0"),
+					resource.TestCheckResourceAttr("newrelic_entity_tags.foo.1", "tag.#", "1"),
+				),
+			},
+			// Test: Missing search arguments
+			{
+				Config:      api.config(`data "newrelic_entities" "foo" {}`),
+				ExpectError: regexp.MustCompile("one of query or the search arguments"),
+			},
+		},
+	})
+}
+
+func testAccNewRelicEntitiesDataUnitConfig(prefix string) string {
+	return fmt.Sprintf(`
+data "newrelic_entities" "by_query" {
+	query = "name like '%[1]s'"
+}
+
+data "newrelic_entities" "by_builder" {
+	name           = "%[1]s"
+	domain         = "APM"
+	type           = "APPLICATION"
+	reporting      = true
+	alert_severity = "NOT_CONFIGURED"
+
+	tag {
+		key   = "language"
+		value = "go"
+	}
+}
+
+data "newrelic_entities" "not_reporting" {
+	name      = "%[1]s"
+	reporting = false
+}
+
+resource "newrelic_entity_tags" "foo" {
+	count = length(data.newrelic_entities.by_query.guids)
+
+	guid = data.newrelic_entities.by_query.guids[count.index]
+
+	tag {
+		key    = "team"
+		values = ["checkout"]
+	}
+}
+`, prefix)
+}
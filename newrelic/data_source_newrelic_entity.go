@@ -17,7 +17,13 @@ func dataSourceNewRelicEntity() *schema.Resource {
 			"name": {
 				Type:        schema.TypeString,
 				Required:    true,
-				Description: "The name of the entity in New Relic One.  The first entity matching this name for the given search parameters will be returned.",
+				Description: "The name of the entity in New Relic One. Exactly one entity must match this name for the given search parameters.",
+			},
+			"ignore_case": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Ignore case of the name when searching for the entity.",
 			},
 			"type": {
 				Type:         schema.TypeString,
@@ -61,8 +67,9 @@ func dataSourceNewRelicEntity() *schema.Resource {
 			},
 			"account_id": {
 				Type:        schema.TypeInt,
+				Optional:    true,
 				Computed:    true,
-				Description: "The New Relic account ID associated with this entity.",
+				Description: "The New Relic account ID associated with this entity. If set, only the entities of this account are searched.",
 			},
 			"application_id": {
 				Type:        schema.TypeInt,
@@ -79,97 +86,228 @@ func dataSourceNewRelicEntity() *schema.Resource {
 				Computed:    true,
 				Description: "A unique entity identifier.",
 			},
+			"tags": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The tags applied to the entity.",
+				Elem:        entityTagsElem(),
+			},
+			"reporting": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the entity is reporting data.",
+			},
+			"alert_severity": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The current alerting severity of the entity.",
+			},
+			"permalink": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The URL of the entity in New Relic One.",
+			},
+			"language": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The language of the agent reporting the entity (only returned for APM applications).",
+			},
+			"golden_metrics": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The golden metrics of the entity, the metrics New Relic considers the most important to monitor its health.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The name of the golden metric.",
+						},
+						"title": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The title of the golden metric.",
+						},
+						"query": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The NRQL query of the golden metric.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// entityTagsElem is the schema of the tags exported for an entity.
+func entityTagsElem() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"key": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The tag key.",
+			},
+			"values": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The tag values.",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
 		},
 	}
 }
 
 func dataSourceNewRelicEntityRead(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*ProviderConfig).NewClient
+	client := accountClient(meta.(*ProviderConfig), d)
 
 	log.Printf("[INFO] Reading New Relic entities")
 
 	name := d.Get("name").(string)
-	entityType := entities.EntitySearchQueryBuilderType(strings.ToUpper(d.Get("type").(string)))
-	tags := expandEntityTag(d.Get("tag").([]interface{}))
-	domain := entities.EntitySearchQueryBuilderDomain(strings.ToUpper(d.Get("domain").(string)))
+	ignoreCase := d.Get("ignore_case").(bool)
+	accountID, accountIDOk := d.GetOk("account_id")
 
-	params := entities.EntitySearchQueryBuilder{
-		Name:   name,
-		Type:   entityType,
-		Tags:   tags,
-		Domain: domain,
+	params := entitySearchQueryBuilder{
+		EntitySearchQueryBuilder: entities.EntitySearchQueryBuilder{
+			Name:   name,
+			Type:   entities.EntitySearchQueryBuilderType(strings.ToUpper(d.Get("type").(string))),
+			Tags:   expandEntityTag(d.Get("tag").([]interface{})),
+			Domain: entities.EntitySearchQueryBuilderDomain(strings.ToUpper(d.Get("domain").(string))),
+		},
 	}
 
-	entityResults, err := client.Entities.GetEntitySearch(entities.EntitySearchOptions{}, "", params, []entities.EntitySearchSortCriteria{})
+	entityResults, err := searchEntitiesWithBuilder(client, "", &params)
 	if err != nil {
 		return err
 	}
 
-	var entity *entities.EntityOutlineInterface
-	for _, e := range entityResults.Results.Entities {
-		if e.GetName() == name {
-			entity = &e
-			break
+	var candidates []entityOutline
+	for _, e := range entityResults {
+		if e.Name != name && !(ignoreCase && strings.EqualFold(e.Name, name)) {
+			continue
 		}
+
+		if accountIDOk && e.AccountID != accountID.(int) {
+			continue
+		}
+
+		candidates = append(candidates, e)
 	}
 
-	if entity == nil {
+	if len(candidates) == 0 {
 		return fmt.Errorf("the name '%s' does not match any New Relic One entity for the given search parameters", name)
 	}
 
-	return flattenEntityData(entity, d)
+	if len(candidates) > 1 {
+		matches := make([]string, len(candidates))
+		for i, e := range candidates {
+			matches[i] = fmt.Sprintf("%s (%s %s '%s' in account %d)", e.GUID, e.Domain, e.Type, e.Name, e.AccountID)
+		}
+
+		return fmt.Errorf("the name '%s' matches %d New Relic One entities for the given search parameters, use account_id, domain, type or tag to select one of: %s",
+			name, len(candidates), strings.Join(matches, ", "))
+	}
+
+	goldenMetrics, err := getEntityGoldenMetrics(client, candidates[0].GUID)
+	if err != nil {
+		return err
+	}
+
+	return flattenEntityData(&candidates[0], goldenMetrics, d)
 }
 
-func flattenEntityData(entity *entities.EntityOutlineInterface, d *schema.ResourceData) error {
+func flattenEntityData(entity *entityOutline, goldenMetrics []entityGoldenMetric, d *schema.ResourceData) error {
 	var err error
 
-	d.SetId(string((*entity).GetGUID()))
+	d.SetId(entity.GUID)
+
+	if err = d.Set("name", entity.Name); err != nil {
+		return err
+	}
+
+	if err = d.Set("guid", entity.GUID); err != nil {
+		return err
+	}
+
+	if err = d.Set("type", entity.Type); err != nil {
+		return err
+	}
+
+	if err = d.Set("domain", entity.Domain); err != nil {
+		return err
+	}
 
-	if err = d.Set("name", (*entity).GetName()); err != nil {
+	if err = d.Set("account_id", entity.AccountID); err != nil {
 		return err
 	}
 
-	if err = d.Set("guid", (*entity).GetGUID()); err != nil {
+	if err = d.Set("tags", flattenEntityOutlineTags(entity.Tags)); err != nil {
 		return err
 	}
 
-	if err = d.Set("type", (*entity).GetType()); err != nil {
+	if err = d.Set("reporting", entity.Reporting); err != nil {
 		return err
 	}
 
-	if err = d.Set("domain", (*entity).GetDomain()); err != nil {
+	if err = d.Set("alert_severity", entity.AlertSeverity); err != nil {
 		return err
 	}
 
-	if err = d.Set("account_id", (*entity).GetAccountID()); err != nil {
+	if err = d.Set("permalink", entity.Permalink); err != nil {
 		return err
 	}
 
-	// store extra values per Entity Type, have to repeat code here due to
-	// go handling of type switching
-	switch e := (*entity).(type) {
-	case *entities.ApmApplicationEntityOutline:
-		if err = d.Set("application_id", e.ApplicationID); err != nil {
+	if err = d.Set("language", entity.Language); err != nil {
+		return err
+	}
+
+	if err = d.Set("golden_metrics", flattenEntityGoldenMetrics(goldenMetrics)); err != nil {
+		return err
+	}
+
+	// Only APM, Browser and Mobile applications have an application ID, and
+	// only Browser applications are served by an APM application.
+	if entity.ApplicationID > 0 {
+		if err = d.Set("application_id", entity.ApplicationID); err != nil {
 			return err
 		}
-	case *entities.MobileApplicationEntityOutline:
-		if err = d.Set("application_id", e.ApplicationID); err != nil {
+	}
+
+	if entity.ServingApmApplicationID > 0 {
+		if err = d.Set("serving_apm_application_id", entity.ServingApmApplicationID); err != nil {
 			return err
 		}
-	case *entities.BrowserApplicationEntityOutline:
-		if err = d.Set("application_id", e.ApplicationID); err != nil {
-			return err
+	}
+
+	return nil
+}
+
+func flattenEntityGoldenMetrics(metrics []entityGoldenMetric) []interface{} {
+	out := make([]interface{}, len(metrics))
+
+	for i, m := range metrics {
+		out[i] = map[string]interface{}{
+			"name":  m.Name,
+			"title": m.Title,
+			"query": m.Query,
 		}
+	}
 
-		if e.ServingApmApplicationID > 0 {
-			err = d.Set("serving_apm_application_id", e.ServingApmApplicationID)
-			if err != nil {
-				return err
-			}
+	return out
+}
+
+func flattenEntityOutlineTags(tags []entityTag) []interface{} {
+	out := make([]interface{}, len(tags))
+
+	for i, t := range tags {
+		out[i] = map[string]interface{}{
+			"key":    t.Key,
+			"values": t.Values,
 		}
 	}
 
-	return nil
+	return out
 }
 
 func expandEntityTag(cfg []interface{}) []entities.EntitySearchQueryBuilderTag {
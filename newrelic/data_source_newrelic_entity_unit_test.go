@@ -0,0 +1,94 @@
+// +build unit
+
+package newrelic
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+)
+
+func TestAccNewRelicEntityData_Offline(t *testing.T) {
+	api := newFakeNewRelicAPI(t)
+	appName := fmt.Sprintf("tf-test-app-%s", acctest.RandString(5))
+	mixedCaseName := fmt.Sprintf("TF-Test-App-%s", acctest.RandString(5))
+
+	// Entities whose names contain the searched name fill the first pages of
+	// search results
+	for i := 0; i < 2*fakeEntitySearchPageSize; i++ {
+		api.seedApplication(fmt.Sprintf("%s-%d", appName, i))
+	}
+
+	api.seedApplication(appName)
+	_, subAccountGUID := api.seedApplication(appName)
+	_, mixedCaseGUID := api.seedApplication(mixedCaseName)
+
+	api.update(fakeKindEntity, subAccountGUID, func(entity map[string]interface{}) {
+		entity["accountId"] = fakeSubAccountID
+	})
+
+	resource.ParallelTest(t, resource.TestCase{
+		IsUnitTest: true,
+		Providers:  api.providers(),
+		Steps: []resource.TestStep{
+			// Test: Scope the search to an account and ignore the case of the name
+			{
+				Config: api.config(testAccNewRelicEntityDataUnitConfig(appName, fakeSubAccountID, strings.ToLower(mixedCaseName))),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.newrelic_entity.scoped", "guid", subAccountGUID),
+					resource.TestCheckResourceAttr("data.newrelic_entity.scoped", "account_id", strconv.Itoa(fakeSubAccountID)),
+					resource.TestCheckResourceAttr("data.newrelic_entity.scoped", "tags.#", "2"),
+					resource.TestCheckResourceAttr("data.newrelic_entity.scoped", "tags.1.key", "language"),
+					resource.TestCheckResourceAttr("data.newrelic_entity.scoped", "tags.1.values.0", "go"),
+					resource.TestCheckResourceAttr("data.newrelic_entity.mixed_case", "guid", mixedCaseGUID),
+					resource.TestCheckResourceAttr("data.newrelic_entity.mixed_case", "name", mixedCaseName),
+					resource.TestCheckResourceAttr("data.newrelic_entity.mixed_case", "reporting", "true"),
+					resource.TestCheckResourceAttr("data.newrelic_entity.mixed_case", "alert_severity", "NOT_CONFIGURED"),
+					resource.TestCheckResourceAttr("data.newrelic_entity.mixed_case", "language", "go"),
+					resource.TestCheckResourceAttr("data.newrelic_entity.mixed_case", "permalink", "https://one.newrelic.com/redirect/entity/"+mixedCaseGUID),
+					resource.TestCheckResourceAttr("data.newrelic_entity.mixed_case", "golden_metrics.#", "3"),
+					resource.TestCheckResourceAttr("data.newrelic_entity.mixed_case", "golden_metrics.0.name", "responseTimeMs"),
+					resource.TestMatchResourceAttr("data.newrelic_entity.mixed_case", "golden_metrics.0.query", regexp.MustCompile(mixedCaseGUID)),
+				),
+			},
+			// Test: The case of the name matters by default
+			{
+				Config: api.config(fmt.Sprintf(`
+data "newrelic_entity" "foo" {
+	name = "%s"
+}
+`, strings.ToLower(mixedCaseName))),
+				ExpectError: regexp.MustCompile("does not match any New Relic One entity"),
+			},
+			// Test: Ambiguous name
+			{
+				Config: api.config(fmt.Sprintf(`
+data "newrelic_entity" "foo" {
+	name   = "%s"
+	domain = "APM"
+}
+`, appName)),
+				ExpectError: regexp.MustCompile(fmt.Sprintf("matches 2 New Relic One entities(.|\\s)*in account %d", fakeSubAccountID)),
+			},
+		},
+	})
+}
+
+func testAccNewRelicEntityDataUnitConfig(appName string, accountID int, mixedCaseName string) string {
+	return fmt.Sprintf(`
+data "newrelic_entity" "scoped" {
+	name       = "%[1]s"
+	account_id = %[2]d
+}
+
+data "newrelic_entity" "mixed_case" {
+	name        = "%[3]s"
+	ignore_case = true
+}
+`, appName, accountID, mixedCaseName)
+}
@@ -24,7 +24,7 @@ func dataSourceNewRelicKeyTransaction() *schema.Resource {
 }
 
 func dataSourceNewRelicKeyTransactionRead(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*ProviderConfig).NewClient
+	client := accountClient(meta.(*ProviderConfig), d)
 
 	log.Printf("[INFO] Reading New Relic key transactions")
 
@@ -29,7 +29,7 @@ func dataSourceNewRelicPlugin() *schema.Resource {
 }
 
 func dataSourceNewRelicPluginRead(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*ProviderConfig).NewClient
+	client := accountClient(meta.(*ProviderConfig), d)
 
 	log.Printf("[INFO] Reading New Relic Plugins")
 
@@ -39,7 +39,7 @@ func dataSourceNewRelicPluginComponent() *schema.Resource {
 }
 
 func dataSourceNewRelicPluginComponentRead(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*ProviderConfig).NewClient
+	client := accountClient(meta.(*ProviderConfig), d)
 
 	log.Printf("[INFO] Reading New Relic Plugin Components")
 
@@ -28,7 +28,7 @@ func dataSourceNewRelicSyntheticsMonitor() *schema.Resource {
 }
 
 func dataSourceNewRelicSyntheticsMonitorRead(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*ProviderConfig).NewClient
+	client := accountClient(meta.(*ProviderConfig), d)
 
 	log.Printf("[INFO] Reading New Relic synthetics monitors")
 
@@ -43,7 +43,7 @@ func dataSourceNewRelicSyntheticsMonitorLocation() *schema.Resource {
 }
 
 func dataSourceNewRelicSyntheticsMonitorLocationRead(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*ProviderConfig).NewClient
+	client := accountClient(meta.(*ProviderConfig), d)
 
 	log.Printf("[INFO] Reading Synthetics monitor locations")
 
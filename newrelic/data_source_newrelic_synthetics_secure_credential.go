@@ -41,7 +41,7 @@ func dataSourceNewRelicSyntheticsSecureCredential() *schema.Resource {
 }
 
 func dataSourceNewRelicSyntheticsSecureCredentialRead(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*ProviderConfig).NewClient
+	client := accountClient(meta.(*ProviderConfig), d)
 
 	log.Printf("[INFO] Reading New Relic Synthetics secure credential")
 
@@ -0,0 +1,207 @@
+package newrelic
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/newrelic/newrelic-client-go/pkg/errors"
+	"github.com/newrelic/newrelic-client-go/pkg/workloads"
+)
+
+func dataSourceNewRelicWorkload() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceNewRelicWorkloadRead,
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "The name of the workload. One of name or guid is required.",
+			},
+			"guid": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "The unique entity identifier of the workload in New Relic. One of name or guid is required.",
+			},
+			"scope_account_ids": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "The account IDs to search for the workload. Defaults to the account ID of the provider.",
+				Elem:        &schema.Schema{Type: schema.TypeInt},
+			},
+			"account_id": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The New Relic account ID of the workload.",
+			},
+			"workload_id": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The unique entity identifier of the workload.",
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Relevant information about the workload.",
+			},
+			"permalink": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The URL of the workload.",
+			},
+			"composite_entity_search_query": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The composite query used to compose a dynamic workload.",
+			},
+			"entity_guids": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The GUIDs of the entities manually assigned to the workload.",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"entities": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The entities currently in the workload, whether assigned manually or matched by its search queries.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"guid": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "A unique entity identifier.",
+						},
+						"name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The name of the entity.",
+						},
+						"type": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The entity's type.",
+						},
+						"domain": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The entity's domain.",
+						},
+						"account_id": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The New Relic account ID of the entity.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceNewRelicWorkloadRead(d *schema.ResourceData, meta interface{}) error {
+	providerConfig := meta.(*ProviderConfig)
+
+	log.Printf("[INFO] Reading New Relic One workloads")
+
+	name, nameOk := d.GetOk("name")
+	guid, guidOk := d.GetOk("guid")
+
+	if nameOk == guidOk {
+		return fmt.Errorf(`exactly one of "name" or "guid" is required to locate a New Relic One workload`)
+	}
+
+	accountIDs := []int{providerConfig.AccountID}
+	if ids := d.Get("scope_account_ids").([]interface{}); len(ids) > 0 {
+		accountIDs = make([]int, len(ids))
+		for i, id := range ids {
+			accountIDs[i] = id.(int)
+		}
+	}
+
+	var found *workloads.Workload
+	for _, accountID := range accountIDs {
+		collections, err := providerConfig.client(accountID).Workloads.ListWorkloads(accountID)
+		if err != nil {
+			// Accounts without any workload are reported as not found.
+			if _, ok := err.(*errors.NotFound); ok {
+				continue
+			}
+
+			return err
+		}
+
+		for _, w := range collections {
+			if (nameOk && w.Name == name.(string)) || (guidOk && w.GUID == guid.(string)) {
+				found = w
+				break
+			}
+		}
+
+		if found != nil {
+			break
+		}
+	}
+
+	if found == nil {
+		if nameOk {
+			return fmt.Errorf("the name '%s' does not match any New Relic One workload", name)
+		}
+
+		return fmt.Errorf("the guid '%s' does not match any New Relic One workload", guid)
+	}
+
+	// The workload and its entities are read with the credentials of the
+	// account the workload belongs to.
+	client := providerConfig.client(found.Account.ID)
+
+	workload, err := getWorkload(client, found.Account.ID, found.GUID)
+	if err != nil {
+		return err
+	}
+
+	var members []entityOutline
+	if query := workloadEntitySearchQuery(workload.Workload); query != "" {
+		members, err = searchEntities(client, query)
+		if err != nil {
+			return err
+		}
+	}
+
+	return flattenWorkloadData(workload, members, d)
+}
+
+// workloadEntitySearchQuery returns the entity search query matching the
+// entities of a workload, both the ones assigned manually and the ones its
+// search queries match, within the accounts the workload is scoped to.
+func workloadEntitySearchQuery(workload *workloads.Workload) string {
+	var queries []string
+
+	if len(workload.Entities) > 0 {
+		guids := make([]string, len(workload.Entities))
+		for i, e := range workload.Entities {
+			guids[i] = fmt.Sprintf("'%s'", e.GUID)
+		}
+
+		queries = append(queries, fmt.Sprintf("id IN (%s)", strings.Join(guids, ", ")))
+	}
+
+	if workload.EntitySearchQuery != "" {
+		queries = append(queries, fmt.Sprintf("(%s)", workload.EntitySearchQuery))
+	}
+
+	query := strings.Join(queries, " OR ")
+	if query == "" || len(workload.ScopeAccounts.AccountIDs) == 0 {
+		return query
+	}
+
+	accountIDs := make([]string, len(workload.ScopeAccounts.AccountIDs))
+	for i, id := range workload.ScopeAccounts.AccountIDs {
+		accountIDs[i] = strconv.Itoa(id)
+	}
+
+	return fmt.Sprintf("(%s) AND accountId IN (%s)", query, strings.Join(accountIDs, ", "))
+}
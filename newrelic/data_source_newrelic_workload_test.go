@@ -0,0 +1,50 @@
+// +build integration
+
+package newrelic
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+)
+
+func TestAccNewRelicWorkloadDataSource_Basic(t *testing.T) {
+	rName := acctest.RandString(5)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccNewRelicWorkloadDataSourceConfig(rName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair("data.newrelic_workload.foo", "guid", "newrelic_workload.foo", "guid"),
+					resource.TestCheckResourceAttrSet("data.newrelic_workload.foo", "entities.#"),
+				),
+			},
+		},
+	})
+}
+
+func testAccNewRelicWorkloadDataSourceConfig(name string) string {
+	return fmt.Sprintf(`
+data "newrelic_entity" "app" {
+	name   = "%[2]s"
+	domain = "APM"
+	type   = "APPLICATION"
+}
+
+resource "newrelic_workload" "foo" {
+	name       = "tf-test-%[3]s"
+	account_id = %[1]d
+
+	entity_guids = [data.newrelic_entity.app.guid]
+}
+
+data "newrelic_workload" "foo" {
+	name = newrelic_workload.foo.name
+}
+`, testAccountID, testAccExpectedApplicationName, name)
+}
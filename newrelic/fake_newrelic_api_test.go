@@ -0,0 +1,1206 @@
+// +build unit
+
+package newrelic
+
+import (
+	"compress/gzip"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+)
+
+const (
+	fakeAccountID           = 11111
+	fakeSubAccountID        = 22222
+	fakeSyntheticsTimestamp = "2006-01-02T15:04:05.999999999-0700"
+)
+
+// Object kinds held by the fake API. REST alert condition kinds match the
+// collection names used in their URLs so the routes can be shared.
+const (
+	fakeKindAPIKey                   = "api_access_keys"
+	fakeKindApplication              = "applications"
+	fakeKindChannel                  = "alerts_channels"
+	fakeKindCondition                = "alerts_conditions"
+	fakeKindDashboard                = "dashboards"
+	fakeKindEntity                   = "entities"
+	fakeKindEventsToMetricsRule      = "events_to_metrics_rules"
+	fakeKindGroup                    = "groups"
+	fakeKindInfraCondition           = "infra_conditions"
+	fakeKindInsightsEvent            = "insights_events"
+	fakeKindLocationFailureCondition = "alerts_location_failure_conditions"
+	fakeKindManagedAccount           = "managed_accounts"
+	fakeKindMonitor                  = "synthetics_monitors"
+	fakeKindMonitorScript            = "synthetics_monitor_scripts"
+	fakeKindMutingRule               = "alerts_muting_rules"
+	fakeKindNotificationChannel      = "notification_channels"
+	fakeKindNotificationDestination  = "notification_destinations"
+	fakeKindNrqlCondition            = "alerts_nrql_conditions"
+	fakeKindPluginsCondition         = "alerts_plugins_conditions"
+	fakeKindPolicy                   = "alerts_policies"
+	fakeKindSecureCredential         = "synthetics_secure_credentials"
+	fakeKindSyntheticsCondition      = "alerts_synthetics_conditions"
+	fakeKindUser                     = "users"
+	fakeKindWorkflow                 = "workflows"
+	fakeKindWorkload                 = "workloads"
+)
+
+// fakeRESTConditionKeys maps each REST alert condition collection to the
+// singular JSON envelope key used by its request and response bodies.
+var fakeRESTConditionKeys = map[string]string{
+	fakeKindCondition:                "condition",
+	fakeKindPluginsCondition:         "plugins_condition",
+	fakeKindSyntheticsCondition:      "synthetics_condition",
+	fakeKindLocationFailureCondition: "location_failure_condition",
+}
+
+// fakeRecord is a single object stored by the fake API. Parent holds the ID of
+// the owning object for APIs that scope objects, such as a condition's policy.
+type fakeRecord struct {
+	ID     string
+	Parent string
+	Data   map[string]interface{}
+}
+
+// fakeNewRelicAPI is an in-process stand-in for the New Relic REST v2,
+// Infrastructure, Synthetics, Insights insert and NerdGraph APIs. It keeps
+// every object in memory so that full resource lifecycles can be exercised
+// without credentials or network access.
+type fakeNewRelicAPI struct {
+	AccountID int
+
+	server *httptest.Server
+
+	mu             sync.Mutex
+	lastID         int
+	records        map[string]map[string]*fakeRecord
+	policyChannels map[string]map[string]bool
+
+	// graphQLCalls counts the requests made for each NerdGraph field.
+	graphQLCalls map[string]int
+	// apiKeyRequests counts the requests made with each Personal API key.
+	apiKeyRequests map[string]int
+
+	// failures is the number of upcoming requests answered with failureStatus
+	// instead of being served.
+	failures      int
+	failureStatus int
+
+	// nrqlConditionSearchDelay is the number of searches new NRQL conditions
+	// are left out of, counted down per condition in unindexedNrqlConditions.
+	nrqlConditionSearchDelay int
+	unindexedNrqlConditions  map[string]int
+
+	// tagReadDelay is the number of reads of an entity which return its tags
+	// as they were before they were added or replaced, kept per entity in
+	// unindexedTags.
+	tagReadDelay  int
+	unindexedTags map[string]*fakeUnindexedTags
+}
+
+// fakeUnindexedTags are the tags returned for an entity until its tags are
+// indexed.
+type fakeUnindexedTags struct {
+	reads int
+	tags  interface{}
+}
+
+// newFakeNewRelicAPI starts a fake API server which is shut down when the test
+// completes.
+func newFakeNewRelicAPI(t *testing.T) *fakeNewRelicAPI {
+	f := &fakeNewRelicAPI{
+		AccountID:      fakeAccountID,
+		lastID:         1000,
+		records:        map[string]map[string]*fakeRecord{},
+		policyChannels: map[string]map[string]bool{},
+		graphQLCalls:   map[string]int{},
+		apiKeyRequests: map[string]int{},
+
+		unindexedNrqlConditions: map[string]int{},
+		unindexedTags:           map[string]*fakeUnindexedTags{},
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v2/", f.handleREST)
+	mux.HandleFunc("/infrastructure/v2/", f.handleInfrastructure)
+	mux.HandleFunc("/synthetics/api/", f.handleSynthetics)
+	mux.HandleFunc("/insights/collector.newrelic.com/v1/accounts/", f.handleInsightsInsert)
+	mux.HandleFunc("/graphql", f.handleNerdGraph)
+
+	f.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		f.countAPIKey(r)
+
+		if f.fail(w) {
+			return
+		}
+
+		mux.ServeHTTP(w, r)
+	}))
+	t.Cleanup(f.server.Close)
+
+	return f
+}
+
+// requestsWithAPIKey returns the number of requests made with a Personal API
+// key.
+func (f *fakeNewRelicAPI) requestsWithAPIKey(key string) int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.apiKeyRequests[key]
+}
+
+func (f *fakeNewRelicAPI) countAPIKey(r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if key := r.Header.Get("Api-Key"); key != "" {
+		f.apiKeyRequests[key]++
+	}
+}
+
+// graphQLCallCount returns the number of requests made for a NerdGraph field.
+func (f *fakeNewRelicAPI) graphQLCallCount(field string) int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.graphQLCalls[field]
+}
+
+// failNext answers the next n requests with the given status code, as the
+// API does when rate limiting or failing. 429 responses ask to be retried
+// right away so tests do not wait for the client's backoff.
+func (f *fakeNewRelicAPI) failNext(n int, status int) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.failures = n
+	f.failureStatus = status
+}
+
+// delayNrqlConditionSearches leaves the NRQL conditions created from now on
+// out of their first n searches, as the API does until it has indexed them.
+func (f *fakeNewRelicAPI) delayNrqlConditionSearches(n int) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.nrqlConditionSearchDelay = n
+}
+
+// delayTagReads leaves the tags added or replaced from now on out of the
+// first n reads of their entity, as the API does until it has indexed them.
+func (f *fakeNewRelicAPI) delayTagReads(n int) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.tagReadDelay = n
+}
+
+func (f *fakeNewRelicAPI) fail(w http.ResponseWriter) bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.failures == 0 {
+		return false
+	}
+
+	f.failures--
+
+	if f.failureStatus == http.StatusTooManyRequests {
+		w.Header().Set("Retry-After", "0")
+	}
+
+	fakeRESTError(w, f.failureStatus, http.StatusText(f.failureStatus))
+
+	return true
+}
+
+// providers returns a provider set that is not shared with other tests, since
+// each test configures the provider against its own fake server.
+func (f *fakeNewRelicAPI) providers() map[string]terraform.ResourceProvider {
+	return map[string]terraform.ResourceProvider{
+		"newrelic": Provider(),
+	}
+}
+
+// config prefixes the given resource configuration with a provider block
+// pointing every API at the fake.
+func (f *fakeNewRelicAPI) config(resources string) string {
+	return f.configWithProvider("", resources)
+}
+
+// configWithProvider is like config, adding the given arguments to the
+// provider block.
+func (f *fakeNewRelicAPI) configWithProvider(providerArgs string, resources string) string {
+	return fmt.Sprintf(`
+provider "newrelic" {
+	account_id             = %[2]d
+	api_key                = "NRAK-FAKE"
+	admin_api_key          = "NRAA-FAKE"
+	region                 = "US"
+	api_url                = "%[1]s/v2"
+	infrastructure_api_url = "%[1]s/infrastructure/v2"
+	synthetics_api_url     = "%[1]s/synthetics/api"
+	nerdgraph_api_url      = "%[1]s/graphql"
+	insights_insert_key    = "fake-insert-key"
+	insights_insert_url    = "%[1]s/insights/collector.newrelic.com/v1/accounts"
+%[4]s
+}
+%[3]s`, f.server.URL, f.AccountID, resources, providerArgs)
+}
+
+// providerConfig returns the ProviderConfig of a provider pointing every API
+// at the fake, for tests that call resource functions directly.
+func (f *fakeNewRelicAPI) providerConfig(t *testing.T) *ProviderConfig {
+	p := Provider().(*schema.Provider)
+
+	raw := terraform.NewResourceConfigRaw(map[string]interface{}{
+		"account_id":             f.AccountID,
+		"api_key":                "NRAK-FAKE",
+		"admin_api_key":          "NRAA-FAKE",
+		"region":                 "US",
+		"api_url":                f.server.URL + "/v2",
+		"infrastructure_api_url": f.server.URL + "/infrastructure/v2",
+		"synthetics_api_url":     f.server.URL + "/synthetics/api",
+		"nerdgraph_api_url":      f.server.URL + "/graphql",
+	})
+
+	if err := p.Configure(raw); err != nil {
+		t.Fatal(err)
+	}
+
+	return p.Meta().(*ProviderConfig)
+}
+
+// checkDestroyed returns a CheckDestroy function which fails when any object
+// of the given kinds is still held by the fake.
+func (f *fakeNewRelicAPI) checkDestroyed(kinds ...string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+
+		for _, kind := range kinds {
+			if n := len(f.records[kind]); n > 0 {
+				return fmt.Errorf("%d %s still exist", n, kind)
+			}
+		}
+
+		return nil
+	}
+}
+
+// count returns the number of objects of the given kind.
+func (f *fakeNewRelicAPI) count(kind string) int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return len(f.records[kind])
+}
+
+// get returns a copy of the stored data for an object, or nil when it does
+// not exist.
+func (f *fakeNewRelicAPI) get(kind string, id string) map[string]interface{} {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	rec := f.find(kind, id)
+	if rec == nil {
+		return nil
+	}
+
+	return fakeCopy(rec.Data).(map[string]interface{})
+}
+
+// update applies changes to a stored object, simulating edits made outside
+// of Terraform.
+func (f *fakeNewRelicAPI) update(kind string, id string, fn func(map[string]interface{})) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if rec := f.find(kind, id); rec != nil {
+		fn(rec.Data)
+	}
+}
+
+// seedApplication registers an APM application and its entity, as if an
+// agent had reported in. It returns the application ID and entity GUID.
+func (f *fakeNewRelicAPI) seedApplication(name string) (int, string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	id := f.nextID()
+	guid := f.guid("APM", "APPLICATION", id)
+
+	f.put(fakeKindApplication, strconv.Itoa(id), "", map[string]interface{}{
+		"id":        id,
+		"name":      name,
+		"language":  "go",
+		"reporting": true,
+		"settings": map[string]interface{}{
+			"app_apdex_threshold":         0.5,
+			"end_user_apdex_threshold":    7.0,
+			"enable_real_user_monitoring": true,
+			"use_server_side_config":      false,
+		},
+	})
+
+	f.put(fakeKindEntity, guid, "", map[string]interface{}{
+		"__typename":    "ApmApplicationEntity",
+		"accountId":     f.AccountID,
+		"alertSeverity": "NOT_CONFIGURED",
+		"applicationId": id,
+		"domain":        "APM",
+		"entityType":    "APM_APPLICATION_ENTITY",
+		"guid":          guid,
+		"language":      "go",
+		"name":          name,
+		"permalink":     fmt.Sprintf("https://one.newrelic.com/redirect/entity/%s", guid),
+		"reporting":     true,
+		"type":          "APPLICATION",
+		"tags": []interface{}{
+			map[string]interface{}{"key": "account", "values": []interface{}{"Fake Account"}},
+			map[string]interface{}{"key": "language", "values": []interface{}{"go"}},
+		},
+		"goldenMetrics": map[string]interface{}{
+			"metrics": []interface{}{
+				map[string]interface{}{
+					"name":  "responseTimeMs",
+					"title": "Response time (ms)",
+					"query": fmt.Sprintf("SELECT average(apm.service.transaction.duration) * 1000 AS 'Response time (ms)' FROM Metric WHERE entity.guid = '%s'", guid),
+				},
+				map[string]interface{}{
+					"name":  "throughput",
+					"title": "Throughput",
+					"query": fmt.Sprintf("SELECT rate(count(apm.service.transaction.duration), 1 minute) AS 'Throughput' FROM Metric WHERE entity.guid = '%s'", guid),
+				},
+				map[string]interface{}{
+					"name":  "errorRate",
+					"title": "Error rate",
+					"query": fmt.Sprintf("SELECT count(apm.service.error.count) / count(apm.service.transaction.duration) AS 'Error rate' FROM Metric WHERE entity.guid = '%s'", guid),
+				},
+			},
+		},
+	})
+
+	return id, guid
+}
+
+// seedSubAccountApplication is like seedApplication, for an application of
+// the sub-account. It returns the entity GUID.
+func (f *fakeNewRelicAPI) seedSubAccountApplication(name string) string {
+	id, guid := f.seedApplication(name)
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	entity := f.remove(fakeKindEntity, guid).Data
+
+	guid = base64.RawStdEncoding.EncodeToString([]byte(fmt.Sprintf("%d|APM|APPLICATION|%d", fakeSubAccountID, id)))
+	entity["accountId"] = fakeSubAccountID
+	entity["guid"] = guid
+	f.put(fakeKindEntity, guid, "", entity)
+
+	return guid
+}
+
+// seedWorkload stores a workload of the given account holding the entities,
+// as if created outside of Terraform, and returns its GUID.
+func (f *fakeNewRelicAPI) seedWorkload(accountID int, name string, entityGUIDs ...string) string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	guids := make([]interface{}, len(entityGUIDs))
+	for i, guid := range entityGUIDs {
+		guids[i] = guid
+	}
+
+	resp, _ := f.workloadCreate(map[string]interface{}{
+		"accountId": accountID,
+		"workload":  map[string]interface{}{"name": name, "entityGuids": guids},
+	})
+
+	return fakeString(fakeMap(fakeMap(resp)["workloadCreate"])["guid"])
+}
+
+func (f *fakeNewRelicAPI) nextID() int {
+	f.lastID++
+	return f.lastID
+}
+
+// guid builds an entity GUID in the same shape as the real API.
+func (f *fakeNewRelicAPI) guid(domain string, entityType string, id int) string {
+	raw := fmt.Sprintf("%d|%s|%s|%d", f.AccountID, domain, entityType, id)
+	return base64.RawStdEncoding.EncodeToString([]byte(raw))
+}
+
+func (f *fakeNewRelicAPI) put(kind string, id string, parent string, data map[string]interface{}) *fakeRecord {
+	if f.records[kind] == nil {
+		f.records[kind] = map[string]*fakeRecord{}
+	}
+
+	rec := &fakeRecord{ID: id, Parent: parent, Data: data}
+	f.records[kind][id] = rec
+
+	return rec
+}
+
+func (f *fakeNewRelicAPI) find(kind string, id string) *fakeRecord {
+	return f.records[kind][id]
+}
+
+func (f *fakeNewRelicAPI) remove(kind string, id string) *fakeRecord {
+	rec := f.records[kind][id]
+	delete(f.records[kind], id)
+
+	return rec
+}
+
+// list returns the objects of a kind in creation order, optionally restricted
+// to those belonging to a parent.
+func (f *fakeNewRelicAPI) list(kind string, parent string) []*fakeRecord {
+	out := []*fakeRecord{}
+
+	for _, rec := range f.records[kind] {
+		if parent == "" || rec.Parent == parent {
+			out = append(out, rec)
+		}
+	}
+
+	sort.Slice(out, func(i, j int) bool {
+		if len(out[i].ID) != len(out[j].ID) {
+			return len(out[i].ID) < len(out[j].ID)
+		}
+		return out[i].ID < out[j].ID
+	})
+
+	return out
+}
+
+func (f *fakeNewRelicAPI) listData(kind string, parent string) []interface{} {
+	out := []interface{}{}
+
+	for _, rec := range f.list(kind, parent) {
+		out = append(out, rec.Data)
+	}
+
+	return out
+}
+
+var (
+	fakeRESTChannelsPath        = regexp.MustCompile(`^/v2/alerts_channels\.json$`)
+	fakeRESTChannelPath         = regexp.MustCompile(`^/v2/alerts_channels/(\d+)\.json$`)
+	fakeRESTPolicyChannelsPath  = regexp.MustCompile(`^/v2/alerts_policy_channels\.json$`)
+	fakeRESTPoliciesPath        = regexp.MustCompile(`^/v2/alerts_policies\.json$`)
+	fakeRESTConditionsPath      = regexp.MustCompile(`^/v2/(alerts_\w*conditions)\.json$`)
+	fakeRESTPolicyConditionPath = regexp.MustCompile(`^/v2/(alerts_\w*conditions)/policies/(\d+)\.json$`)
+	fakeRESTConditionPath       = regexp.MustCompile(`^/v2/(alerts_\w*conditions)/(\d+)\.json$`)
+	fakeRESTApplicationsPath    = regexp.MustCompile(`^/v2/applications\.json$`)
+	fakeRESTApplicationPath     = regexp.MustCompile(`^/v2/applications/(\d+)\.json$`)
+	fakeRESTDashboardsPath      = regexp.MustCompile(`^/v2/dashboards\.json$`)
+	fakeRESTDashboardPath       = regexp.MustCompile(`^/v2/dashboards/(\d+)\.json$`)
+)
+
+// handleREST serves the REST v2 API.
+// nolint:gocyclo
+func (f *fakeNewRelicAPI) handleREST(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	p := r.URL.Path
+	q := r.URL.Query()
+
+	switch {
+	case fakeRESTChannelsPath.MatchString(p) && r.Method == http.MethodGet:
+		channels := []interface{}{}
+		for _, rec := range f.list(fakeKindChannel, "") {
+			channels = append(channels, f.channelWithLinks(rec))
+		}
+		fakeWriteJSON(w, http.StatusOK, map[string]interface{}{"channels": channels})
+
+	case fakeRESTChannelsPath.MatchString(p) && r.Method == http.MethodPost:
+		var body struct {
+			Channel map[string]interface{} `json:"channel"`
+		}
+		if !fakeDecodeBody(w, r, &body) {
+			return
+		}
+
+		id := f.nextID()
+		body.Channel["id"] = id
+		rec := f.put(fakeKindChannel, strconv.Itoa(id), "", body.Channel)
+
+		fakeWriteJSON(w, http.StatusCreated, map[string]interface{}{"channels": []interface{}{f.channelWithLinks(rec)}})
+
+	case fakeRESTChannelPath.MatchString(p) && r.Method == http.MethodDelete:
+		id := fakeRESTChannelPath.FindStringSubmatch(p)[1]
+		rec := f.remove(fakeKindChannel, id)
+		if rec == nil {
+			fakeRESTNotFound(w)
+			return
+		}
+
+		for _, channels := range f.policyChannels {
+			delete(channels, id)
+		}
+
+		fakeWriteJSON(w, http.StatusOK, map[string]interface{}{"channel": rec.Data})
+
+	case fakeRESTPolicyChannelsPath.MatchString(p) && r.Method == http.MethodPut:
+		policyID := q.Get("policy_id")
+		if f.find(fakeKindPolicy, policyID) == nil {
+			fakeRESTNotFound(w)
+			return
+		}
+
+		if f.policyChannels[policyID] == nil {
+			f.policyChannels[policyID] = map[string]bool{}
+		}
+
+		for _, channelID := range strings.Split(q.Get("channel_ids"), ",") {
+			if f.find(fakeKindChannel, channelID) == nil {
+				fakeRESTError(w, http.StatusUnprocessableEntity, fmt.Sprintf("channel %s does not exist", channelID))
+				return
+			}
+			f.policyChannels[policyID][channelID] = true
+		}
+
+		fakeWriteJSON(w, http.StatusOK, map[string]interface{}{
+			"policy": map[string]interface{}{
+				"id":          fakeInt(policyID),
+				"channel_ids": f.channelIDsForPolicy(policyID),
+			},
+		})
+
+	case fakeRESTPolicyChannelsPath.MatchString(p) && r.Method == http.MethodDelete:
+		policyID := q.Get("policy_id")
+		channelID := q.Get("channel_id")
+		rec := f.find(fakeKindChannel, channelID)
+		if rec == nil || !f.policyChannels[policyID][channelID] {
+			fakeRESTNotFound(w)
+			return
+		}
+
+		delete(f.policyChannels[policyID], channelID)
+
+		fakeWriteJSON(w, http.StatusOK, map[string]interface{}{"channel": f.channelWithLinks(rec)})
+
+	case fakeRESTPoliciesPath.MatchString(p) && r.Method == http.MethodGet:
+		policies := []interface{}{}
+		for _, rec := range f.list(fakeKindPolicy, "") {
+			if name := q.Get("filter[name]"); name != "" && rec.Data["name"] != name {
+				continue
+			}
+			policies = append(policies, map[string]interface{}{
+				"id":                  fakeInt(rec.ID),
+				"name":                rec.Data["name"],
+				"incident_preference": rec.Data["incidentPreference"],
+			})
+		}
+		fakeWriteJSON(w, http.StatusOK, map[string]interface{}{"policies": policies})
+
+	case fakeRESTConditionsPath.MatchString(p) && r.Method == http.MethodGet:
+		kind := fakeRESTConditionsPath.FindStringSubmatch(p)[1]
+		f.listRESTConditions(w, kind, q.Get("policy_id"))
+
+	case fakeRESTPolicyConditionPath.MatchString(p) && r.Method == http.MethodGet:
+		m := fakeRESTPolicyConditionPath.FindStringSubmatch(p)
+		f.listRESTConditions(w, m[1], m[2])
+
+	case fakeRESTPolicyConditionPath.MatchString(p) && r.Method == http.MethodPost:
+		m := fakeRESTPolicyConditionPath.FindStringSubmatch(p)
+		kind, policyID := m[1], m[2]
+
+		key, ok := fakeRESTConditionKeys[kind]
+		if !ok {
+			fakeRESTNotFound(w)
+			return
+		}
+
+		if f.find(fakeKindPolicy, policyID) == nil {
+			fakeRESTNotFound(w)
+			return
+		}
+
+		body := map[string]map[string]interface{}{}
+		if !fakeDecodeBody(w, r, &body) {
+			return
+		}
+
+		id := f.nextID()
+		condition := body[key]
+		condition["id"] = id
+		f.put(kind, strconv.Itoa(id), policyID, condition)
+
+		fakeWriteJSON(w, http.StatusCreated, map[string]interface{}{key: condition})
+
+	case fakeRESTConditionPath.MatchString(p) && r.Method == http.MethodPut:
+		m := fakeRESTConditionPath.FindStringSubmatch(p)
+		kind, id := m[1], m[2]
+
+		key, ok := fakeRESTConditionKeys[kind]
+		rec := f.find(kind, id)
+		if !ok || rec == nil {
+			fakeRESTNotFound(w)
+			return
+		}
+
+		body := map[string]map[string]interface{}{}
+		if !fakeDecodeBody(w, r, &body) {
+			return
+		}
+
+		rec.Data = body[key]
+		rec.Data["id"] = fakeInt(id)
+
+		fakeWriteJSON(w, http.StatusOK, map[string]interface{}{key: rec.Data})
+
+	case fakeRESTConditionPath.MatchString(p) && r.Method == http.MethodDelete:
+		m := fakeRESTConditionPath.FindStringSubmatch(p)
+		kind, id := m[1], m[2]
+
+		// Location failure conditions are deleted through the generic
+		// conditions endpoint.
+		rec := f.remove(kind, id)
+		if rec == nil && kind == fakeKindCondition {
+			kind = fakeKindLocationFailureCondition
+			rec = f.remove(kind, id)
+		}
+
+		if rec == nil {
+			fakeRESTNotFound(w)
+			return
+		}
+
+		fakeWriteJSON(w, http.StatusOK, map[string]interface{}{fakeRESTConditionKeys[kind]: rec.Data})
+
+	case fakeRESTApplicationsPath.MatchString(p) && r.Method == http.MethodGet:
+		apps := []interface{}{}
+		for _, rec := range f.list(fakeKindApplication, "") {
+			if name := q.Get("filter[name]"); name != "" && !strings.Contains(rec.Data["name"].(string), name) {
+				continue
+			}
+			apps = append(apps, rec.Data)
+		}
+		fakeWriteJSON(w, http.StatusOK, map[string]interface{}{"applications": apps})
+
+	case fakeRESTApplicationPath.MatchString(p) && r.Method == http.MethodGet:
+		rec := f.find(fakeKindApplication, fakeRESTApplicationPath.FindStringSubmatch(p)[1])
+		if rec == nil {
+			fakeRESTNotFound(w)
+			return
+		}
+		fakeWriteJSON(w, http.StatusOK, map[string]interface{}{"application": rec.Data})
+
+	case fakeRESTApplicationPath.MatchString(p) && r.Method == http.MethodPut:
+		rec := f.find(fakeKindApplication, fakeRESTApplicationPath.FindStringSubmatch(p)[1])
+		if rec == nil {
+			fakeRESTNotFound(w)
+			return
+		}
+
+		var body struct {
+			Application map[string]interface{} `json:"application"`
+		}
+		if !fakeDecodeBody(w, r, &body) {
+			return
+		}
+
+		if name, ok := body.Application["name"]; ok && name != "" {
+			rec.Data["name"] = name
+		}
+		if settings, ok := body.Application["settings"].(map[string]interface{}); ok {
+			for k, v := range settings {
+				rec.Data["settings"].(map[string]interface{})[k] = v
+			}
+		}
+
+		fakeWriteJSON(w, http.StatusOK, map[string]interface{}{"application": rec.Data})
+
+	case fakeRESTDashboardsPath.MatchString(p) && r.Method == http.MethodPost:
+		var body struct {
+			Dashboard map[string]interface{} `json:"dashboard"`
+		}
+		if !fakeDecodeBody(w, r, &body) {
+			return
+		}
+
+		id := f.nextID()
+		body.Dashboard["id"] = id
+		body.Dashboard["ui_url"] = fmt.Sprintf("https://insights.newrelic.com/accounts/%d/dashboards/%d", f.AccountID, id)
+		f.assignWidgetIDs(body.Dashboard)
+		f.put(fakeKindDashboard, strconv.Itoa(id), "", body.Dashboard)
+
+		fakeWriteJSON(w, http.StatusOK, map[string]interface{}{"dashboard": body.Dashboard})
+
+	case fakeRESTDashboardPath.MatchString(p) && r.Method == http.MethodGet:
+		rec := f.find(fakeKindDashboard, fakeRESTDashboardPath.FindStringSubmatch(p)[1])
+		if rec == nil {
+			fakeRESTNotFound(w)
+			return
+		}
+		fakeWriteJSON(w, http.StatusOK, map[string]interface{}{"dashboard": rec.Data})
+
+	case fakeRESTDashboardPath.MatchString(p) && r.Method == http.MethodPut:
+		rec := f.find(fakeKindDashboard, fakeRESTDashboardPath.FindStringSubmatch(p)[1])
+		if rec == nil {
+			fakeRESTNotFound(w)
+			return
+		}
+
+		var body struct {
+			Dashboard map[string]interface{} `json:"dashboard"`
+		}
+		if !fakeDecodeBody(w, r, &body) {
+			return
+		}
+
+		body.Dashboard["id"] = rec.Data["id"]
+		body.Dashboard["ui_url"] = rec.Data["ui_url"]
+		f.assignWidgetIDs(body.Dashboard)
+		rec.Data = body.Dashboard
+
+		fakeWriteJSON(w, http.StatusOK, map[string]interface{}{"dashboard": rec.Data})
+
+	case fakeRESTDashboardPath.MatchString(p) && r.Method == http.MethodDelete:
+		rec := f.remove(fakeKindDashboard, fakeRESTDashboardPath.FindStringSubmatch(p)[1])
+		if rec == nil {
+			fakeRESTNotFound(w)
+			return
+		}
+		fakeWriteJSON(w, http.StatusOK, map[string]interface{}{"dashboard": rec.Data})
+
+	default:
+		fakeRESTError(w, http.StatusNotImplemented, fmt.Sprintf("%s %s is not implemented by the fake API", r.Method, p))
+	}
+}
+
+func (f *fakeNewRelicAPI) listRESTConditions(w http.ResponseWriter, kind string, policyID string) {
+	key, ok := fakeRESTConditionKeys[kind]
+	if !ok {
+		fakeRESTNotFound(w)
+		return
+	}
+
+	fakeWriteJSON(w, http.StatusOK, map[string]interface{}{key + "s": f.listData(kind, policyID)})
+}
+
+// channelWithLinks returns a channel along with the policies it is attached to.
+func (f *fakeNewRelicAPI) channelWithLinks(rec *fakeRecord) map[string]interface{} {
+	policyIDs := []int{}
+	for policyID, channels := range f.policyChannels {
+		if channels[rec.ID] {
+			policyIDs = append(policyIDs, fakeInt(policyID))
+		}
+	}
+	sort.Ints(policyIDs)
+
+	out := fakeCopy(rec.Data).(map[string]interface{})
+	out["links"] = map[string]interface{}{"policy_ids": policyIDs}
+
+	return out
+}
+
+func (f *fakeNewRelicAPI) channelIDsForPolicy(policyID string) []int {
+	ids := []int{}
+	for channelID, linked := range f.policyChannels[policyID] {
+		if linked {
+			ids = append(ids, fakeInt(channelID))
+		}
+	}
+	sort.Ints(ids)
+
+	return ids
+}
+
+func (f *fakeNewRelicAPI) assignWidgetIDs(dashboard map[string]interface{}) {
+	widgets, _ := dashboard["widgets"].([]interface{})
+	for _, w := range widgets {
+		widget := w.(map[string]interface{})
+		if fakeInt(widget["widget_id"]) == 0 {
+			widget["widget_id"] = f.nextID()
+		}
+	}
+}
+
+var (
+	fakeInfraConditionsPath = regexp.MustCompile(`^/infrastructure/v2/alerts/conditions$`)
+	fakeInfraConditionPath  = regexp.MustCompile(`^/infrastructure/v2/alerts/conditions/(\d+)$`)
+)
+
+// handleInfrastructure serves the Infrastructure alert conditions API.
+func (f *fakeNewRelicAPI) handleInfrastructure(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	p := r.URL.Path
+
+	switch {
+	case fakeInfraConditionsPath.MatchString(p) && r.Method == http.MethodGet:
+		fakeWriteJSON(w, http.StatusOK, map[string]interface{}{"data": f.listData(fakeKindInfraCondition, r.URL.Query().Get("policy_id"))})
+
+	case fakeInfraConditionsPath.MatchString(p) && r.Method == http.MethodPost:
+		var body struct {
+			Data map[string]interface{} `json:"data"`
+		}
+		if !fakeDecodeBody(w, r, &body) {
+			return
+		}
+
+		policyID := strconv.Itoa(fakeInt(body.Data["policy_id"]))
+		if f.find(fakeKindPolicy, policyID) == nil {
+			fakeRESTNotFound(w)
+			return
+		}
+
+		id := f.nextID()
+		now := time.Now().UnixNano() / int64(time.Millisecond)
+		body.Data["id"] = id
+		body.Data["created_at_epoch_millis"] = now
+		body.Data["updated_at_epoch_millis"] = now
+		f.put(fakeKindInfraCondition, strconv.Itoa(id), policyID, body.Data)
+
+		fakeWriteJSON(w, http.StatusCreated, map[string]interface{}{"data": body.Data})
+
+	case fakeInfraConditionPath.MatchString(p):
+		id := fakeInfraConditionPath.FindStringSubmatch(p)[1]
+		rec := f.find(fakeKindInfraCondition, id)
+		if rec == nil {
+			fakeRESTNotFound(w)
+			return
+		}
+
+		switch r.Method {
+		case http.MethodGet:
+			fakeWriteJSON(w, http.StatusOK, map[string]interface{}{"data": rec.Data})
+		case http.MethodPut:
+			var body struct {
+				Data map[string]interface{} `json:"data"`
+			}
+			if !fakeDecodeBody(w, r, &body) {
+				return
+			}
+
+			body.Data["id"] = rec.Data["id"]
+			body.Data["created_at_epoch_millis"] = rec.Data["created_at_epoch_millis"]
+			body.Data["updated_at_epoch_millis"] = time.Now().UnixNano() / int64(time.Millisecond)
+			rec.Data = body.Data
+
+			fakeWriteJSON(w, http.StatusOK, map[string]interface{}{"data": rec.Data})
+		case http.MethodDelete:
+			f.remove(fakeKindInfraCondition, id)
+			w.WriteHeader(http.StatusNoContent)
+		}
+
+	default:
+		fakeRESTError(w, http.StatusNotImplemented, fmt.Sprintf("%s %s is not implemented by the fake API", r.Method, p))
+	}
+}
+
+var (
+	fakeSyntheticsMonitorsPath          = regexp.MustCompile(`^/synthetics/api/v4/monitors$`)
+	fakeSyntheticsMonitorPath           = regexp.MustCompile(`^/synthetics/api/v4/monitors/([^/]+)$`)
+	fakeSyntheticsMonitorScriptPath     = regexp.MustCompile(`^/synthetics/api/v4/monitors/([^/]+)/script$`)
+	fakeSyntheticsSecureCredentialsPath = regexp.MustCompile(`^/synthetics/api/v1/secure-credentials$`)
+	fakeSyntheticsSecureCredentialPath  = regexp.MustCompile(`^/synthetics/api/v1/secure-credentials/([^/]+)$`)
+	fakeSyntheticsLocationsPath         = regexp.MustCompile(`^/synthetics/api/v1/locations$`)
+)
+
+// handleSynthetics serves the Synthetics monitor, script, secure credential and
+// location APIs.
+// nolint:gocyclo
+func (f *fakeNewRelicAPI) handleSynthetics(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	p := r.URL.Path
+	now := time.Now().Format(fakeSyntheticsTimestamp)
+
+	switch {
+	case fakeSyntheticsMonitorsPath.MatchString(p) && r.Method == http.MethodGet:
+		monitors := f.listData(fakeKindMonitor, "")
+		fakeWriteJSON(w, http.StatusOK, map[string]interface{}{"monitors": monitors, "count": len(monitors)})
+
+	case fakeSyntheticsMonitorsPath.MatchString(p) && r.Method == http.MethodPost:
+		monitor := map[string]interface{}{}
+		if !fakeDecodeBody(w, r, &monitor) {
+			return
+		}
+
+		id := fmt.Sprintf("00000000-0000-0000-0000-%012d", f.nextID())
+		monitor["id"] = id
+		monitor["apiVersion"] = "LATEST"
+		monitor["createdAt"] = now
+		monitor["modifiedAt"] = now
+		f.put(fakeKindMonitor, id, "", monitor)
+
+		w.Header().Set("Location", f.server.URL+"/synthetics/api/v4/monitors/"+id)
+		w.WriteHeader(http.StatusCreated)
+
+	case fakeSyntheticsMonitorPath.MatchString(p):
+		id := fakeSyntheticsMonitorPath.FindStringSubmatch(p)[1]
+		rec := f.find(fakeKindMonitor, id)
+		if rec == nil {
+			fakeSyntheticsError(w, http.StatusNotFound, "monitor not found")
+			return
+		}
+
+		switch r.Method {
+		case http.MethodGet:
+			fakeWriteJSON(w, http.StatusOK, rec.Data)
+		case http.MethodPut:
+			monitor := map[string]interface{}{}
+			if !fakeDecodeBody(w, r, &monitor) {
+				return
+			}
+
+			monitor["id"] = id
+			monitor["apiVersion"] = rec.Data["apiVersion"]
+			monitor["createdAt"] = rec.Data["createdAt"]
+			monitor["modifiedAt"] = now
+			rec.Data = monitor
+
+			w.WriteHeader(http.StatusNoContent)
+		case http.MethodDelete:
+			f.remove(fakeKindMonitor, id)
+			f.remove(fakeKindMonitorScript, id)
+			w.WriteHeader(http.StatusNoContent)
+		}
+
+	case fakeSyntheticsMonitorScriptPath.MatchString(p):
+		id := fakeSyntheticsMonitorScriptPath.FindStringSubmatch(p)[1]
+		if f.find(fakeKindMonitor, id) == nil {
+			fakeSyntheticsError(w, http.StatusNotFound, "monitor not found")
+			return
+		}
+
+		switch r.Method {
+		case http.MethodGet:
+			rec := f.find(fakeKindMonitorScript, id)
+			if rec == nil {
+				fakeSyntheticsError(w, http.StatusNotFound, "script not found")
+				return
+			}
+			fakeWriteJSON(w, http.StatusOK, rec.Data)
+		case http.MethodPut:
+			script := map[string]interface{}{}
+			if !fakeDecodeBody(w, r, &script) {
+				return
+			}
+
+			f.put(fakeKindMonitorScript, id, id, script)
+			w.WriteHeader(http.StatusNoContent)
+		}
+
+	case fakeSyntheticsSecureCredentialsPath.MatchString(p) && r.Method == http.MethodGet:
+		credentials := []interface{}{}
+		for _, rec := range f.list(fakeKindSecureCredential, "") {
+			credentials = append(credentials, fakeRedactSecureCredential(rec.Data))
+		}
+		fakeWriteJSON(w, http.StatusOK, map[string]interface{}{"secureCredentials": credentials, "count": len(credentials)})
+
+	case fakeSyntheticsSecureCredentialsPath.MatchString(p) && r.Method == http.MethodPost:
+		credential := map[string]interface{}{}
+		if !fakeDecodeBody(w, r, &credential) {
+			return
+		}
+
+		key, _ := credential["key"].(string)
+		if f.find(fakeKindSecureCredential, key) != nil {
+			fakeSyntheticsError(w, http.StatusConflict, fmt.Sprintf("secure credential %s already exists", key))
+			return
+		}
+
+		credential["createdAt"] = now
+		credential["lastUpdated"] = now
+		f.put(fakeKindSecureCredential, key, "", credential)
+
+		w.WriteHeader(http.StatusCreated)
+
+	case fakeSyntheticsSecureCredentialPath.MatchString(p):
+		key := fakeSyntheticsSecureCredentialPath.FindStringSubmatch(p)[1]
+		rec := f.find(fakeKindSecureCredential, key)
+		if rec == nil {
+			fakeSyntheticsError(w, http.StatusNotFound, "secure credential not found")
+			return
+		}
+
+		switch r.Method {
+		case http.MethodGet:
+			fakeWriteJSON(w, http.StatusOK, fakeRedactSecureCredential(rec.Data))
+		case http.MethodPut:
+			credential := map[string]interface{}{}
+			if !fakeDecodeBody(w, r, &credential) {
+				return
+			}
+
+			credential["createdAt"] = rec.Data["createdAt"]
+			credential["lastUpdated"] = now
+			rec.Data = credential
+
+			w.WriteHeader(http.StatusNoContent)
+		case http.MethodDelete:
+			f.remove(fakeKindSecureCredential, key)
+			w.WriteHeader(http.StatusNoContent)
+		}
+
+	case fakeSyntheticsLocationsPath.MatchString(p) && r.Method == http.MethodGet:
+		fakeWriteJSON(w, http.StatusOK, []interface{}{
+			map[string]interface{}{"name": "AWS_US_EAST_1", "label": "Washington, DC, USA", "private": false},
+			map[string]interface{}{"name": "AWS_US_WEST_1", "label": "San Francisco, CA, USA", "private": false},
+		})
+
+	default:
+		fakeSyntheticsError(w, http.StatusNotImplemented, fmt.Sprintf("%s %s is not implemented by the fake API", r.Method, p))
+	}
+}
+
+// fakeRedactSecureCredential strips the value, which the Synthetics API never
+// returns.
+func fakeRedactSecureCredential(in map[string]interface{}) map[string]interface{} {
+	out := fakeCopy(in).(map[string]interface{})
+	delete(out, "value")
+
+	return out
+}
+
+// handleInsightsInsert serves the Insights insert API.
+func (f *fakeNewRelicAPI) handleInsightsInsert(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if r.Method != http.MethodPost || !strings.HasSuffix(r.URL.Path, "/events") {
+		fakeWriteJSON(w, http.StatusNotFound, map[string]interface{}{"error": "not found"})
+		return
+	}
+
+	events := []map[string]interface{}{}
+	if !fakeDecodeBody(w, r, &events) {
+		return
+	}
+
+	for _, event := range events {
+		id := strconv.Itoa(f.nextID())
+		f.put(fakeKindInsightsEvent, id, "", event)
+	}
+
+	fakeWriteJSON(w, http.StatusOK, map[string]interface{}{"success": true})
+}
+
+// fakeDecodeBody decodes a JSON request body, transparently handling gzip
+// compression. It writes an error response and returns false on failure.
+func fakeDecodeBody(w http.ResponseWriter, r *http.Request, v interface{}) bool {
+	var reader io.Reader = r.Body
+
+	if r.Header.Get("Content-Encoding") == "gzip" {
+		gz, err := gzip.NewReader(r.Body)
+		if err != nil {
+			fakeRESTError(w, http.StatusBadRequest, err.Error())
+			return false
+		}
+		defer gz.Close()
+
+		reader = gz
+	}
+
+	body, err := ioutil.ReadAll(reader)
+	if err != nil {
+		fakeRESTError(w, http.StatusBadRequest, err.Error())
+		return false
+	}
+
+	if err := json.Unmarshal(body, v); err != nil {
+		fakeRESTError(w, http.StatusBadRequest, err.Error())
+		return false
+	}
+
+	return true
+}
+
+func fakeWriteJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func fakeRESTError(w http.ResponseWriter, status int, message string) {
+	fakeWriteJSON(w, status, map[string]interface{}{
+		"error": map[string]interface{}{"title": message},
+	})
+}
+
+func fakeRESTNotFound(w http.ResponseWriter) {
+	fakeRESTError(w, http.StatusNotFound, "Resource not found")
+}
+
+func fakeSyntheticsError(w http.ResponseWriter, status int, message string) {
+	fakeWriteJSON(w, status, map[string]interface{}{"error": message})
+}
+
+// fakeInt converts a JSON number or numeric string to an int.
+func fakeInt(v interface{}) int {
+	switch n := v.(type) {
+	case int:
+		return n
+	case int64:
+		return int(n)
+	case float64:
+		return int(n)
+	case string:
+		i, _ := strconv.Atoi(n)
+		return i
+	case json.Number:
+		i, _ := n.Int64()
+		return int(i)
+	}
+
+	return 0
+}
+
+// fakeString converts a JSON value to a string.
+func fakeString(v interface{}) string {
+	switch s := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return s
+	case float64:
+		return strconv.FormatFloat(s, 'f', -1, 64)
+	}
+
+	return fmt.Sprintf("%v", v)
+}
+
+// fakeCopy deep copies decoded JSON data.
+func fakeCopy(v interface{}) interface{} {
+	switch t := v.(type) {
+	case map[string]interface{}:
+		out := make(map[string]interface{}, len(t))
+		for k, val := range t {
+			out[k] = fakeCopy(val)
+		}
+		return out
+	case []interface{}:
+		out := make([]interface{}, len(t))
+		for i, val := range t {
+			out[i] = fakeCopy(val)
+		}
+		return out
+	}
+
+	return v
+}
@@ -0,0 +1,2238 @@
+// +build unit
+
+package newrelic
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// fakeGraphQLHandler resolves a single NerdGraph operation. It returns the
+// value of the "data" member of the response, or an error which is reported
+// in the "errors" member.
+type fakeGraphQLHandler func(f *fakeNewRelicAPI, vars map[string]interface{}) (interface{}, error)
+
+// fakeGraphQLOperation associates a NerdGraph field with its handler. Fields
+// are matched by name against the query document, so operations are checked
+// in order and the first match wins.
+type fakeGraphQLOperation struct {
+	name    string
+	pattern *regexp.Regexp
+	handler fakeGraphQLHandler
+}
+
+func fakeGraphQLField(name string, handler fakeGraphQLHandler) fakeGraphQLOperation {
+	return fakeGraphQLOperation{
+		name:    name,
+		pattern: regexp.MustCompile(`\b` + name + `\s*[({]`),
+		handler: handler,
+	}
+}
+
+var fakeGraphQLMutations = []fakeGraphQLOperation{
+	fakeGraphQLField("alertsPolicyCreate", (*fakeNewRelicAPI).alertsPolicyCreate),
+	fakeGraphQLField("alertsPolicyUpdate", (*fakeNewRelicAPI).alertsPolicyUpdate),
+	fakeGraphQLField("alertsPolicyDelete", (*fakeNewRelicAPI).alertsPolicyDelete),
+	fakeGraphQLField("alertsNotificationChannelUpdate", (*fakeNewRelicAPI).alertsNotificationChannelUpdate),
+	fakeGraphQLField("alertsNrqlConditionBaselineCreate", fakeNrqlConditionCreate("BASELINE")),
+	fakeGraphQLField("alertsNrqlConditionStaticCreate", fakeNrqlConditionCreate("STATIC")),
+	fakeGraphQLField("alertsNrqlConditionOutlierCreate", fakeNrqlConditionCreate("OUTLIER")),
+	fakeGraphQLField("alertsNrqlConditionBaselineUpdate", fakeNrqlConditionUpdate("BASELINE")),
+	fakeGraphQLField("alertsNrqlConditionStaticUpdate", fakeNrqlConditionUpdate("STATIC")),
+	fakeGraphQLField("alertsNrqlConditionOutlierUpdate", fakeNrqlConditionUpdate("OUTLIER")),
+	fakeGraphQLField("alertsConditionDelete", (*fakeNewRelicAPI).alertsConditionDelete),
+	fakeGraphQLField("alertsMutingRuleCreate", (*fakeNewRelicAPI).alertsMutingRuleCreate),
+	fakeGraphQLField("alertsMutingRuleUpdate", (*fakeNewRelicAPI).alertsMutingRuleUpdate),
+	fakeGraphQLField("alertsMutingRuleDelete", (*fakeNewRelicAPI).alertsMutingRuleDelete),
+	fakeGraphQLField("apiAccessCreateKeys", (*fakeNewRelicAPI).apiAccessCreateKeys),
+	fakeGraphQLField("apiAccessUpdateKeys", (*fakeNewRelicAPI).apiAccessUpdateKeys),
+	fakeGraphQLField("apiAccessDeleteKeys", (*fakeNewRelicAPI).apiAccessDeleteKeys),
+	fakeGraphQLField("eventsToMetricsCreateRule", (*fakeNewRelicAPI).eventsToMetricsCreateRule),
+	fakeGraphQLField("eventsToMetricsUpdateRule", (*fakeNewRelicAPI).eventsToMetricsUpdateRule),
+	fakeGraphQLField("eventsToMetricsDeleteRule", (*fakeNewRelicAPI).eventsToMetricsDeleteRule),
+	fakeGraphQLField("taggingAddTagsToEntity", (*fakeNewRelicAPI).taggingAddTagsToEntity),
+	fakeGraphQLField("taggingReplaceTagsOnEntity", (*fakeNewRelicAPI).taggingReplaceTagsOnEntity),
+	fakeGraphQLField("taggingDeleteTagFromEntity", (*fakeNewRelicAPI).taggingDeleteTagFromEntity),
+	fakeGraphQLField("taggingDeleteTagValuesFromEntity", (*fakeNewRelicAPI).taggingDeleteTagValuesFromEntity),
+	fakeGraphQLField("dashboardCreate", (*fakeNewRelicAPI).dashboardCreate),
+	fakeGraphQLField("dashboardUpdate", (*fakeNewRelicAPI).dashboardUpdate),
+	fakeGraphQLField("dashboardDelete", (*fakeNewRelicAPI).dashboardDelete),
+	fakeGraphQLField("workloadCreate", (*fakeNewRelicAPI).workloadCreate),
+	fakeGraphQLField("workloadUpdate", (*fakeNewRelicAPI).workloadUpdate),
+	fakeGraphQLField("workloadDelete", (*fakeNewRelicAPI).workloadDelete),
+	fakeGraphQLField("aiNotificationsCreateDestination", (*fakeNewRelicAPI).aiNotificationsCreateDestination),
+	fakeGraphQLField("aiNotificationsUpdateDestination", (*fakeNewRelicAPI).aiNotificationsUpdateDestination),
+	fakeGraphQLField("aiNotificationsDeleteDestination", (*fakeNewRelicAPI).aiNotificationsDeleteDestination),
+	fakeGraphQLField("aiNotificationsCreateChannel", (*fakeNewRelicAPI).aiNotificationsCreateChannel),
+	fakeGraphQLField("aiNotificationsUpdateChannel", (*fakeNewRelicAPI).aiNotificationsUpdateChannel),
+	fakeGraphQLField("aiNotificationsDeleteChannel", (*fakeNewRelicAPI).aiNotificationsDeleteChannel),
+	fakeGraphQLField("aiWorkflowsCreateWorkflow", (*fakeNewRelicAPI).aiWorkflowsCreateWorkflow),
+	fakeGraphQLField("aiWorkflowsUpdateWorkflow", (*fakeNewRelicAPI).aiWorkflowsUpdateWorkflow),
+	fakeGraphQLField("aiWorkflowsDeleteWorkflow", (*fakeNewRelicAPI).aiWorkflowsDeleteWorkflow),
+	fakeGraphQLField("accountManagementCreateAccount", (*fakeNewRelicAPI).accountManagementCreateAccount),
+	fakeGraphQLField("accountManagementUpdateAccount", (*fakeNewRelicAPI).accountManagementUpdateAccount),
+	fakeGraphQLField("userManagementCreateUser", (*fakeNewRelicAPI).userManagementCreateUser),
+	fakeGraphQLField("userManagementUpdateUser", (*fakeNewRelicAPI).userManagementUpdateUser),
+	fakeGraphQLField("userManagementDeleteUser", (*fakeNewRelicAPI).userManagementDeleteUser),
+	fakeGraphQLField("userManagementCreateGroup", (*fakeNewRelicAPI).userManagementCreateGroup),
+	fakeGraphQLField("userManagementUpdateGroup", (*fakeNewRelicAPI).userManagementUpdateGroup),
+	fakeGraphQLField("userManagementDeleteGroup", (*fakeNewRelicAPI).userManagementDeleteGroup),
+	fakeGraphQLField("userManagementAddUsersToGroups", fakeGroupUsersUpdate("userManagementAddUsersToGroups", "addUsersToGroupsOptions", true)),
+	fakeGraphQLField("userManagementRemoveUsersFromGroups", fakeGroupUsersUpdate("userManagementRemoveUsersFromGroups", "removeUsersFromGroupsOptions", false)),
+	fakeGraphQLField("authorizationManagementGrantAccess", fakeGroupAccessUpdate("authorizationManagementGrantAccess", "grantAccessOptions", true)),
+	fakeGraphQLField("authorizationManagementRevokeAccess", fakeGroupAccessUpdate("authorizationManagementRevokeAccess", "revokeAccessOptions", false)),
+}
+
+var fakeGraphQLQueries = []fakeGraphQLOperation{
+	fakeGraphQLField("destinations", (*fakeNewRelicAPI).aiNotificationsDestinations),
+	fakeGraphQLField("channels", (*fakeNewRelicAPI).aiNotificationsChannels),
+	fakeGraphQLField("workflows", (*fakeNewRelicAPI).aiWorkflowsWorkflows),
+	fakeGraphQLField("policiesSearch", (*fakeNewRelicAPI).policiesSearch),
+	fakeGraphQLField("policy", (*fakeNewRelicAPI).policy),
+	fakeGraphQLField("nrqlConditionsSearch", (*fakeNewRelicAPI).nrqlConditionsSearch),
+	fakeGraphQLField("nrqlCondition", (*fakeNewRelicAPI).nrqlCondition),
+	fakeGraphQLField("mutingRules", (*fakeNewRelicAPI).mutingRules),
+	fakeGraphQLField("mutingRule", (*fakeNewRelicAPI).mutingRule),
+	fakeGraphQLField("key", (*fakeNewRelicAPI).apiAccessKey),
+	fakeGraphQLField("rulesById", (*fakeNewRelicAPI).eventsToMetricsRulesByID),
+	fakeGraphQLField("allRules", (*fakeNewRelicAPI).eventsToMetricsAllRules),
+	fakeGraphQLField("collections", (*fakeNewRelicAPI).workloadCollections),
+	fakeGraphQLField("collection", (*fakeNewRelicAPI).workloadCollection),
+	fakeGraphQLField("entitySearch", (*fakeNewRelicAPI).entitySearch),
+	fakeGraphQLField("entity", (*fakeNewRelicAPI).entity),
+	fakeGraphQLField("accounts", (*fakeNewRelicAPI).accounts),
+	fakeGraphQLField("managedAccounts", (*fakeNewRelicAPI).managedAccounts),
+	// Group role queries also select groups, and group queries their users.
+	fakeGraphQLField("roles", (*fakeNewRelicAPI).authorizationManagementGroups),
+	fakeGraphQLField("groups", (*fakeNewRelicAPI).userManagementGroups),
+	fakeGraphQLField("users", (*fakeNewRelicAPI).userManagementUsers),
+}
+
+// fakeImmutableTagKeys are the tag keys New Relic manages on an entity's
+// behalf, which cannot be changed through the tagging API.
+var fakeImmutableTagKeys = map[string]bool{
+	"account":          true,
+	"accountId":        true,
+	"guid":             true,
+	"language":         true,
+	"trustedAccountId": true,
+}
+
+// fakeGraphQLNotFound is reported when a NerdGraph operation references an
+// object which does not exist. Its shape satisfies the not found detection of
+// the alerts client.
+type fakeGraphQLNotFound struct{}
+
+func (fakeGraphQLNotFound) Error() string { return "Not Found" }
+
+// handleNerdGraph serves the NerdGraph API.
+func (f *fakeNewRelicAPI) handleNerdGraph(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	var body struct {
+		Query     string                 `json:"query"`
+		Variables map[string]interface{} `json:"variables"`
+	}
+	if !fakeDecodeBody(w, r, &body) {
+		return
+	}
+
+	if body.Variables == nil {
+		body.Variables = map[string]interface{}{}
+	}
+
+	operations := fakeGraphQLQueries
+	if strings.HasPrefix(strings.TrimSpace(body.Query), "mutation") {
+		operations = fakeGraphQLMutations
+	}
+
+	for _, op := range operations {
+		if !op.pattern.MatchString(body.Query) {
+			continue
+		}
+
+		f.graphQLCalls[op.name]++
+
+		data, err := op.handler(f, body.Variables)
+		if err != nil {
+			fakeWriteGraphQLError(w, err)
+			return
+		}
+
+		fakeWriteJSON(w, http.StatusOK, map[string]interface{}{"data": data})
+		return
+	}
+
+	fakeWriteGraphQLError(w, fmt.Errorf("operation is not implemented by the fake API: %s", body.Query))
+}
+
+func fakeWriteGraphQLError(w http.ResponseWriter, err error) {
+	gqlErr := map[string]interface{}{"message": err.Error()}
+
+	if _, ok := err.(fakeGraphQLNotFound); ok {
+		gqlErr["downstreamResponse"] = []interface{}{
+			map[string]interface{}{
+				"message":    "Not Found",
+				"extensions": map[string]interface{}{"code": "BAD_USER_INPUT"},
+			},
+		}
+	}
+
+	fakeWriteJSON(w, http.StatusOK, map[string]interface{}{
+		"data":   nil,
+		"errors": []interface{}{gqlErr},
+	})
+}
+
+// fakeActorAccount wraps a value in the actor.account envelope used by
+// account scoped NerdGraph queries.
+func fakeActorAccount(path []string, value interface{}) interface{} {
+	for i := len(path) - 1; i >= 0; i-- {
+		value = map[string]interface{}{path[i]: value}
+	}
+
+	return map[string]interface{}{
+		"actor": map[string]interface{}{"account": value},
+	}
+}
+
+func fakeMap(v interface{}) map[string]interface{} {
+	m, _ := v.(map[string]interface{})
+	if m == nil {
+		m = map[string]interface{}{}
+	}
+
+	return m
+}
+
+func fakeList(v interface{}) []interface{} {
+	l, _ := v.([]interface{})
+	return l
+}
+
+// fakeField returns the first present value among the given keys. Some
+// client types have no JSON tags and are sent with Go field names.
+func fakeField(m map[string]interface{}, keys ...string) interface{} {
+	for _, k := range keys {
+		if v, ok := m[k]; ok {
+			return v
+		}
+	}
+
+	return nil
+}
+
+func fakeNow() string {
+	return time.Now().UTC().Format(time.RFC3339)
+}
+
+func fakeNowMillis() int64 {
+	return time.Now().UnixNano() / int64(time.Millisecond)
+}
+
+//
+// Alert policies
+//
+
+func (f *fakeNewRelicAPI) alertsPolicyCreate(vars map[string]interface{}) (interface{}, error) {
+	policy := fakeMap(vars["policy"])
+
+	id := strconv.Itoa(f.nextID())
+	policy["id"] = id
+	policy["accountId"] = fakeInt(vars["accountID"])
+	f.put(fakeKindPolicy, id, "", policy)
+
+	return map[string]interface{}{"alertsPolicyCreate": policy}, nil
+}
+
+func (f *fakeNewRelicAPI) alertsPolicyUpdate(vars map[string]interface{}) (interface{}, error) {
+	rec := f.find(fakeKindPolicy, fakeString(vars["policyID"]))
+	if rec == nil {
+		return nil, fakeGraphQLNotFound{}
+	}
+
+	for k, v := range fakeMap(vars["policy"]) {
+		rec.Data[k] = v
+	}
+
+	return map[string]interface{}{"alertsPolicyUpdate": rec.Data}, nil
+}
+
+func (f *fakeNewRelicAPI) alertsPolicyDelete(vars map[string]interface{}) (interface{}, error) {
+	id := fakeString(vars["policyID"])
+	if f.remove(fakeKindPolicy, id) == nil {
+		return nil, fakeGraphQLNotFound{}
+	}
+
+	// Deleting a policy removes everything scoped to it.
+	for _, kind := range []string{
+		fakeKindCondition,
+		fakeKindInfraCondition,
+		fakeKindLocationFailureCondition,
+		fakeKindNrqlCondition,
+		fakeKindPluginsCondition,
+		fakeKindSyntheticsCondition,
+	} {
+		for _, rec := range f.list(kind, id) {
+			f.remove(kind, rec.ID)
+		}
+	}
+	delete(f.policyChannels, id)
+
+	return map[string]interface{}{"alertsPolicyDelete": map[string]interface{}{"id": id}}, nil
+}
+
+func (f *fakeNewRelicAPI) policy(vars map[string]interface{}) (interface{}, error) {
+	rec := f.find(fakeKindPolicy, fakeString(vars["policyID"]))
+	if rec == nil {
+		return nil, fakeGraphQLNotFound{}
+	}
+
+	return fakeActorAccount([]string{"alerts", "policy"}, rec.Data), nil
+}
+
+func (f *fakeNewRelicAPI) policiesSearch(vars map[string]interface{}) (interface{}, error) {
+	return fakeActorAccount([]string{"alerts", "policiesSearch"}, map[string]interface{}{
+		"nextCursor": nil,
+		"policies":   f.listData(fakeKindPolicy, ""),
+		"totalCount": len(f.records[fakeKindPolicy]),
+	}), nil
+}
+
+//
+// NRQL alert conditions
+//
+
+// alertsNotificationChannelUpdate applies a NerdGraph channel update to the
+// channel stored by the REST API, translating it back into REST configuration.
+func (f *fakeNewRelicAPI) alertsNotificationChannelUpdate(vars map[string]interface{}) (interface{}, error) {
+	rec := f.find(fakeKindChannel, fakeString(vars["id"]))
+	if rec == nil {
+		return map[string]interface{}{
+			"alertsNotificationChannelUpdate": map[string]interface{}{
+				"error": map[string]interface{}{"description": "channel not found", "errorType": "NOT_FOUND"},
+			},
+		}, nil
+	}
+
+	config := fakeMap(rec.Data["configuration"])
+	if config == nil {
+		config = map[string]interface{}{}
+	}
+
+	join := func(v interface{}) string {
+		values := []string{}
+		for _, s := range fakeList(v) {
+			values = append(values, fakeString(s))
+		}
+		return strings.Join(values, ",")
+	}
+
+	var input map[string]interface{}
+
+	// Like the API, fields omitted from the update are left untouched and
+	// fields sent as null are cleared.
+	update := func(key string, field string) {
+		if v, ok := input[field]; ok {
+			if v == nil {
+				delete(config, key)
+			} else {
+				config[key] = v
+			}
+		}
+	}
+
+	for channelType, v := range fakeMap(vars["notificationChannel"]) {
+		input = fakeMap(v)
+
+		switch channelType {
+		case "email":
+			config["recipients"] = join(input["emails"])
+			if input["includeJson"] == true {
+				config["include_json_attachment"] = "1"
+			} else if _, ok := config["include_json_attachment"]; ok {
+				config["include_json_attachment"] = "0"
+			}
+		case "opsGenie":
+			config["recipients"] = join(input["recipients"])
+			config["tags"] = join(input["tags"])
+			config["teams"] = join(input["teams"])
+			update("region", "dataCenterRegion")
+			update("api_key", "apiKey")
+		case "pagerDuty":
+			if input["apiKey"] != nil {
+				config["service_key"] = input["apiKey"]
+			}
+		case "victorOps":
+			update("key", "key")
+			update("route_key", "routeKey")
+		case "slack":
+			update("channel", "teamChannel")
+			update("url", "url")
+		case "webhook":
+			if v, ok := input["baseUrl"]; ok {
+				config["base_url"] = v
+			}
+			if v, ok := input["basicAuth"]; ok {
+				delete(config, "auth_username")
+				delete(config, "auth_password")
+				if auth, ok := v.(map[string]interface{}); ok {
+					config["auth_username"] = auth["username"]
+					config["auth_password"] = auth["password"]
+				}
+			}
+			if v, ok := input["customHttpHeaders"]; ok {
+				headers := map[string]interface{}{}
+				for _, h := range fakeList(v) {
+					headers[fakeString(fakeMap(h)["name"])] = fakeMap(h)["value"]
+				}
+				config["headers"] = headers
+			}
+			if v, ok := input["customPayloadBody"]; ok {
+				delete(config, "payload")
+				delete(config, "payload_type")
+				if body := fakeString(v); body != "" {
+					payload := map[string]interface{}{}
+					if input["customPayloadType"] == "FORM" {
+						values, err := url.ParseQuery(body)
+						if err != nil {
+							return nil, err
+						}
+						for k := range values {
+							payload[k] = values.Get(k)
+						}
+						config["payload_type"] = "application/x-www-form-urlencoded"
+					} else {
+						if err := json.Unmarshal([]byte(body), &payload); err != nil {
+							return nil, err
+						}
+						config["payload_type"] = "application/json"
+					}
+					config["payload"] = payload
+				}
+			}
+		default:
+			return nil, fmt.Errorf("fake API does not support updating %s channels", channelType)
+		}
+	}
+
+	rec.Data["name"] = input["name"]
+	rec.Data["configuration"] = config
+
+	return map[string]interface{}{
+		"alertsNotificationChannelUpdate": map[string]interface{}{
+			"notificationChannel": map[string]interface{}{"id": rec.ID, "name": rec.Data["name"], "type": rec.Data["type"]},
+			"error":               nil,
+		},
+	}, nil
+}
+
+func fakeNrqlConditionCreate(conditionType string) fakeGraphQLHandler {
+	return func(f *fakeNewRelicAPI, vars map[string]interface{}) (interface{}, error) {
+		policyID := fakeString(vars["policyId"])
+		if f.find(fakeKindPolicy, policyID) == nil {
+			return nil, fakeGraphQLNotFound{}
+		}
+
+		condition := fakeMap(vars["condition"])
+
+		id := strconv.Itoa(f.nextID())
+		condition["id"] = id
+		condition["policyId"] = policyID
+		condition["type"] = conditionType
+		f.put(fakeKindNrqlCondition, id, policyID, condition)
+
+		if f.nrqlConditionSearchDelay > 0 {
+			f.unindexedNrqlConditions[id] = f.nrqlConditionSearchDelay
+		}
+
+		field := fmt.Sprintf("alertsNrqlCondition%sCreate", fakeTitle(conditionType))
+		return map[string]interface{}{field: condition}, nil
+	}
+}
+
+func fakeNrqlConditionUpdate(conditionType string) fakeGraphQLHandler {
+	return func(f *fakeNewRelicAPI, vars map[string]interface{}) (interface{}, error) {
+		rec := f.find(fakeKindNrqlCondition, fakeString(vars["id"]))
+		if rec == nil {
+			return nil, fakeGraphQLNotFound{}
+		}
+
+		condition := fakeMap(vars["condition"])
+		condition["id"] = rec.ID
+		condition["policyId"] = rec.Parent
+		condition["type"] = conditionType
+		rec.Data = condition
+
+		field := fmt.Sprintf("alertsNrqlCondition%sUpdate", fakeTitle(conditionType))
+		return map[string]interface{}{field: condition}, nil
+	}
+}
+
+func (f *fakeNewRelicAPI) alertsConditionDelete(vars map[string]interface{}) (interface{}, error) {
+	id := fakeString(vars["id"])
+	if f.remove(fakeKindNrqlCondition, id) == nil {
+		return nil, fakeGraphQLNotFound{}
+	}
+
+	return map[string]interface{}{"alertsConditionDelete": map[string]interface{}{"id": id}}, nil
+}
+
+func (f *fakeNewRelicAPI) nrqlCondition(vars map[string]interface{}) (interface{}, error) {
+	rec := f.find(fakeKindNrqlCondition, fakeString(vars["id"]))
+	if rec == nil {
+		return nil, fakeGraphQLNotFound{}
+	}
+
+	return fakeActorAccount([]string{"alerts", "nrqlCondition"}, rec.Data), nil
+}
+
+// fakeNrqlConditionsPageSize is the number of NRQL conditions returned per
+// page of a search.
+const fakeNrqlConditionsPageSize = 2
+
+func (f *fakeNewRelicAPI) nrqlConditionsSearch(vars map[string]interface{}) (interface{}, error) {
+	criteria := fakeMap(vars["searchCriteria"])
+
+	conditions := []interface{}{}
+	var unindexed []string
+	for _, rec := range f.list(fakeKindNrqlCondition, fakeString(criteria["policyId"])) {
+		if f.unindexedNrqlConditions[rec.ID] > 0 {
+			unindexed = append(unindexed, rec.ID)
+			continue
+		}
+
+		conditions = append(conditions, rec.Data)
+	}
+	count := len(conditions)
+
+	start, _ := strconv.Atoi(fakeString(vars["cursor"]))
+	if start > count {
+		start = count
+	}
+
+	var nextCursor interface{}
+	end := start + fakeNrqlConditionsPageSize
+	if end < count {
+		nextCursor = strconv.Itoa(end)
+	} else {
+		end = count
+
+		// A search counts once its last page is served.
+		for _, id := range unindexed {
+			f.unindexedNrqlConditions[id]--
+		}
+	}
+
+	return fakeActorAccount([]string{"alerts", "nrqlConditionsSearch"}, map[string]interface{}{
+		"nextCursor":     nextCursor,
+		"nrqlConditions": conditions[start:end],
+		"totalCount":     count,
+	}), nil
+}
+
+func fakeTitle(s string) string {
+	return strings.ToUpper(s[:1]) + strings.ToLower(s[1:])
+}
+
+//
+// Muting rules
+//
+
+func (f *fakeNewRelicAPI) alertsMutingRuleCreate(vars map[string]interface{}) (interface{}, error) {
+	rule := fakeMap(vars["rule"])
+
+	id := strconv.Itoa(f.nextID())
+	rule["id"] = id
+	rule["accountId"] = fakeInt(vars["accountID"])
+	rule["createdAt"] = fakeNow()
+	rule["updatedAt"] = rule["createdAt"]
+	if err := fakeMutingRuleSchedule(rule); err != nil {
+		return nil, err
+	}
+	f.put(fakeKindMutingRule, id, "", rule)
+
+	return map[string]interface{}{"alertsMutingRuleCreate": rule}, nil
+}
+
+func (f *fakeNewRelicAPI) alertsMutingRuleUpdate(vars map[string]interface{}) (interface{}, error) {
+	rec := f.find(fakeKindMutingRule, fakeString(vars["ruleID"]))
+	if rec == nil {
+		return nil, fakeGraphQLNotFound{}
+	}
+
+	rule := fakeMap(vars["rule"])
+	schedule, hasSchedule := rule["schedule"].(map[string]interface{})
+	stored, _ := rec.Data["schedule"].(map[string]interface{})
+
+	if _, ok := schedule["timeZone"]; hasSchedule && !ok && stored != nil {
+		schedule["timeZone"] = stored["timeZone"]
+	}
+
+	if err := fakeMutingRuleSchedule(rule); err != nil {
+		return nil, err
+	}
+
+	// Like the API, the schedule is merged: omitted fields are left untouched
+	// and null fields are removed.
+	if hasSchedule {
+		merged := map[string]interface{}{}
+		for k, v := range stored {
+			merged[k] = v
+		}
+		for k, v := range schedule {
+			if v == nil {
+				delete(merged, k)
+			} else {
+				merged[k] = v
+			}
+		}
+		rule["schedule"] = merged
+	}
+
+	for k, v := range rule {
+		rec.Data[k] = v
+	}
+	rec.Data["updatedAt"] = fakeNow()
+
+	return map[string]interface{}{"alertsMutingRuleUpdate": rec.Data}, nil
+}
+
+func (f *fakeNewRelicAPI) alertsMutingRuleDelete(vars map[string]interface{}) (interface{}, error) {
+	id := fakeString(vars["ruleID"])
+	if f.remove(fakeKindMutingRule, id) == nil {
+		return nil, fakeGraphQLNotFound{}
+	}
+
+	return map[string]interface{}{"alertsMutingRuleDelete": map[string]interface{}{"id": id}}, nil
+}
+
+// fakeMutingRuleSchedule converts the schedule's times, which are given in its
+// time zone without an offset, to UTC as NerdGraph does when returning them.
+func fakeMutingRuleSchedule(rule map[string]interface{}) error {
+	schedule := fakeMap(rule["schedule"])
+	if schedule == nil {
+		return nil
+	}
+
+	location, err := time.LoadLocation(fakeString(schedule["timeZone"]))
+	if err != nil {
+		return err
+	}
+
+	for _, k := range []string{"startTime", "endTime", "endRepeat"} {
+		v := fakeString(schedule[k])
+		if v == "" {
+			continue
+		}
+
+		t, err := time.ParseInLocation("2006-01-02T15:04:05", v, location)
+		if err != nil {
+			return fmt.Errorf("%s must not include a time zone offset: %s", k, err)
+		}
+
+		schedule[k] = t.UTC().Format(time.RFC3339)
+	}
+
+	if start, end := fakeString(schedule["startTime"]), fakeString(schedule["endTime"]); start != "" && end != "" && end <= start {
+		return fmt.Errorf("endTime must be after startTime")
+	}
+
+	return nil
+}
+
+func (f *fakeNewRelicAPI) mutingRule(vars map[string]interface{}) (interface{}, error) {
+	rec := f.find(fakeKindMutingRule, fakeString(vars["ruleID"]))
+	if rec == nil {
+		return nil, fakeGraphQLNotFound{}
+	}
+
+	return fakeActorAccount([]string{"alerts", "mutingRule"}, rec.Data), nil
+}
+
+func (f *fakeNewRelicAPI) mutingRules(vars map[string]interface{}) (interface{}, error) {
+	return fakeActorAccount([]string{"alerts", "mutingRules"}, f.listData(fakeKindMutingRule, "")), nil
+}
+
+//
+// API access keys
+//
+
+func (f *fakeNewRelicAPI) apiAccessCreateKeys(vars map[string]interface{}) (interface{}, error) {
+	keys := fakeMap(vars["keys"])
+	created := []interface{}{}
+
+	for keyType, inputs := range map[string][]interface{}{
+		"INGEST": fakeList(keys["ingest"]),
+		"USER":   fakeList(keys["user"]),
+	} {
+		for _, in := range inputs {
+			key := fakeMap(in)
+
+			id := f.nextID()
+			key["id"] = fmt.Sprintf("%08X", id)
+			key["key"] = fmt.Sprintf("NRAK-FAKE%016d", id)
+			key["type"] = keyType
+			key["createdAt"] = strconv.FormatInt(time.Now().Unix(), 10)
+			f.put(fakeKindAPIKey, key["id"].(string), "", key)
+
+			created = append(created, key)
+		}
+	}
+
+	return map[string]interface{}{
+		"apiAccessCreateKeys": map[string]interface{}{
+			"createdKeys": created,
+			"errors":      []interface{}{},
+		},
+	}, nil
+}
+
+func (f *fakeNewRelicAPI) apiAccessUpdateKeys(vars map[string]interface{}) (interface{}, error) {
+	keys := fakeMap(vars["keys"])
+	updated := []interface{}{}
+
+	for _, in := range append(fakeList(keys["ingest"]), fakeList(keys["user"])...) {
+		input := fakeMap(in)
+
+		rec := f.find(fakeKindAPIKey, fakeString(input["keyId"]))
+		if rec == nil {
+			return nil, fakeGraphQLNotFound{}
+		}
+
+		for _, k := range []string{"name", "notes"} {
+			if v, ok := input[k]; ok {
+				rec.Data[k] = v
+			}
+		}
+
+		updated = append(updated, rec.Data)
+	}
+
+	return map[string]interface{}{
+		"apiAccessUpdateKeys": map[string]interface{}{
+			"updatedKeys": updated,
+			"errors":      []interface{}{},
+		},
+	}, nil
+}
+
+func (f *fakeNewRelicAPI) apiAccessDeleteKeys(vars map[string]interface{}) (interface{}, error) {
+	keys := fakeMap(vars["keys"])
+	deleted := []interface{}{}
+
+	for _, id := range append(fakeList(keys["ingestKeyIds"]), fakeList(keys["userKeyIds"])...) {
+		if f.remove(fakeKindAPIKey, fakeString(id)) != nil {
+			deleted = append(deleted, map[string]interface{}{"id": id})
+		}
+	}
+
+	return map[string]interface{}{
+		"apiAccessDeleteKeys": map[string]interface{}{
+			"deletedKeys": deleted,
+			"errors":      []interface{}{},
+		},
+	}, nil
+}
+
+func (f *fakeNewRelicAPI) apiAccessKey(vars map[string]interface{}) (interface{}, error) {
+	rec := f.find(fakeKindAPIKey, fakeString(vars["id"]))
+	if rec == nil || rec.Data["type"] != vars["keyType"] {
+		return nil, fakeGraphQLNotFound{}
+	}
+
+	return map[string]interface{}{
+		"actor": map[string]interface{}{
+			"apiAccess": map[string]interface{}{"key": rec.Data},
+		},
+	}, nil
+}
+
+//
+// Events to metrics rules
+//
+
+func (f *fakeNewRelicAPI) eventsToMetricsCreateRule(vars map[string]interface{}) (interface{}, error) {
+	successes := []interface{}{}
+
+	for _, in := range fakeList(vars["createInput"]) {
+		rule := fakeMap(in)
+
+		id := strconv.Itoa(f.nextID())
+		rule["id"] = id
+		rule["enabled"] = true
+		rule["createdAt"] = fakeNow()
+		rule["updatedAt"] = rule["createdAt"]
+		f.put(fakeKindEventsToMetricsRule, id, fakeString(rule["accountId"]), rule)
+
+		successes = append(successes, rule)
+	}
+
+	return map[string]interface{}{
+		"eventsToMetricsCreateRule": map[string]interface{}{"successes": successes, "failures": []interface{}{}},
+	}, nil
+}
+
+func (f *fakeNewRelicAPI) eventsToMetricsUpdateRule(vars map[string]interface{}) (interface{}, error) {
+	successes := []interface{}{}
+
+	for _, in := range fakeList(vars["updateInput"]) {
+		input := fakeMap(in)
+
+		rec := f.find(fakeKindEventsToMetricsRule, fakeString(input["ruleId"]))
+		if rec == nil {
+			return nil, fakeGraphQLNotFound{}
+		}
+
+		rec.Data["enabled"] = input["enabled"]
+		rec.Data["updatedAt"] = fakeNow()
+
+		successes = append(successes, rec.Data)
+	}
+
+	return map[string]interface{}{
+		"eventsToMetricsUpdateRule": map[string]interface{}{"successes": successes, "failures": []interface{}{}},
+	}, nil
+}
+
+func (f *fakeNewRelicAPI) eventsToMetricsDeleteRule(vars map[string]interface{}) (interface{}, error) {
+	successes := []interface{}{}
+
+	for _, in := range fakeList(vars["deleteInput"]) {
+		input := fakeMap(in)
+
+		rec := f.remove(fakeKindEventsToMetricsRule, fakeString(input["ruleId"]))
+		if rec == nil {
+			return nil, fakeGraphQLNotFound{}
+		}
+
+		successes = append(successes, rec.Data)
+	}
+
+	return map[string]interface{}{
+		"eventsToMetricsDeleteRule": map[string]interface{}{"successes": successes, "failures": []interface{}{}},
+	}, nil
+}
+
+func (f *fakeNewRelicAPI) eventsToMetricsRulesByID(vars map[string]interface{}) (interface{}, error) {
+	rules := []interface{}{}
+
+	for _, id := range fakeList(vars["ruleIds"]) {
+		if rec := f.find(fakeKindEventsToMetricsRule, fakeString(id)); rec != nil {
+			rules = append(rules, rec.Data)
+		}
+	}
+
+	return fakeActorAccount([]string{"eventsToMetrics", "rulesById"}, map[string]interface{}{"rules": rules}), nil
+}
+
+func (f *fakeNewRelicAPI) eventsToMetricsAllRules(vars map[string]interface{}) (interface{}, error) {
+	rules := f.listData(fakeKindEventsToMetricsRule, fakeString(vars["accountId"]))
+
+	return fakeActorAccount([]string{"eventsToMetrics", "allRules"}, map[string]interface{}{"rules": rules}), nil
+}
+
+//
+// Entities and tagging
+//
+
+func (f *fakeNewRelicAPI) entity(vars map[string]interface{}) (interface{}, error) {
+	var entity interface{}
+
+	if rec := f.find(fakeKindEntity, fakeString(vars["guid"])); rec != nil {
+		data := fakeCopy(rec.Data).(map[string]interface{})
+		if unindexed := f.unindexedTags[rec.ID]; unindexed != nil && unindexed.reads > 0 {
+			unindexed.reads--
+			data["tags"] = fakeCopy(unindexed.tags)
+		}
+		data["tagsWithMetadata"] = fakeTagsWithMetadata(fakeList(data["tags"]))
+		entity = data
+	}
+
+	return map[string]interface{}{
+		"actor": map[string]interface{}{"entity": entity},
+	}, nil
+}
+
+// fakeEntitySearchPageSize is the number of entities returned per page to
+// clients which page through the results with a cursor.
+const fakeEntitySearchPageSize = 2
+
+func (f *fakeNewRelicAPI) entitySearch(vars map[string]interface{}) (interface{}, error) {
+	builder := fakeMap(vars["queryBuilder"])
+	entities := []interface{}{}
+
+	query := fakeString(vars["query"])
+
+	for _, rec := range f.list(fakeKindEntity, "") {
+		if !fakeEntityMatches(rec.Data, builder) || (query != "" && !fakeEntityMatchesQuery(rec.Data, query)) {
+			continue
+		}
+
+		outline := map[string]interface{}{
+			"__typename": fmt.Sprintf("%sOutline", rec.Data["__typename"]),
+		}
+		for _, k := range []string{"accountId", "alertSeverity", "applicationId", "domain", "entityType", "guid", "language", "name", "permalink", "reporting", "tags", "type"} {
+			if v, ok := rec.Data[k]; ok {
+				outline[k] = v
+			}
+		}
+
+		entities = append(entities, outline)
+	}
+
+	count := len(entities)
+
+	var nextCursor interface{}
+	if _, paged := vars["cursor"]; paged {
+		start, _ := strconv.Atoi(fakeString(vars["cursor"]))
+		if start > count {
+			start = count
+		}
+
+		end := start + fakeEntitySearchPageSize
+		if end < count {
+			nextCursor = strconv.Itoa(end)
+		} else {
+			end = count
+		}
+
+		entities = entities[start:end]
+	}
+
+	return map[string]interface{}{
+		"actor": map[string]interface{}{
+			"entitySearch": map[string]interface{}{
+				"count": count,
+				"results": map[string]interface{}{
+					"entities":   entities,
+					"nextCursor": nextCursor,
+				},
+			},
+		},
+	}, nil
+}
+
+func fakeEntityMatches(entity map[string]interface{}, builder map[string]interface{}) bool {
+	if name := strings.ToLower(fakeString(builder["name"])); name != "" && !strings.Contains(strings.ToLower(fakeString(entity["name"])), name) {
+		return false
+	}
+
+	if domain := fakeString(builder["domain"]); domain != "" && entity["domain"] != domain {
+		return false
+	}
+
+	if entityType := fakeString(builder["type"]); entityType != "" && entity["type"] != entityType {
+		return false
+	}
+
+	if severity := fakeString(builder["alertSeverity"]); severity != "" && entity["alertSeverity"] != severity {
+		return false
+	}
+
+	if reporting, ok := builder["reporting"].(bool); ok && entity["reporting"] != reporting {
+		return false
+	}
+
+	for _, t := range fakeList(builder["tags"]) {
+		tag := fakeMap(t)
+		if !fakeHasTag(fakeList(entity["tags"]), fakeString(tag["key"]), fakeString(tag["value"])) {
+			return false
+		}
+	}
+
+	return true
+}
+
+var (
+	fakeEntityQueryCondition    = regexp.MustCompile(`(?i)(\w+)\s+(like|=|in)\s+`)
+	fakeEntityQueryValue        = regexp.MustCompile(`'([^']*)'`)
+	fakeEntityQueryAccountScope = regexp.MustCompile(`\s+AND accountId IN \(([\d, ]+)\)$`)
+	fakeEntityQueryAttribute    = map[string]string{"id": "guid", "name": "name", "type": "type", "domain": "domain"}
+)
+
+// fakeEntityMatchesQuery evaluates an entity search query made of conditions
+// joined by OR, which is how workloads compose their search queries, and
+// optionally scoped to accounts with a trailing AND accountId IN (...).
+func fakeEntityMatchesQuery(entity map[string]interface{}, query string) bool {
+	if match := fakeEntityQueryAccountScope.FindStringSubmatch(query); match != nil {
+		scoped := false
+		for _, id := range strings.Split(match[1], ",") {
+			if strings.TrimSpace(id) == strconv.Itoa(fakeInt(entity["accountId"])) {
+				scoped = true
+			}
+		}
+
+		if !scoped {
+			return false
+		}
+
+		query = strings.TrimSuffix(query, match[0])
+	}
+
+	for _, condition := range strings.Split(query, " OR ") {
+		match := fakeEntityQueryCondition.FindStringSubmatch(condition)
+		if match == nil {
+			continue
+		}
+
+		actual := fakeString(entity[fakeEntityQueryAttribute[strings.ToLower(match[1])]])
+
+		for _, value := range fakeEntityQueryValue.FindAllStringSubmatch(condition, -1) {
+			if strings.EqualFold(match[2], "like") {
+				if strings.Contains(strings.ToLower(actual), strings.ToLower(strings.Trim(value[1], "%"))) {
+					return true
+				}
+			} else if actual == value[1] {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func fakeHasTag(tags []interface{}, key string, value string) bool {
+	for _, t := range tags {
+		tag := fakeMap(t)
+		if tag["key"] != key {
+			continue
+		}
+
+		for _, v := range fakeList(tag["values"]) {
+			if v == value {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func fakeTagsWithMetadata(tags []interface{}) []interface{} {
+	out := []interface{}{}
+
+	for _, t := range tags {
+		tag := fakeMap(t)
+		key := fakeString(tag["key"])
+
+		values := []interface{}{}
+		for _, v := range fakeList(tag["values"]) {
+			values = append(values, map[string]interface{}{
+				"mutable": !fakeImmutableTagKeys[key],
+				"value":   v,
+			})
+		}
+
+		out = append(out, map[string]interface{}{"key": key, "values": values})
+	}
+
+	return out
+}
+
+// fakeTagInputs normalizes tag inputs, which the client sends with Go
+// field names, into the key and values shape NerdGraph returns.
+func fakeTagInputs(v interface{}) []map[string]interface{} {
+	out := []map[string]interface{}{}
+
+	for _, t := range fakeList(v) {
+		tag := fakeMap(t)
+		values := []interface{}{}
+		values = append(values, fakeList(fakeField(tag, "values", "Values"))...)
+
+		out = append(out, map[string]interface{}{
+			"key":    fakeString(fakeField(tag, "key", "Key")),
+			"values": values,
+		})
+	}
+
+	return out
+}
+
+func fakeTagMutationResult(field string) interface{} {
+	return map[string]interface{}{
+		field: map[string]interface{}{"errors": []interface{}{}},
+	}
+}
+
+// unindexTags keeps the current tags of an entity to be read in place of the
+// ones about to be written, while tag reads are delayed.
+func (f *fakeNewRelicAPI) unindexTags(rec *fakeRecord) {
+	if f.tagReadDelay == 0 {
+		return
+	}
+
+	if unindexed := f.unindexedTags[rec.ID]; unindexed == nil || unindexed.reads == 0 {
+		f.unindexedTags[rec.ID] = &fakeUnindexedTags{tags: fakeCopy(rec.Data["tags"])}
+	}
+	f.unindexedTags[rec.ID].reads = f.tagReadDelay
+}
+
+func (f *fakeNewRelicAPI) taggingAddTagsToEntity(vars map[string]interface{}) (interface{}, error) {
+	rec := f.find(fakeKindEntity, fakeString(vars["guid"]))
+	if rec == nil {
+		return nil, fakeGraphQLNotFound{}
+	}
+	f.unindexTags(rec)
+
+	tags := fakeList(rec.Data["tags"])
+
+	for _, in := range fakeTagInputs(vars["tags"]) {
+		var existing map[string]interface{}
+		for _, t := range tags {
+			if tag := fakeMap(t); tag["key"] == in["key"] {
+				existing = tag
+			}
+		}
+
+		if existing == nil {
+			tags = append(tags, in)
+			continue
+		}
+
+		for _, v := range fakeList(in["values"]) {
+			if !fakeHasTag([]interface{}{existing}, fakeString(in["key"]), fakeString(v)) {
+				existing["values"] = append(fakeList(existing["values"]), v)
+			}
+		}
+	}
+
+	rec.Data["tags"] = tags
+
+	return fakeTagMutationResult("taggingAddTagsToEntity"), nil
+}
+
+func (f *fakeNewRelicAPI) taggingReplaceTagsOnEntity(vars map[string]interface{}) (interface{}, error) {
+	rec := f.find(fakeKindEntity, fakeString(vars["guid"]))
+	if rec == nil {
+		return nil, fakeGraphQLNotFound{}
+	}
+	f.unindexTags(rec)
+
+	tags := []interface{}{}
+	for _, t := range fakeList(rec.Data["tags"]) {
+		if fakeImmutableTagKeys[fakeString(fakeMap(t)["key"])] {
+			tags = append(tags, t)
+		}
+	}
+
+	for _, in := range fakeTagInputs(vars["tags"]) {
+		tags = append(tags, in)
+	}
+
+	rec.Data["tags"] = tags
+
+	return fakeTagMutationResult("taggingReplaceTagsOnEntity"), nil
+}
+
+func (f *fakeNewRelicAPI) taggingDeleteTagFromEntity(vars map[string]interface{}) (interface{}, error) {
+	rec := f.find(fakeKindEntity, fakeString(vars["guid"]))
+	if rec == nil {
+		return nil, fakeGraphQLNotFound{}
+	}
+
+	remove := map[string]bool{}
+	for _, k := range fakeList(vars["tagKeys"]) {
+		remove[fakeString(k)] = true
+	}
+
+	tags := []interface{}{}
+	for _, t := range fakeList(rec.Data["tags"]) {
+		if !remove[fakeString(fakeMap(t)["key"])] {
+			tags = append(tags, t)
+		}
+	}
+
+	rec.Data["tags"] = tags
+
+	return fakeTagMutationResult("taggingDeleteTagFromEntity"), nil
+}
+
+func (f *fakeNewRelicAPI) taggingDeleteTagValuesFromEntity(vars map[string]interface{}) (interface{}, error) {
+	rec := f.find(fakeKindEntity, fakeString(vars["guid"]))
+	if rec == nil {
+		return nil, fakeGraphQLNotFound{}
+	}
+
+	for _, tv := range fakeList(vars["tagValues"]) {
+		in := fakeMap(tv)
+		key := fakeString(fakeField(in, "key", "Key"))
+		value := fakeField(in, "value", "Value")
+
+		tags := []interface{}{}
+		for _, t := range fakeList(rec.Data["tags"]) {
+			tag := fakeMap(t)
+			if tag["key"] == key {
+				values := []interface{}{}
+				for _, v := range fakeList(tag["values"]) {
+					if v != value {
+						values = append(values, v)
+					}
+				}
+				tag["values"] = values
+			}
+
+			// A tag goes away along with its last value.
+			if len(fakeList(tag["values"])) > 0 {
+				tags = append(tags, tag)
+			}
+		}
+
+		rec.Data["tags"] = tags
+	}
+
+	return fakeTagMutationResult("taggingDeleteTagValuesFromEntity"), nil
+}
+
+//
+// Dashboards
+//
+
+func (f *fakeNewRelicAPI) dashboardCreate(vars map[string]interface{}) (interface{}, error) {
+	input := fakeMap(vars["dashboard"])
+
+	id := f.nextID()
+	guid := f.guid("VIZ", "DASHBOARD", id)
+	now := fakeNow()
+
+	dashboard := map[string]interface{}{
+		"__typename": "DashboardEntity",
+		"accountId":  fakeInt(vars["accountId"]),
+		"createdAt":  now,
+		"domain":     "VIZ",
+		"entityType": "DASHBOARD_ENTITY",
+		"guid":       guid,
+		"permalink":  fmt.Sprintf("https://one.newrelic.com/redirect/entity/%s", guid),
+		"tags":       []interface{}{},
+		"type":       "DASHBOARD",
+	}
+	f.applyDashboardInput(dashboard, input)
+	f.put(fakeKindEntity, guid, "", dashboard)
+
+	return map[string]interface{}{
+		"dashboardCreate": map[string]interface{}{
+			"entityResult": fakeDashboardEntityResult(dashboard),
+			"errors":       []interface{}{},
+		},
+	}, nil
+}
+
+func (f *fakeNewRelicAPI) dashboardUpdate(vars map[string]interface{}) (interface{}, error) {
+	rec := f.find(fakeKindEntity, fakeString(vars["guid"]))
+	if rec == nil || rec.Data["__typename"] != "DashboardEntity" {
+		return nil, fakeGraphQLNotFound{}
+	}
+
+	f.applyDashboardInput(rec.Data, fakeMap(vars["dashboard"]))
+
+	return map[string]interface{}{
+		"dashboardUpdate": map[string]interface{}{
+			"entityResult": fakeDashboardEntityResult(rec.Data),
+			"errors":       []interface{}{},
+		},
+	}, nil
+}
+
+func (f *fakeNewRelicAPI) dashboardDelete(vars map[string]interface{}) (interface{}, error) {
+	guid := fakeString(vars["guid"])
+
+	rec := f.find(fakeKindEntity, guid)
+	if rec == nil || rec.Data["__typename"] != "DashboardEntity" {
+		return nil, fakeGraphQLNotFound{}
+	}
+
+	f.remove(fakeKindEntity, guid)
+
+	return map[string]interface{}{
+		"dashboardDelete": map[string]interface{}{
+			"errors": []interface{}{},
+			"status": "SUCCESS",
+		},
+	}, nil
+}
+
+// applyDashboardInput stores a DashboardInput on a dashboard entity, filling
+// in the identifiers and derived fields NerdGraph would assign.
+func (f *fakeNewRelicAPI) applyDashboardInput(dashboard map[string]interface{}, input map[string]interface{}) {
+	now := fakeNow()
+
+	pages := []interface{}{}
+	for _, p := range fakeList(input["pages"]) {
+		page := fakeMap(p)
+
+		if fakeString(page["guid"]) == "" {
+			page["guid"] = f.guid("VIZ", "DASHBOARD", f.nextID())
+		}
+		page["createdAt"] = now
+		page["updatedAt"] = now
+
+		widgets := []interface{}{}
+		for _, w := range fakeList(page["widgets"]) {
+			widgets = append(widgets, f.dashboardWidget(fakeMap(w)))
+		}
+		page["widgets"] = widgets
+
+		pages = append(pages, page)
+	}
+
+	variables := []interface{}{}
+	for _, v := range fakeList(input["variables"]) {
+		variable := fakeMap(v)
+		if fakeString(variable["replacementStrategy"]) == "" {
+			variable["replacementStrategy"] = "DEFAULT"
+		}
+		variables = append(variables, variable)
+	}
+
+	dashboard["name"] = input["name"]
+	dashboard["description"] = input["description"]
+	dashboard["permissions"] = input["permissions"]
+	dashboard["pages"] = pages
+	dashboard["variables"] = variables
+	dashboard["updatedAt"] = now
+}
+
+func (f *fakeNewRelicAPI) dashboardWidget(widget map[string]interface{}) map[string]interface{} {
+	if fakeString(widget["id"]) == "" {
+		widget["id"] = strconv.Itoa(f.nextID())
+	}
+
+	// The visualization is derived from the typed configuration when it is
+	// not given explicitly.
+	visualization := fakeMap(widget["visualization"])
+	if fakeString(visualization["id"]) == "" {
+		for k, v := range fakeMap(widget["configuration"]) {
+			if v != nil {
+				visualization["id"] = "viz." + k
+			}
+		}
+	}
+	widget["visualization"] = visualization
+
+	linked := []interface{}{}
+	for _, g := range fakeList(widget["linkedEntityGuids"]) {
+		guid := fakeString(g)
+		outline := map[string]interface{}{
+			"__typename": "DashboardEntityOutline",
+			"guid":       guid,
+		}
+
+		if rec := f.find(fakeKindEntity, guid); rec != nil {
+			outline["accountId"] = rec.Data["accountId"]
+			outline["name"] = rec.Data["name"]
+		}
+
+		linked = append(linked, outline)
+	}
+	delete(widget, "linkedEntityGuids")
+	widget["linkedEntities"] = linked
+
+	return widget
+}
+
+func fakeDashboardEntityResult(dashboard map[string]interface{}) map[string]interface{} {
+	result := map[string]interface{}{}
+	for _, k := range []string{"accountId", "createdAt", "description", "guid", "name", "pages", "permissions", "updatedAt"} {
+		result[k] = dashboard[k]
+	}
+
+	return result
+}
+
+//
+// Workloads
+//
+
+func (f *fakeNewRelicAPI) workloadCreate(vars map[string]interface{}) (interface{}, error) {
+	accountID := fakeInt(vars["accountId"])
+
+	id := f.nextID()
+	guid := f.guid("NR1", "WORKLOAD", id)
+
+	workload := map[string]interface{}{
+		"account":   map[string]interface{}{"id": accountID, "name": "Fake Account"},
+		"createdAt": fakeNowMillis(),
+		"guid":      guid,
+		"id":        id,
+		"permalink": fmt.Sprintf("https://one.newrelic.com/redirect/entity/%s", guid),
+	}
+	f.applyWorkloadInput(workload, fakeMap(vars["workload"]))
+	f.put(fakeKindWorkload, guid, strconv.Itoa(accountID), workload)
+
+	return map[string]interface{}{"workloadCreate": workload}, nil
+}
+
+func (f *fakeNewRelicAPI) workloadUpdate(vars map[string]interface{}) (interface{}, error) {
+	rec := f.find(fakeKindWorkload, fakeString(vars["guid"]))
+	if rec == nil {
+		return nil, fakeGraphQLNotFound{}
+	}
+
+	f.applyWorkloadInput(rec.Data, fakeMap(vars["workload"]))
+	rec.Data["updatedAt"] = fakeNowMillis()
+
+	return map[string]interface{}{"workloadUpdate": rec.Data}, nil
+}
+
+func (f *fakeNewRelicAPI) workloadDelete(vars map[string]interface{}) (interface{}, error) {
+	rec := f.remove(fakeKindWorkload, fakeString(vars["guid"]))
+	if rec == nil {
+		return nil, fakeGraphQLNotFound{}
+	}
+
+	return map[string]interface{}{"workloadDelete": rec.Data}, nil
+}
+
+func (f *fakeNewRelicAPI) applyWorkloadInput(workload map[string]interface{}, input map[string]interface{}) {
+	workload["name"] = input["name"]
+
+	entities := []interface{}{}
+	for _, guid := range fakeList(input["entityGuids"]) {
+		entities = append(entities, map[string]interface{}{"guid": guid})
+	}
+	workload["entities"] = entities
+
+	queries := []interface{}{}
+	composite := []string{}
+	for _, q := range fakeList(input["entitySearchQueries"]) {
+		query := fakeString(fakeMap(q)["query"])
+		queries = append(queries, map[string]interface{}{
+			"createdAt": fakeNowMillis(),
+			"id":        f.nextID(),
+			"query":     query,
+		})
+		composite = append(composite, fmt.Sprintf("(%s)", query))
+	}
+	workload["entitySearchQueries"] = queries
+	workload["entitySearchQuery"] = strings.Join(composite, " OR ")
+
+	scope := fakeMap(input["scopeAccounts"])
+	if len(fakeList(scope["accountIds"])) == 0 {
+		scope = map[string]interface{}{
+			"accountIds": []interface{}{fakeMap(workload["account"])["id"]},
+		}
+	}
+	workload["scopeAccounts"] = scope
+	workload["description"] = input["description"]
+
+	// Rules reference their entities by GUID, but return the entities.
+	statusConfig := fakeMap(input["statusConfig"])
+	for _, r := range fakeList(fakeMap(statusConfig["automatic"])["rules"]) {
+		rule := fakeMap(r)
+
+		ruleEntities := []interface{}{}
+		for _, guid := range fakeList(rule["entityGuids"]) {
+			ruleEntities = append(ruleEntities, map[string]interface{}{"guid": guid})
+		}
+		delete(rule, "entityGuids")
+		rule["entities"] = ruleEntities
+	}
+	workload["statusConfig"] = statusConfig
+}
+
+func (f *fakeNewRelicAPI) workloadCollection(vars map[string]interface{}) (interface{}, error) {
+	var workload interface{}
+	if rec := f.find(fakeKindWorkload, fakeString(vars["guid"])); rec != nil {
+		workload = rec.Data
+	}
+
+	return fakeActorAccount([]string{"workload", "collection"}, workload), nil
+}
+
+func (f *fakeNewRelicAPI) workloadCollections(vars map[string]interface{}) (interface{}, error) {
+	workloads := f.listData(fakeKindWorkload, fakeString(vars["accountId"]))
+
+	return fakeActorAccount([]string{"workload", "collections"}, workloads), nil
+}
+
+//
+// Accounts
+//
+
+func (f *fakeNewRelicAPI) accounts(vars map[string]interface{}) (interface{}, error) {
+	return map[string]interface{}{
+		"actor": map[string]interface{}{
+			"accounts": []interface{}{
+				map[string]interface{}{"id": f.AccountID, "name": "Fake Account"},
+				map[string]interface{}{"id": fakeSubAccountID, "name": "Fake Sub-account"},
+			},
+		},
+	}, nil
+}
+
+//
+// Notification destinations and channels
+//
+
+// fakeNotificationID builds an ID in the UUID format used by the
+// notifications and workflows APIs.
+func fakeNotificationID(id int) string {
+	return fmt.Sprintf("%08x-0000-4000-8000-%012x", id, id)
+}
+
+func fakeNotificationError(errorType string, description string) map[string]interface{} {
+	return map[string]interface{}{
+		"type":        errorType,
+		"description": description,
+		"details":     description,
+	}
+}
+
+func fakeNotificationAuth(in interface{}) interface{} {
+	auth := fakeMap(in)
+
+	switch auth["type"] {
+	case "BASIC":
+		return map[string]interface{}{"authType": "BASIC", "user": fakeMap(auth["basic"])["user"]}
+	case "TOKEN":
+		return map[string]interface{}{"authType": "TOKEN", "prefix": fakeMap(auth["token"])["prefix"]}
+	}
+
+	return nil
+}
+
+func (f *fakeNewRelicAPI) aiNotificationsCreateDestination(vars map[string]interface{}) (interface{}, error) {
+	input := fakeMap(vars["destination"])
+
+	id := fakeNotificationID(f.nextID())
+	destination := map[string]interface{}{
+		"id":         id,
+		"accountId":  fakeInt(vars["accountId"]),
+		"name":       input["name"],
+		"type":       input["type"],
+		"active":     true,
+		"status":     "DEFAULT",
+		"properties": fakeList(input["properties"]),
+		"auth":       fakeNotificationAuth(input["auth"]),
+		"createdAt":  fakeNow(),
+	}
+	f.put(fakeKindNotificationDestination, id, fakeString(vars["accountId"]), destination)
+
+	return map[string]interface{}{
+		"aiNotificationsCreateDestination": map[string]interface{}{"destination": destination, "error": nil},
+	}, nil
+}
+
+func (f *fakeNewRelicAPI) aiNotificationsUpdateDestination(vars map[string]interface{}) (interface{}, error) {
+	rec := f.find(fakeKindNotificationDestination, fakeString(vars["destinationId"]))
+	if rec == nil {
+		return map[string]interface{}{
+			"aiNotificationsUpdateDestination": map[string]interface{}{
+				"error": fakeNotificationError("ENTITY_NOT_FOUND", "destination not found"),
+			},
+		}, nil
+	}
+
+	input := fakeMap(vars["destination"])
+
+	rec.Data["name"] = input["name"]
+	rec.Data["active"] = input["active"]
+	rec.Data["properties"] = fakeList(input["properties"])
+	if input["auth"] != nil {
+		rec.Data["auth"] = fakeNotificationAuth(input["auth"])
+	}
+	if input["disableAuth"] == true {
+		rec.Data["auth"] = nil
+	}
+
+	return map[string]interface{}{
+		"aiNotificationsUpdateDestination": map[string]interface{}{"destination": rec.Data, "error": nil},
+	}, nil
+}
+
+func (f *fakeNewRelicAPI) aiNotificationsDeleteDestination(vars map[string]interface{}) (interface{}, error) {
+	id := fakeString(vars["destinationId"])
+
+	// Destinations cannot be removed while channels still send to them.
+	for _, channel := range f.list(fakeKindNotificationChannel, "") {
+		if channel.Data["destinationId"] == id {
+			return map[string]interface{}{
+				"aiNotificationsDeleteDestination": map[string]interface{}{
+					"ids":   []string{},
+					"error": fakeNotificationError("BAD_REQUEST", "destination is in use by a channel"),
+				},
+			}, nil
+		}
+	}
+
+	if f.remove(fakeKindNotificationDestination, id) == nil {
+		return map[string]interface{}{
+			"aiNotificationsDeleteDestination": map[string]interface{}{
+				"ids":   []string{},
+				"error": fakeNotificationError("BAD_REQUEST", "destination not found"),
+			},
+		}, nil
+	}
+
+	return map[string]interface{}{
+		"aiNotificationsDeleteDestination": map[string]interface{}{"ids": []string{id}, "error": nil},
+	}, nil
+}
+
+func (f *fakeNewRelicAPI) aiNotificationsDestinations(vars map[string]interface{}) (interface{}, error) {
+	entities := []interface{}{}
+
+	if rec := f.find(fakeKindNotificationDestination, fakeString(vars["id"])); rec != nil && rec.Parent == fakeString(vars["accountId"]) {
+		entities = append(entities, rec.Data)
+	}
+
+	return fakeActorAccount([]string{"aiNotifications", "destinations"}, map[string]interface{}{
+		"entities":   entities,
+		"error":      nil,
+		"totalCount": len(entities),
+	}), nil
+}
+
+func (f *fakeNewRelicAPI) aiNotificationsCreateChannel(vars map[string]interface{}) (interface{}, error) {
+	input := fakeMap(vars["channel"])
+
+	if f.find(fakeKindNotificationDestination, fakeString(input["destinationId"])) == nil {
+		return map[string]interface{}{
+			"aiNotificationsCreateChannel": map[string]interface{}{
+				"error": fakeNotificationError("BAD_REQUEST", "destination not found"),
+			},
+		}, nil
+	}
+
+	id := fakeNotificationID(f.nextID())
+	channel := map[string]interface{}{
+		"id":            id,
+		"accountId":     fakeInt(vars["accountId"]),
+		"name":          input["name"],
+		"type":          input["type"],
+		"destinationId": input["destinationId"],
+		"product":       input["product"],
+		"active":        true,
+		"status":        "DEFAULT",
+		"properties":    fakeList(input["properties"]),
+		"createdAt":     fakeNow(),
+	}
+	f.put(fakeKindNotificationChannel, id, fakeString(vars["accountId"]), channel)
+
+	return map[string]interface{}{
+		"aiNotificationsCreateChannel": map[string]interface{}{"channel": channel, "error": nil},
+	}, nil
+}
+
+func (f *fakeNewRelicAPI) aiNotificationsUpdateChannel(vars map[string]interface{}) (interface{}, error) {
+	rec := f.find(fakeKindNotificationChannel, fakeString(vars["channelId"]))
+	if rec == nil {
+		return map[string]interface{}{
+			"aiNotificationsUpdateChannel": map[string]interface{}{
+				"error": fakeNotificationError("ENTITY_NOT_FOUND", "channel not found"),
+			},
+		}, nil
+	}
+
+	input := fakeMap(vars["channel"])
+
+	rec.Data["name"] = input["name"]
+	rec.Data["active"] = input["active"]
+	rec.Data["properties"] = fakeList(input["properties"])
+
+	return map[string]interface{}{
+		"aiNotificationsUpdateChannel": map[string]interface{}{"channel": rec.Data, "error": nil},
+	}, nil
+}
+
+func (f *fakeNewRelicAPI) aiNotificationsDeleteChannel(vars map[string]interface{}) (interface{}, error) {
+	id := fakeString(vars["channelId"])
+
+	// Channels cannot be removed while workflows still send to them.
+	for _, wf := range f.list(fakeKindWorkflow, "") {
+		for _, dest := range fakeList(wf.Data["destinationConfigurations"]) {
+			if fakeMap(dest)["channelId"] == id {
+				return map[string]interface{}{
+					"aiNotificationsDeleteChannel": map[string]interface{}{
+						"ids":   []string{},
+						"error": fakeNotificationError("BAD_REQUEST", "channel is in use by a workflow"),
+					},
+				}, nil
+			}
+		}
+	}
+
+	if f.remove(fakeKindNotificationChannel, id) == nil {
+		return map[string]interface{}{
+			"aiNotificationsDeleteChannel": map[string]interface{}{
+				"ids":   []string{},
+				"error": fakeNotificationError("BAD_REQUEST", "channel not found"),
+			},
+		}, nil
+	}
+
+	return map[string]interface{}{
+		"aiNotificationsDeleteChannel": map[string]interface{}{"ids": []string{id}, "error": nil},
+	}, nil
+}
+
+func (f *fakeNewRelicAPI) aiNotificationsChannels(vars map[string]interface{}) (interface{}, error) {
+	entities := []interface{}{}
+
+	if rec := f.find(fakeKindNotificationChannel, fakeString(vars["id"])); rec != nil && rec.Parent == fakeString(vars["accountId"]) {
+		entities = append(entities, rec.Data)
+	}
+
+	return fakeActorAccount([]string{"aiNotifications", "channels"}, map[string]interface{}{
+		"entities":   entities,
+		"error":      nil,
+		"totalCount": len(entities),
+	}), nil
+}
+
+//
+// Workflows
+//
+
+// applyWorkflowInput copies the fields of a workflow input onto a stored
+// workflow, resolving channel references and assigning IDs to new filters
+// and enrichments. Like the API, omitted enrichments are left untouched.
+func (f *fakeNewRelicAPI) applyWorkflowInput(wf map[string]interface{}, input map[string]interface{}) error {
+	for _, k := range []string{"name", "workflowEnabled", "destinationsEnabled", "enrichmentsEnabled", "mutingRulesHandling"} {
+		wf[k] = input[k]
+	}
+
+	filter := fakeMap(input["issuesFilter"])
+	filterID := fakeString(filter["id"])
+	if in, ok := filter["filterInput"]; ok {
+		filter = fakeMap(in)
+	}
+	if filterID == "" {
+		filterID = fakeNotificationID(f.nextID())
+	}
+	wf["issuesFilter"] = map[string]interface{}{
+		"id":         filterID,
+		"name":       filter["name"],
+		"type":       filter["type"],
+		"predicates": fakeList(filter["predicates"]),
+	}
+
+	enrichments, ok := wf["enrichments"].([]interface{})
+	if _, set := input["enrichments"]; set || !ok {
+		enrichments = []interface{}{}
+	}
+	for _, e := range fakeList(fakeMap(input["enrichments"])["nrql"]) {
+		enrichment := fakeMap(e)
+
+		id := fakeString(enrichment["id"])
+		if id == "" {
+			id = fakeNotificationID(f.nextID())
+		}
+
+		enrichments = append(enrichments, map[string]interface{}{
+			"id":             id,
+			"name":           enrichment["name"],
+			"type":           "NRQL",
+			"configurations": fakeList(enrichment["configuration"]),
+		})
+	}
+	wf["enrichments"] = enrichments
+
+	destinations := []interface{}{}
+	for _, d := range fakeList(input["destinationConfigurations"]) {
+		channelID := fakeString(fakeMap(d)["channelId"])
+
+		channel := f.find(fakeKindNotificationChannel, channelID)
+		if channel == nil {
+			return fmt.Errorf("channel %s not found", channelID)
+		}
+
+		destinations = append(destinations, map[string]interface{}{
+			"channelId": channelID,
+			"name":      channel.Data["name"],
+			"type":      channel.Data["type"],
+		})
+	}
+	wf["destinationConfigurations"] = destinations
+
+	return nil
+}
+
+func fakeWorkflowErrors(err error) []interface{} {
+	return []interface{}{map[string]interface{}{
+		"type":        "INVALID_PARAMETER",
+		"description": err.Error(),
+	}}
+}
+
+func (f *fakeNewRelicAPI) aiWorkflowsCreateWorkflow(vars map[string]interface{}) (interface{}, error) {
+	id := fakeNotificationID(f.nextID())
+	wf := map[string]interface{}{
+		"id":        id,
+		"accountId": fakeInt(vars["accountId"]),
+		"createdAt": fakeNow(),
+	}
+
+	if err := f.applyWorkflowInput(wf, fakeMap(vars["createWorkflowData"])); err != nil {
+		return map[string]interface{}{
+			"aiWorkflowsCreateWorkflow": map[string]interface{}{"errors": fakeWorkflowErrors(err)},
+		}, nil
+	}
+
+	f.put(fakeKindWorkflow, id, fakeString(vars["accountId"]), wf)
+
+	return map[string]interface{}{
+		"aiWorkflowsCreateWorkflow": map[string]interface{}{"workflow": wf, "errors": []interface{}{}},
+	}, nil
+}
+
+func (f *fakeNewRelicAPI) aiWorkflowsUpdateWorkflow(vars map[string]interface{}) (interface{}, error) {
+	input := fakeMap(vars["updateWorkflowData"])
+
+	rec := f.find(fakeKindWorkflow, fakeString(input["id"]))
+	if rec == nil {
+		return map[string]interface{}{
+			"aiWorkflowsUpdateWorkflow": map[string]interface{}{
+				"errors": fakeWorkflowErrors(fmt.Errorf("workflow %s not found", input["id"])),
+			},
+		}, nil
+	}
+
+	updated := fakeCopy(rec.Data).(map[string]interface{})
+	if err := f.applyWorkflowInput(updated, input); err != nil {
+		return map[string]interface{}{
+			"aiWorkflowsUpdateWorkflow": map[string]interface{}{"errors": fakeWorkflowErrors(err)},
+		}, nil
+	}
+	rec.Data = updated
+
+	return map[string]interface{}{
+		"aiWorkflowsUpdateWorkflow": map[string]interface{}{"workflow": rec.Data, "errors": []interface{}{}},
+	}, nil
+}
+
+func (f *fakeNewRelicAPI) aiWorkflowsDeleteWorkflow(vars map[string]interface{}) (interface{}, error) {
+	id := fakeString(vars["id"])
+
+	rec := f.remove(fakeKindWorkflow, id)
+	if rec == nil {
+		return map[string]interface{}{
+			"aiWorkflowsDeleteWorkflow": map[string]interface{}{
+				"errors": fakeWorkflowErrors(fmt.Errorf("workflow %s not found", id)),
+			},
+		}, nil
+	}
+
+	if vars["deleteChannels"] == true {
+		for _, d := range fakeList(rec.Data["destinationConfigurations"]) {
+			f.remove(fakeKindNotificationChannel, fakeString(fakeMap(d)["channelId"]))
+		}
+	}
+
+	return map[string]interface{}{
+		"aiWorkflowsDeleteWorkflow": map[string]interface{}{"id": id, "errors": []interface{}{}},
+	}, nil
+}
+
+func (f *fakeNewRelicAPI) aiWorkflowsWorkflows(vars map[string]interface{}) (interface{}, error) {
+	entities := []interface{}{}
+
+	if rec := f.find(fakeKindWorkflow, fakeString(vars["id"])); rec != nil && rec.Parent == fakeString(vars["accountId"]) {
+		entities = append(entities, rec.Data)
+	}
+
+	return fakeActorAccount([]string{"aiWorkflows", "workflows"}, map[string]interface{}{
+		"entities":   entities,
+		"totalCount": len(entities),
+	}), nil
+}
+
+//
+// Account, user and authorization management
+//
+
+// fakeAuthenticationDomains are the authentication domains of the fake
+// organization. They are listed one per page, so clients have to follow the
+// cursor to find the users and groups of the later ones.
+var fakeAuthenticationDomains = []string{"fake-domain-1", "fake-domain-2"}
+
+var fakeUserTypes = map[string]map[string]interface{}{
+	"BASIC_USER_TIER": {"id": "0", "displayName": "Basic"},
+	"CORE_USER_TIER":  {"id": "1", "displayName": "Core"},
+	"FULL_USER_TIER":  {"id": "2", "displayName": "Full platform"},
+}
+
+func (f *fakeNewRelicAPI) accountManagementCreateAccount(vars map[string]interface{}) (interface{}, error) {
+	input := fakeMap(vars["managedAccount"])
+
+	regionCode := fakeString(input["regionCode"])
+	if regionCode != "us01" && regionCode != "eu01" {
+		return nil, fmt.Errorf("invalid region code %s", regionCode)
+	}
+
+	id := f.nextID()
+	account := map[string]interface{}{
+		"id":         id,
+		"name":       input["name"],
+		"regionCode": regionCode,
+	}
+	f.put(fakeKindManagedAccount, strconv.Itoa(id), "", account)
+
+	return map[string]interface{}{
+		"accountManagementCreateAccount": map[string]interface{}{"managedAccount": account},
+	}, nil
+}
+
+func (f *fakeNewRelicAPI) accountManagementUpdateAccount(vars map[string]interface{}) (interface{}, error) {
+	input := fakeMap(vars["managedAccount"])
+
+	rec := f.find(fakeKindManagedAccount, fakeString(input["id"]))
+	if rec == nil {
+		return nil, fmt.Errorf("account %s not found", fakeString(input["id"]))
+	}
+	rec.Data["name"] = input["name"]
+
+	return map[string]interface{}{
+		"accountManagementUpdateAccount": map[string]interface{}{"managedAccount": rec.Data},
+	}, nil
+}
+
+func (f *fakeNewRelicAPI) managedAccounts(vars map[string]interface{}) (interface{}, error) {
+	return map[string]interface{}{
+		"actor": map[string]interface{}{
+			"organization": map[string]interface{}{
+				"accountManagement": map[string]interface{}{
+					"managedAccounts": f.listData(fakeKindManagedAccount, ""),
+				},
+			},
+		},
+	}, nil
+}
+
+// applyUserInput copies the fields of a user input onto a stored user.
+func (f *fakeNewRelicAPI) applyUserInput(u map[string]interface{}, input map[string]interface{}) error {
+	userType, ok := fakeUserTypes[fakeString(input["userType"])]
+	if !ok {
+		return fmt.Errorf("invalid user type %s", fakeString(input["userType"]))
+	}
+
+	for _, rec := range f.list(fakeKindUser, "") {
+		if rec.Data["email"] == input["email"] && rec.Data["id"] != u["id"] {
+			return fmt.Errorf("a user with email %s already exists", fakeString(input["email"]))
+		}
+	}
+
+	u["name"] = input["name"]
+	u["email"] = input["email"]
+	u["type"] = userType
+
+	return nil
+}
+
+func (f *fakeNewRelicAPI) userManagementCreateUser(vars map[string]interface{}) (interface{}, error) {
+	input := fakeMap(vars["createUserOptions"])
+
+	domainID := fakeString(input["authenticationDomainId"])
+	if !stringInSlice(fakeAuthenticationDomains, domainID) {
+		return nil, fmt.Errorf("authentication domain %s not found", domainID)
+	}
+
+	id := strconv.Itoa(f.nextID())
+	u := map[string]interface{}{"id": id}
+	if err := f.applyUserInput(u, input); err != nil {
+		return nil, err
+	}
+	f.put(fakeKindUser, id, domainID, u)
+
+	return map[string]interface{}{
+		"userManagementCreateUser": map[string]interface{}{"createdUser": u},
+	}, nil
+}
+
+func (f *fakeNewRelicAPI) userManagementUpdateUser(vars map[string]interface{}) (interface{}, error) {
+	input := fakeMap(vars["updateUserOptions"])
+
+	rec := f.find(fakeKindUser, fakeString(input["id"]))
+	if rec == nil {
+		return nil, fmt.Errorf("user %s not found", fakeString(input["id"]))
+	}
+
+	if err := f.applyUserInput(rec.Data, input); err != nil {
+		return nil, err
+	}
+
+	return map[string]interface{}{
+		"userManagementUpdateUser": map[string]interface{}{"user": rec.Data},
+	}, nil
+}
+
+func (f *fakeNewRelicAPI) userManagementDeleteUser(vars map[string]interface{}) (interface{}, error) {
+	id := fakeString(fakeMap(vars["deleteUserOptions"])["id"])
+
+	if f.remove(fakeKindUser, id) == nil {
+		return nil, fmt.Errorf("user %s not found", id)
+	}
+
+	// Deleted users leave their groups.
+	for _, rec := range f.list(fakeKindGroup, "") {
+		rec.Data["userIds"] = fakeRemoveString(fakeList(rec.Data["userIds"]), id)
+	}
+
+	return map[string]interface{}{
+		"userManagementDeleteUser": map[string]interface{}{"deletedUser": map[string]interface{}{"id": id}},
+	}, nil
+}
+
+func (f *fakeNewRelicAPI) userManagementCreateGroup(vars map[string]interface{}) (interface{}, error) {
+	input := fakeMap(vars["createGroupOptions"])
+
+	domainID := fakeString(input["authenticationDomainId"])
+	if !stringInSlice(fakeAuthenticationDomains, domainID) {
+		return nil, fmt.Errorf("authentication domain %s not found", domainID)
+	}
+
+	id := fmt.Sprintf("00000000-0000-0000-0000-%012d", f.nextID())
+	g := map[string]interface{}{
+		"id":          id,
+		"displayName": input["displayName"],
+		"userIds":     []interface{}{},
+		"roles":       []interface{}{},
+	}
+	f.put(fakeKindGroup, id, domainID, g)
+
+	return map[string]interface{}{
+		"userManagementCreateGroup": map[string]interface{}{"group": fakeGroup(g, nil)},
+	}, nil
+}
+
+func (f *fakeNewRelicAPI) userManagementUpdateGroup(vars map[string]interface{}) (interface{}, error) {
+	input := fakeMap(vars["updateGroupOptions"])
+
+	rec := f.find(fakeKindGroup, fakeString(input["id"]))
+	if rec == nil {
+		return nil, fmt.Errorf("group %s not found", fakeString(input["id"]))
+	}
+	rec.Data["displayName"] = input["displayName"]
+
+	return map[string]interface{}{
+		"userManagementUpdateGroup": map[string]interface{}{"group": fakeGroup(rec.Data, nil)},
+	}, nil
+}
+
+func (f *fakeNewRelicAPI) userManagementDeleteGroup(vars map[string]interface{}) (interface{}, error) {
+	id := fakeString(fakeMap(vars["groupOptions"])["id"])
+
+	if f.remove(fakeKindGroup, id) == nil {
+		return nil, fmt.Errorf("group %s not found", id)
+	}
+
+	return map[string]interface{}{
+		"userManagementDeleteGroup": map[string]interface{}{"group": map[string]interface{}{"id": id}},
+	}, nil
+}
+
+// fakeGroupUsersUpdate adds users to or removes them from groups.
+func fakeGroupUsersUpdate(field string, optionsVar string, add bool) fakeGraphQLHandler {
+	return func(f *fakeNewRelicAPI, vars map[string]interface{}) (interface{}, error) {
+		input := fakeMap(vars[optionsVar])
+
+		groups := []interface{}{}
+		for _, groupID := range fakeList(input["groupIds"]) {
+			rec := f.find(fakeKindGroup, fakeString(groupID))
+			if rec == nil {
+				return nil, fmt.Errorf("group %s not found", fakeString(groupID))
+			}
+
+			for _, userID := range fakeList(input["userIds"]) {
+				if f.find(fakeKindUser, fakeString(userID)) == nil {
+					return nil, fmt.Errorf("user %s not found", fakeString(userID))
+				}
+
+				userIDs := fakeRemoveString(fakeList(rec.Data["userIds"]), fakeString(userID))
+				if add {
+					userIDs = append(userIDs, fakeString(userID))
+				}
+				rec.Data["userIds"] = userIDs
+			}
+
+			groups = append(groups, map[string]interface{}{"id": rec.ID})
+		}
+
+		return map[string]interface{}{field: map[string]interface{}{"groups": groups}}, nil
+	}
+}
+
+// fakeGroupAccessUpdate grants roles to or revokes them from a group.
+func fakeGroupAccessUpdate(field string, optionsVar string, grant bool) fakeGraphQLHandler {
+	return func(f *fakeNewRelicAPI, vars map[string]interface{}) (interface{}, error) {
+		input := fakeMap(vars[optionsVar])
+
+		rec := f.find(fakeKindGroup, fakeString(input["groupId"]))
+		if rec == nil {
+			return nil, fmt.Errorf("group %s not found", fakeString(input["groupId"]))
+		}
+
+		roles := fakeList(rec.Data["roles"])
+		for _, g := range fakeList(input["accountAccessGrants"]) {
+			grantInput := fakeMap(g)
+			role := map[string]interface{}{
+				"accountId": fakeInt(grantInput["accountId"]),
+				"roleId":    fakeString(grantInput["roleId"]),
+			}
+
+			kept := []interface{}{}
+			for _, r := range roles {
+				if fakeInt(fakeMap(r)["accountId"]) != role["accountId"] || fakeString(fakeMap(r)["roleId"]) != role["roleId"] {
+					kept = append(kept, r)
+				}
+			}
+			if grant {
+				kept = append(kept, role)
+			}
+			roles = kept
+		}
+		rec.Data["roles"] = roles
+
+		return map[string]interface{}{field: map[string]interface{}{"roles": roles}}, nil
+	}
+}
+
+// fakeGroupPageSize is the number of members or roles of a group returned
+// per page.
+const fakeGroupPageSize = 2
+
+// fakeGroup returns the user management view of a stored group, with the
+// page of its members at the cursor.
+func fakeGroup(g map[string]interface{}, usersCursor interface{}) map[string]interface{} {
+	users := []interface{}{}
+	for _, id := range fakeList(g["userIds"]) {
+		users = append(users, map[string]interface{}{"id": id})
+	}
+
+	page, nextCursor := fakeGroupPage(users, usersCursor)
+
+	return map[string]interface{}{
+		"id":          g["id"],
+		"displayName": g["displayName"],
+		"users":       map[string]interface{}{"nextCursor": nextCursor, "users": page},
+	}
+}
+
+// fakeGroupPage returns the page of the members or roles of a group at the
+// cursor, and the cursor of the next page.
+func fakeGroupPage(items []interface{}, cursor interface{}) ([]interface{}, interface{}) {
+	start := fakeInt(cursor)
+	if start > len(items) {
+		start = len(items)
+	}
+
+	end := start + fakeGroupPageSize
+	if end >= len(items) {
+		return items[start:], nil
+	}
+
+	return items[start:end], strconv.Itoa(end)
+}
+
+func fakeRemoveString(l []interface{}, s string) []interface{} {
+	out := []interface{}{}
+	for _, v := range l {
+		if fakeString(v) != s {
+			out = append(out, v)
+		}
+	}
+
+	return out
+}
+
+// fakeAuthenticationDomainsPage returns the page of authentication domains
+// at the cursor, each described by the given function.
+func fakeAuthenticationDomainsPage(vars map[string]interface{}, describe func(domainID string) map[string]interface{}) map[string]interface{} {
+	// Domains asked for by ID are returned at once.
+	if ids := fakeList(vars["domainId"]); len(ids) > 0 {
+		domains := []interface{}{}
+		for _, id := range ids {
+			if stringInSlice(fakeAuthenticationDomains, fakeString(id)) {
+				domain := describe(fakeString(id))
+				domain["id"] = id
+				domains = append(domains, domain)
+			}
+		}
+
+		return map[string]interface{}{"authenticationDomains": domains}
+	}
+
+	i := fakeInt(vars["cursor"])
+
+	domain := describe(fakeAuthenticationDomains[i])
+	domain["id"] = fakeAuthenticationDomains[i]
+
+	var nextCursor interface{}
+	if i+1 < len(fakeAuthenticationDomains) {
+		nextCursor = strconv.Itoa(i + 1)
+	}
+
+	return map[string]interface{}{
+		"nextCursor":            nextCursor,
+		"authenticationDomains": []interface{}{domain},
+	}
+}
+
+// domainRecords returns the records of a kind in an authentication
+// domain, restricted to the IDs asked for.
+func (f *fakeNewRelicAPI) domainRecords(kind string, domainID string, ids []interface{}) []*fakeRecord {
+	out := []*fakeRecord{}
+	for _, rec := range f.list(kind, domainID) {
+		for _, id := range ids {
+			if fakeString(id) == rec.ID {
+				out = append(out, rec)
+			}
+		}
+	}
+
+	return out
+}
+
+func fakeOrganization(api string, authenticationDomains interface{}) interface{} {
+	return map[string]interface{}{
+		"actor": map[string]interface{}{
+			"organization": map[string]interface{}{
+				api: map[string]interface{}{"authenticationDomains": authenticationDomains},
+			},
+		},
+	}
+}
+
+func (f *fakeNewRelicAPI) userManagementUsers(vars map[string]interface{}) (interface{}, error) {
+	return fakeOrganization("userManagement", fakeAuthenticationDomainsPage(vars, func(domainID string) map[string]interface{} {
+		users := []interface{}{}
+		for _, rec := range f.domainRecords(fakeKindUser, domainID, fakeList(vars["id"])) {
+			users = append(users, rec.Data)
+		}
+
+		return map[string]interface{}{"users": map[string]interface{}{"users": users}}
+	})), nil
+}
+
+func (f *fakeNewRelicAPI) userManagementGroups(vars map[string]interface{}) (interface{}, error) {
+	return fakeOrganization("userManagement", fakeAuthenticationDomainsPage(vars, func(domainID string) map[string]interface{} {
+		groups := []interface{}{}
+		for _, rec := range f.domainRecords(fakeKindGroup, domainID, fakeList(vars["id"])) {
+			groups = append(groups, fakeGroup(rec.Data, vars["usersCursor"]))
+		}
+
+		return map[string]interface{}{"groups": map[string]interface{}{"groups": groups}}
+	})), nil
+}
+
+func (f *fakeNewRelicAPI) authorizationManagementGroups(vars map[string]interface{}) (interface{}, error) {
+	return fakeOrganization("authorizationManagement", fakeAuthenticationDomainsPage(vars, func(domainID string) map[string]interface{} {
+		groups := []interface{}{}
+		for _, rec := range f.domainRecords(fakeKindGroup, domainID, fakeList(vars["id"])) {
+			roles, nextCursor := fakeGroupPage(fakeList(rec.Data["roles"]), vars["rolesCursor"])
+			groups = append(groups, map[string]interface{}{
+				"id":    rec.ID,
+				"roles": map[string]interface{}{"nextCursor": nextCursor, "roles": roles},
+			})
+		}
+
+		return map[string]interface{}{"groups": map[string]interface{}{"groups": groups}}
+	})), nil
+}
@@ -0,0 +1,106 @@
+package newrelic
+
+import (
+	nr "github.com/newrelic/newrelic-client-go/newrelic"
+	"github.com/newrelic/newrelic-client-go/pkg/errors"
+)
+
+// The account management API is not yet exposed by newrelic-client-go, so
+// the provider issues the NerdGraph requests itself. Managed accounts are the
+// sub-accounts of the organization of the provider's API key.
+
+type managedAccount struct {
+	ID         int    `json:"id"`
+	Name       string `json:"name"`
+	RegionCode string `json:"regionCode"`
+}
+
+type managedAccountCreateInput struct {
+	Name       string `json:"name"`
+	RegionCode string `json:"regionCode"`
+}
+
+type managedAccountUpdateInput struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+const managedAccountFields = `id name regionCode`
+
+const listManagedAccountsQuery = `query {
+	actor { organization { accountManagement {
+		managedAccounts {` + managedAccountFields + `}
+	} } }
+}`
+
+const createManagedAccountMutation = `mutation($managedAccount: AccountManagementCreateInput!) {
+	accountManagementCreateAccount(managedAccount: $managedAccount) {
+		managedAccount {` + managedAccountFields + `}
+	}
+}`
+
+const updateManagedAccountMutation = `mutation($managedAccount: AccountManagementUpdateInput!) {
+	accountManagementUpdateAccount(managedAccount: $managedAccount) {
+		managedAccount {` + managedAccountFields + `}
+	}
+}`
+
+func getManagedAccount(client *nr.NewRelic, id int) (*managedAccount, error) {
+	var resp struct {
+		Actor struct {
+			Organization struct {
+				AccountManagement struct {
+					ManagedAccounts []managedAccount `json:"managedAccounts"`
+				} `json:"accountManagement"`
+			} `json:"organization"`
+		} `json:"actor"`
+	}
+
+	if err := client.NerdGraph.QueryWithResponse(listManagedAccountsQuery, nil, &resp); err != nil {
+		return nil, err
+	}
+
+	for _, a := range resp.Actor.Organization.AccountManagement.ManagedAccounts {
+		if a.ID == id {
+			return &a, nil
+		}
+	}
+
+	return nil, errors.NewNotFoundf("account %d not found", id)
+}
+
+func createManagedAccount(client *nr.NewRelic, input managedAccountCreateInput) (*managedAccount, error) {
+	var resp struct {
+		Result struct {
+			ManagedAccount *managedAccount `json:"managedAccount"`
+		} `json:"accountManagementCreateAccount"`
+	}
+
+	vars := map[string]interface{}{
+		"managedAccount": input,
+	}
+
+	if err := client.NerdGraph.QueryWithResponse(createManagedAccountMutation, vars, &resp); err != nil {
+		return nil, err
+	}
+
+	return resp.Result.ManagedAccount, nil
+}
+
+func updateManagedAccount(client *nr.NewRelic, input managedAccountUpdateInput) (*managedAccount, error) {
+	var resp struct {
+		Result struct {
+			ManagedAccount *managedAccount `json:"managedAccount"`
+		} `json:"accountManagementUpdateAccount"`
+	}
+
+	vars := map[string]interface{}{
+		"managedAccount": input,
+	}
+
+	if err := client.NerdGraph.QueryWithResponse(updateManagedAccountMutation, vars, &resp); err != nil {
+		return nil, err
+	}
+
+	return resp.Result.ManagedAccount, nil
+}
@@ -0,0 +1,114 @@
+package newrelic
+
+import (
+	nr "github.com/newrelic/newrelic-client-go/newrelic"
+)
+
+// The REST API cannot modify an existing alert channel, and newrelic-client-go
+// does not yet expose the NerdGraph mutation that can. Updating in place keeps
+// the channel's ID, and with it every policy the channel is attached to.
+
+type alertChannelEmailUpdate struct {
+	Name        string   `json:"name"`
+	Emails      []string `json:"emails"`
+	IncludeJSON bool     `json:"includeJson"`
+}
+
+// Fields omitted from an update are left untouched by the API, so the optional
+// fields of each channel type are always sent and cleared with null.
+type alertChannelOpsGenieUpdate struct {
+	Name             string   `json:"name"`
+	APIKey           *string  `json:"apiKey"`
+	DataCenterRegion *string  `json:"dataCenterRegion"`
+	Recipients       []string `json:"recipients"`
+	Tags             []string `json:"tags"`
+	Teams            []string `json:"teams"`
+}
+
+type alertChannelPagerDutyUpdate struct {
+	Name   string `json:"name"`
+	APIKey string `json:"apiKey,omitempty"`
+}
+
+type alertChannelSlackUpdate struct {
+	Name        string  `json:"name"`
+	TeamChannel *string `json:"teamChannel"`
+	URL         *string `json:"url"`
+}
+
+type alertChannelVictorOpsUpdate struct {
+	Name     string  `json:"name"`
+	Key      *string `json:"key"`
+	RouteKey *string `json:"routeKey"`
+}
+
+type alertChannelBasicAuth struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
+}
+
+type alertChannelHTTPHeader struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// The webhook fields which can be removed from the configuration are cleared
+// with an empty value or null.
+type alertChannelWebhookUpdate struct {
+	Name              string                   `json:"name"`
+	BaseURL           string                   `json:"baseUrl"`
+	BasicAuth         *alertChannelBasicAuth   `json:"basicAuth"`
+	CustomHTTPHeaders []alertChannelHTTPHeader `json:"customHttpHeaders"`
+	CustomPayloadBody *string                  `json:"customPayloadBody"`
+	CustomPayloadType *string                  `json:"customPayloadType"`
+}
+
+// alertChannelUpdateInput holds the configuration for exactly one channel type.
+type alertChannelUpdateInput struct {
+	Email     *alertChannelEmailUpdate     `json:"email,omitempty"`
+	OpsGenie  *alertChannelOpsGenieUpdate  `json:"opsGenie,omitempty"`
+	PagerDuty *alertChannelPagerDutyUpdate `json:"pagerDuty,omitempty"`
+	Slack     *alertChannelSlackUpdate     `json:"slack,omitempty"`
+	VictorOps *alertChannelVictorOpsUpdate `json:"victorOps,omitempty"`
+	Webhook   *alertChannelWebhookUpdate   `json:"webhook,omitempty"`
+}
+
+type alertChannelError struct {
+	Description string `json:"description"`
+	ErrorType   string `json:"errorType"`
+}
+
+func (e *alertChannelError) Error() string {
+	return e.ErrorType + ": " + e.Description
+}
+
+const updateAlertChannelMutation = `mutation($accountId: Int!, $id: ID!, $notificationChannel: AlertsNotificationChannelUpdateConfiguration!) {
+	alertsNotificationChannelUpdate(accountId: $accountId, id: $id, notificationChannel: $notificationChannel) {
+		notificationChannel { id name type }
+		error { description errorType }
+	}
+}`
+
+func updateAlertChannel(client *nr.NewRelic, accountID int, id string, input alertChannelUpdateInput) error {
+	var resp struct {
+		Result struct {
+			Error *alertChannelError `json:"error"`
+		} `json:"alertsNotificationChannelUpdate"`
+	}
+
+	vars := map[string]interface{}{
+		"accountId":           accountID,
+		"id":                  id,
+		"notificationChannel": input,
+	}
+
+	if err := client.NerdGraph.QueryWithResponse(updateAlertChannelMutation, vars, &resp); err != nil {
+		return err
+	}
+
+	if resp.Result.Error != nil {
+		return resp.Result.Error
+	}
+
+	return nil
+}
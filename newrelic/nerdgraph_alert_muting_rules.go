@@ -0,0 +1,51 @@
+package newrelic
+
+import (
+	nr "github.com/newrelic/newrelic-client-go/newrelic"
+	"github.com/newrelic/newrelic-client-go/pkg/alerts"
+)
+
+// The muting rule update input of newrelic-client-go omits every empty field,
+// which the API treats as leaving that field unchanged. Updating with the
+// provider's own input sends cleared schedule fields as null, which removes
+// them from the schedule.
+
+type mutingRuleScheduleUpdateInput struct {
+	StartTime        *alerts.NaiveDateTime            `json:"startTime"`
+	EndTime          *alerts.NaiveDateTime            `json:"endTime"`
+	TimeZone         string                           `json:"timeZone"`
+	Repeat           *alerts.MutingRuleScheduleRepeat `json:"repeat"`
+	EndRepeat        *alerts.NaiveDateTime            `json:"endRepeat"`
+	RepeatCount      *int                             `json:"repeatCount"`
+	WeeklyRepeatDays *[]alerts.DayOfWeek              `json:"weeklyRepeatDays"`
+}
+
+type mutingRuleUpdateInput struct {
+	Condition   *alerts.MutingRuleConditionGroup `json:"condition,omitempty"`
+	Description string                           `json:"description"`
+	Enabled     bool                             `json:"enabled"`
+	Name        string                           `json:"name"`
+	Schedule    *mutingRuleScheduleUpdateInput   `json:"schedule,omitempty"`
+}
+
+const updateMutingRuleMutation = `mutation($accountID: Int!, $ruleID: ID!, $rule: AlertsMutingRuleUpdateInput!) {
+	alertsMutingRuleUpdate(accountId: $accountID, id: $ruleID, rule: $rule) {
+		id
+	}
+}`
+
+func updateMutingRule(client *nr.NewRelic, accountID int, ruleID int, input mutingRuleUpdateInput) error {
+	var resp struct {
+		Result struct {
+			ID string `json:"id"`
+		} `json:"alertsMutingRuleUpdate"`
+	}
+
+	vars := map[string]interface{}{
+		"accountID": accountID,
+		"ruleID":    ruleID,
+		"rule":      input,
+	}
+
+	return client.NerdGraph.QueryWithResponse(updateMutingRuleMutation, vars, &resp)
+}
@@ -0,0 +1,281 @@
+package newrelic
+
+import (
+	"fmt"
+	"strings"
+
+	nr "github.com/newrelic/newrelic-client-go/newrelic"
+	"github.com/newrelic/newrelic-client-go/pkg/dashboards"
+	"github.com/newrelic/newrelic-client-go/pkg/entities"
+	"github.com/newrelic/newrelic-client-go/pkg/errors"
+)
+
+// newrelic-client-go does not yet support dashboard variables, so the
+// dashboard input and entity are extended here. The mutations themselves are
+// the ones exported by the client, which accept any valid DashboardInput.
+
+type dashboardVariableDefaultValue struct {
+	String string `json:"string"`
+}
+
+type dashboardVariableDefaultItem struct {
+	Value dashboardVariableDefaultValue `json:"value"`
+}
+
+type dashboardVariableEnumItem struct {
+	Title string `json:"title,omitempty"`
+	Value string `json:"value"`
+}
+
+type dashboardVariableNRQLQuery struct {
+	AccountIDs []int  `json:"accountIds"`
+	Query      string `json:"query"`
+}
+
+type dashboardVariable struct {
+	Name                string                         `json:"name"`
+	Title               string                         `json:"title,omitempty"`
+	Type                string                         `json:"type"`
+	DefaultValues       []dashboardVariableDefaultItem `json:"defaultValues,omitempty"`
+	IsMultiSelection    bool                           `json:"isMultiSelection"`
+	Items               []dashboardVariableEnumItem    `json:"items,omitempty"`
+	NRQLQuery           *dashboardVariableNRQLQuery    `json:"nrqlQuery,omitempty"`
+	ReplacementStrategy string                         `json:"replacementStrategy,omitempty"`
+}
+
+type dashboardInput struct {
+	dashboards.DashboardInput
+	Variables []dashboardVariable `json:"variables"`
+}
+
+type dashboardEntity struct {
+	*entities.DashboardEntity
+	Variables []dashboardVariable
+}
+
+type dashboardWidgetColorOverride struct {
+	Color      string `json:"color"`
+	SeriesName string `json:"seriesName"`
+}
+
+type dashboardWidgetColors struct {
+	Color           string                         `json:"color,omitempty"`
+	SeriesOverrides []dashboardWidgetColorOverride `json:"seriesOverrides,omitempty"`
+}
+
+type dashboardWidgetLegend struct {
+	Enabled bool `json:"enabled"`
+}
+
+type dashboardWidgetNullValueOverride struct {
+	NullValue  string `json:"nullValue"`
+	SeriesName string `json:"seriesName"`
+}
+
+type dashboardWidgetNullValues struct {
+	NullValue       string                             `json:"nullValue,omitempty"`
+	SeriesOverrides []dashboardWidgetNullValueOverride `json:"seriesOverrides,omitempty"`
+}
+
+type dashboardWidgetUnitOverride struct {
+	Unit       string `json:"unit"`
+	SeriesName string `json:"seriesName"`
+}
+
+type dashboardWidgetUnits struct {
+	Unit            string                        `json:"unit,omitempty"`
+	SeriesOverrides []dashboardWidgetUnitOverride `json:"seriesOverrides,omitempty"`
+}
+
+// dashboardWidgetNRQLQuery is a NRQL query in the rawConfiguration of a
+// widget, which unlike the typed configuration can span several accounts.
+type dashboardWidgetNRQLQuery struct {
+	AccountID  int    `json:"accountId,omitempty"`
+	AccountIDs []int  `json:"accountIds,omitempty"`
+	Query      string `json:"query"`
+}
+
+type dashboardWidgetYAxis struct {
+	Min *float64 `json:"min,omitempty"`
+	Max *float64 `json:"max,omitempty"`
+}
+
+// dashboardWidgetRawConfiguration is the rawConfiguration of a widget. It is
+// used for the widgets that have no typed configuration in
+// DashboardWidgetConfigurationInput, and for those that set options the typed
+// configuration does not support.
+type dashboardWidgetRawConfiguration struct {
+	Colors      *dashboardWidgetColors                              `json:"colors,omitempty"`
+	Legend      *dashboardWidgetLegend                              `json:"legend,omitempty"`
+	Limit       float64                                             `json:"limit,omitempty"`
+	NRQLQueries []dashboardWidgetNRQLQuery                          `json:"nrqlQueries"`
+	NullValues  *dashboardWidgetNullValues                          `json:"nullValues,omitempty"`
+	Thresholds  []dashboards.DashboardBillboardWidgetThresholdInput `json:"thresholds,omitempty"`
+	Units       *dashboardWidgetUnits                               `json:"units,omitempty"`
+	YAxisLeft   *dashboardWidgetYAxis                               `json:"yAxisLeft,omitempty"`
+}
+
+const getDashboardVariablesQuery = `query($guid: EntityGuid!) {
+	actor {
+		entity(guid: $guid) {
+			... on DashboardEntity {
+				variables {
+					name
+					title
+					type
+					defaultValues { value { string } }
+					isMultiSelection
+					items { title value }
+					nrqlQuery { accountIds query }
+					replacementStrategy
+				}
+			}
+		}
+	}
+}`
+
+const getDashboardJSONQuery = `query($guid: EntityGuid!) {
+	actor {
+		entity(guid: $guid) {
+			... on DashboardEntity {
+				accountId
+				guid
+				name
+				description
+				permalink
+				permissions
+				pages {
+					guid
+					name
+					description
+					widgets {
+						id
+						title
+						layout { column row width height }
+						visualization { id }
+						rawConfiguration
+						linkedEntities { guid }
+					}
+				}
+				variables {
+					name
+					title
+					type
+					defaultValues { value { string } }
+					isMultiSelection
+					items { title value }
+					nrqlQuery { accountIds query }
+					replacementStrategy
+				}
+			}
+		}
+	}
+}`
+
+// createDashboard creates a dashboard from a dashboardInput or, for
+// newrelic_one_dashboard_json, the decoded dashboard JSON.
+func createDashboard(client *nr.NewRelic, accountID int, input interface{}) (*dashboards.DashboardCreateResult, error) {
+	var resp struct {
+		Result dashboards.DashboardCreateResult `json:"dashboardCreate"`
+	}
+
+	vars := map[string]interface{}{
+		"accountId": accountID,
+		"dashboard": input,
+	}
+
+	if err := client.NerdGraph.QueryWithResponse(dashboards.DashboardCreateMutation, vars, &resp); err != nil {
+		return nil, err
+	}
+
+	if len(resp.Result.Errors) > 0 {
+		messages := make([]string, len(resp.Result.Errors))
+		for i, e := range resp.Result.Errors {
+			messages[i] = fmt.Sprintf("%s: %s", e.Type, e.Description)
+		}
+
+		return nil, fmt.Errorf("error creating dashboard: %s", strings.Join(messages, "; "))
+	}
+
+	return &resp.Result, nil
+}
+
+// updateDashboard replaces the dashboard with a dashboardInput or the decoded
+// dashboard JSON.
+func updateDashboard(client *nr.NewRelic, guid entities.EntityGUID, input interface{}) (*dashboards.DashboardUpdateResult, error) {
+	var resp struct {
+		Result dashboards.DashboardUpdateResult `json:"dashboardUpdate"`
+	}
+
+	vars := map[string]interface{}{
+		"dashboard": input,
+		"guid":      guid,
+	}
+
+	if err := client.NerdGraph.QueryWithResponse(dashboards.DashboardUpdateMutation, vars, &resp); err != nil {
+		return nil, err
+	}
+
+	if len(resp.Result.Errors) > 0 {
+		messages := make([]string, len(resp.Result.Errors))
+		for i, e := range resp.Result.Errors {
+			messages[i] = fmt.Sprintf("%s: %s", e.Type, e.Description)
+		}
+
+		return nil, fmt.Errorf("error updating dashboard %s: %s", guid, strings.Join(messages, "; "))
+	}
+
+	return &resp.Result, nil
+}
+
+func getDashboardEntity(client *nr.NewRelic, guid entities.EntityGUID) (*dashboardEntity, error) {
+	dashboard, err := client.Dashboards.GetDashboardEntity(guid)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp struct {
+		Actor struct {
+			Entity struct {
+				Variables []dashboardVariable `json:"variables"`
+			} `json:"entity"`
+		} `json:"actor"`
+	}
+
+	vars := map[string]interface{}{
+		"guid": guid,
+	}
+
+	if err := client.NerdGraph.QueryWithResponse(getDashboardVariablesQuery, vars, &resp); err != nil {
+		return nil, err
+	}
+
+	return &dashboardEntity{
+		DashboardEntity: dashboard,
+		Variables:       resp.Actor.Entity.Variables,
+	}, nil
+}
+
+// getDashboardJSON returns the dashboard in the shape of the JSON exported
+// from the New Relic One UI, along with the fields NerdGraph assigns.
+func getDashboardJSON(client *nr.NewRelic, guid entities.EntityGUID) (map[string]interface{}, error) {
+	var resp struct {
+		Actor struct {
+			Entity map[string]interface{} `json:"entity"`
+		} `json:"actor"`
+	}
+
+	vars := map[string]interface{}{
+		"guid": guid,
+	}
+
+	if err := client.NerdGraph.QueryWithResponse(getDashboardJSONQuery, vars, &resp); err != nil {
+		return nil, err
+	}
+
+	if resp.Actor.Entity == nil || resp.Actor.Entity["guid"] == nil {
+		return nil, errors.NewNotFoundf("dashboard %s not found", guid)
+	}
+
+	return resp.Actor.Entity, nil
+}
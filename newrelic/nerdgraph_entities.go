@@ -0,0 +1,158 @@
+package newrelic
+
+import (
+	nr "github.com/newrelic/newrelic-client-go/newrelic"
+	"github.com/newrelic/newrelic-client-go/pkg/entities"
+)
+
+// Searching entities with a query string, paging through the results, and
+// reading the golden metrics of an entity are not supported by
+// newrelic-client-go, so the provider issues the NerdGraph requests itself.
+
+type entityOutline struct {
+	AccountID               int         `json:"accountId"`
+	AlertSeverity           string      `json:"alertSeverity"`
+	ApplicationID           int         `json:"applicationId"`
+	Domain                  string      `json:"domain"`
+	GUID                    string      `json:"guid"`
+	Language                string      `json:"language"`
+	Name                    string      `json:"name"`
+	Permalink               string      `json:"permalink"`
+	Reporting               bool        `json:"reporting"`
+	ServingApmApplicationID int         `json:"servingApmApplicationId"`
+	Tags                    []entityTag `json:"tags"`
+	Type                    string      `json:"type"`
+}
+
+type entityTag struct {
+	Key    string   `json:"key"`
+	Values []string `json:"values"`
+}
+
+type entityGoldenMetric struct {
+	Name  string `json:"name"`
+	Query string `json:"query"`
+	Title string `json:"title"`
+}
+
+// entitySearchQueryBuilder is the EntitySearchQueryBuilder of newrelic-client-go
+// with a reporting filter which can also select the entities not reporting.
+type entitySearchQueryBuilder struct {
+	entities.EntitySearchQueryBuilder
+	Reporting *bool `json:"reporting,omitempty"`
+}
+
+const searchEntitiesQuery = `query($query: String, $queryBuilder: EntitySearchQueryBuilder, $cursor: String) {
+	actor {
+		entitySearch(query: $query, queryBuilder: $queryBuilder) {
+			results(cursor: $cursor) {
+				entities {
+					accountId
+					domain
+					guid
+					name
+					permalink
+					reporting
+					tags { key values }
+					type
+					... on AlertableEntityOutline { alertSeverity }
+					... on ApmApplicationEntityOutline { applicationId language }
+					... on BrowserApplicationEntityOutline { applicationId servingApmApplicationId }
+					... on MobileApplicationEntityOutline { applicationId }
+				}
+				nextCursor
+			}
+		}
+	}
+}`
+
+const getEntityGoldenMetricsQuery = `query($guid: EntityGuid!) {
+	actor {
+		entity(guid: $guid) {
+			goldenMetrics {
+				metrics {
+					name
+					query
+					title
+				}
+			}
+		}
+	}
+}`
+
+// searchEntities returns every entity matching an entity search query.
+func searchEntities(client *nr.NewRelic, query string) ([]entityOutline, error) {
+	return searchEntitiesWithBuilder(client, query, nil)
+}
+
+// searchEntitiesWithBuilder returns every entity matching an entity search
+// query, a query builder, or both.
+func searchEntitiesWithBuilder(client *nr.NewRelic, query string, builder *entitySearchQueryBuilder) ([]entityOutline, error) {
+	var found []entityOutline
+	var cursor *string
+
+	for {
+		var resp struct {
+			Actor struct {
+				EntitySearch struct {
+					Results struct {
+						Entities   []entityOutline `json:"entities"`
+						NextCursor *string         `json:"nextCursor"`
+					} `json:"results"`
+				} `json:"entitySearch"`
+			} `json:"actor"`
+		}
+
+		vars := map[string]interface{}{
+			"cursor": cursor,
+		}
+
+		if query != "" {
+			vars["query"] = query
+		}
+
+		if builder != nil {
+			vars["queryBuilder"] = builder
+		}
+
+		if err := client.NerdGraph.QueryWithResponse(searchEntitiesQuery, vars, &resp); err != nil {
+			return nil, err
+		}
+
+		results := resp.Actor.EntitySearch.Results
+		found = append(found, results.Entities...)
+
+		if results.NextCursor == nil || *results.NextCursor == "" {
+			return found, nil
+		}
+		cursor = results.NextCursor
+	}
+}
+
+// getEntityGoldenMetrics returns the golden metrics of an entity, the metrics
+// New Relic considers the most important to monitor its health.
+func getEntityGoldenMetrics(client *nr.NewRelic, guid string) ([]entityGoldenMetric, error) {
+	var resp struct {
+		Actor struct {
+			Entity *struct {
+				GoldenMetrics struct {
+					Metrics []entityGoldenMetric `json:"metrics"`
+				} `json:"goldenMetrics"`
+			} `json:"entity"`
+		} `json:"actor"`
+	}
+
+	vars := map[string]interface{}{
+		"guid": guid,
+	}
+
+	if err := client.NerdGraph.QueryWithResponse(getEntityGoldenMetricsQuery, vars, &resp); err != nil {
+		return nil, err
+	}
+
+	if resp.Actor.Entity == nil {
+		return nil, nil
+	}
+
+	return resp.Actor.Entity.GoldenMetrics.Metrics, nil
+}
@@ -0,0 +1,424 @@
+package newrelic
+
+import (
+	"fmt"
+	"strings"
+
+	nr "github.com/newrelic/newrelic-client-go/newrelic"
+	"github.com/newrelic/newrelic-client-go/pkg/errors"
+)
+
+// The notification destination and channel APIs are not yet exposed by
+// newrelic-client-go, so the provider issues the NerdGraph requests itself.
+
+type notificationProperty struct {
+	Key          string `json:"key"`
+	Value        string `json:"value"`
+	Label        string `json:"label,omitempty"`
+	DisplayValue string `json:"displayValue,omitempty"`
+}
+
+type notificationAuth struct {
+	AuthType string `json:"authType,omitempty"`
+	User     string `json:"user,omitempty"`
+	Prefix   string `json:"prefix,omitempty"`
+}
+
+type notificationBasicAuthInput struct {
+	User     string `json:"user"`
+	Password string `json:"password"`
+}
+
+type notificationTokenAuthInput struct {
+	Prefix string `json:"prefix,omitempty"`
+	Token  string `json:"token"`
+}
+
+type notificationAuthInput struct {
+	Type  string                      `json:"type"`
+	Basic *notificationBasicAuthInput `json:"basic,omitempty"`
+	Token *notificationTokenAuthInput `json:"token,omitempty"`
+}
+
+type notificationDestination struct {
+	ID         string                 `json:"id"`
+	AccountID  int                    `json:"accountId"`
+	Name       string                 `json:"name"`
+	Type       string                 `json:"type"`
+	Active     bool                   `json:"active"`
+	Status     string                 `json:"status"`
+	Properties []notificationProperty `json:"properties"`
+	Auth       *notificationAuth      `json:"auth"`
+}
+
+type notificationDestinationInput struct {
+	Name       string                 `json:"name"`
+	Type       string                 `json:"type"`
+	Properties []notificationProperty `json:"properties"`
+	Auth       *notificationAuthInput `json:"auth,omitempty"`
+}
+
+type notificationDestinationUpdate struct {
+	Name        string                 `json:"name"`
+	Active      bool                   `json:"active"`
+	Properties  []notificationProperty `json:"properties"`
+	Auth        *notificationAuthInput `json:"auth,omitempty"`
+	DisableAuth bool                   `json:"disableAuth"`
+}
+
+type notificationChannel struct {
+	ID            string                 `json:"id"`
+	AccountID     int                    `json:"accountId"`
+	Name          string                 `json:"name"`
+	Type          string                 `json:"type"`
+	DestinationID string                 `json:"destinationId"`
+	Product       string                 `json:"product"`
+	Active        bool                   `json:"active"`
+	Status        string                 `json:"status"`
+	Properties    []notificationProperty `json:"properties"`
+}
+
+type notificationChannelInput struct {
+	Name          string                 `json:"name"`
+	Type          string                 `json:"type"`
+	DestinationID string                 `json:"destinationId"`
+	Product       string                 `json:"product"`
+	Properties    []notificationProperty `json:"properties"`
+}
+
+type notificationChannelUpdate struct {
+	Name       string                 `json:"name"`
+	Active     bool                   `json:"active"`
+	Properties []notificationProperty `json:"properties"`
+}
+
+// notificationError is the error union returned in the payload of
+// notification mutations and queries.
+type notificationError struct {
+	Description string `json:"description"`
+	Details     string `json:"details"`
+	Type        string `json:"type"`
+	Fields      []struct {
+		Field   string `json:"field"`
+		Message string `json:"message"`
+	} `json:"fields"`
+}
+
+func (e *notificationError) Error() string {
+	msg := e.Description
+	if msg == "" {
+		msg = e.Details
+	}
+
+	for _, f := range e.Fields {
+		msg = fmt.Sprintf("%s; %s: %s", msg, f.Field, f.Message)
+	}
+
+	return strings.TrimPrefix(msg, "; ")
+}
+
+const (
+	notificationPropertyFields = `properties { key value label displayValue }`
+
+	notificationErrorFields = `
+		... on AiNotificationsResponseError { description details type }
+		... on AiNotificationsDataValidationError { details fields { field message } }
+		... on AiNotificationsSuiteError { description details type }`
+
+	notificationDestinationFields = `
+		id accountId name type active status
+		` + notificationPropertyFields + `
+		auth {
+			... on AiNotificationsBasicAuth { authType user }
+			... on AiNotificationsTokenAuth { authType prefix }
+		}`
+
+	notificationChannelFields = `
+		id accountId name type destinationId product active status
+		` + notificationPropertyFields
+)
+
+const getNotificationDestinationQuery = `query($accountId: Int!, $id: ID!) {
+	actor { account(id: $accountId) { aiNotifications {
+		destinations(filters: { id: $id }) {
+			entities {` + notificationDestinationFields + `}
+			error {` + notificationErrorFields + `}
+		}
+	} } }
+}`
+
+const createNotificationDestinationMutation = `mutation($accountId: Int!, $destination: AiNotificationsDestinationInput!) {
+	aiNotificationsCreateDestination(accountId: $accountId, destination: $destination) {
+		destination {` + notificationDestinationFields + `}
+		error {` + notificationErrorFields + `}
+	}
+}`
+
+const updateNotificationDestinationMutation = `mutation($accountId: Int!, $destinationId: ID!, $destination: AiNotificationsDestinationUpdate!) {
+	aiNotificationsUpdateDestination(accountId: $accountId, destinationId: $destinationId, destination: $destination) {
+		destination {` + notificationDestinationFields + `}
+		error {` + notificationErrorFields + `}
+	}
+}`
+
+const deleteNotificationDestinationMutation = `mutation($accountId: Int!, $destinationId: ID!) {
+	aiNotificationsDeleteDestination(accountId: $accountId, destinationId: $destinationId) {
+		ids
+		error {` + notificationErrorFields + `}
+	}
+}`
+
+const getNotificationChannelQuery = `query($accountId: Int!, $id: ID!) {
+	actor { account(id: $accountId) { aiNotifications {
+		channels(filters: { id: $id }) {
+			entities {` + notificationChannelFields + `}
+			error {` + notificationErrorFields + `}
+		}
+	} } }
+}`
+
+const createNotificationChannelMutation = `mutation($accountId: Int!, $channel: AiNotificationsChannelInput!) {
+	aiNotificationsCreateChannel(accountId: $accountId, channel: $channel) {
+		channel {` + notificationChannelFields + `}
+		error {` + notificationErrorFields + `}
+	}
+}`
+
+const updateNotificationChannelMutation = `mutation($accountId: Int!, $channelId: ID!, $channel: AiNotificationsChannelUpdate!) {
+	aiNotificationsUpdateChannel(accountId: $accountId, channelId: $channelId, channel: $channel) {
+		channel {` + notificationChannelFields + `}
+		error {` + notificationErrorFields + `}
+	}
+}`
+
+const deleteNotificationChannelMutation = `mutation($accountId: Int!, $channelId: ID!) {
+	aiNotificationsDeleteChannel(accountId: $accountId, channelId: $channelId) {
+		ids
+		error {` + notificationErrorFields + `}
+	}
+}`
+
+type notificationDestinationResult struct {
+	Destination *notificationDestination `json:"destination"`
+	Error       *notificationError       `json:"error"`
+}
+
+type notificationChannelResult struct {
+	Channel *notificationChannel `json:"channel"`
+	Error   *notificationError   `json:"error"`
+}
+
+type notificationDeleteResult struct {
+	IDs   []string           `json:"ids"`
+	Error *notificationError `json:"error"`
+}
+
+func getNotificationDestination(client *nr.NewRelic, accountID int, id string) (*notificationDestination, error) {
+	var resp struct {
+		Actor struct {
+			Account struct {
+				AINotifications struct {
+					Destinations struct {
+						Entities []notificationDestination `json:"entities"`
+						Error    *notificationError        `json:"error"`
+					} `json:"destinations"`
+				} `json:"aiNotifications"`
+			} `json:"account"`
+		} `json:"actor"`
+	}
+
+	vars := map[string]interface{}{
+		"accountId": accountID,
+		"id":        id,
+	}
+
+	if err := client.NerdGraph.QueryWithResponse(getNotificationDestinationQuery, vars, &resp); err != nil {
+		return nil, err
+	}
+
+	destinations := resp.Actor.Account.AINotifications.Destinations
+	if destinations.Error != nil {
+		return nil, destinations.Error
+	}
+
+	if len(destinations.Entities) == 0 {
+		return nil, errors.NewNotFoundf("notification destination %s not found", id)
+	}
+
+	return &destinations.Entities[0], nil
+}
+
+func createNotificationDestination(client *nr.NewRelic, accountID int, input notificationDestinationInput) (*notificationDestination, error) {
+	var resp struct {
+		Result notificationDestinationResult `json:"aiNotificationsCreateDestination"`
+	}
+
+	vars := map[string]interface{}{
+		"accountId":   accountID,
+		"destination": input,
+	}
+
+	if err := client.NerdGraph.QueryWithResponse(createNotificationDestinationMutation, vars, &resp); err != nil {
+		return nil, err
+	}
+
+	if resp.Result.Error != nil {
+		return nil, resp.Result.Error
+	}
+
+	return resp.Result.Destination, nil
+}
+
+func updateNotificationDestination(client *nr.NewRelic, accountID int, id string, input notificationDestinationUpdate) (*notificationDestination, error) {
+	var resp struct {
+		Result notificationDestinationResult `json:"aiNotificationsUpdateDestination"`
+	}
+
+	vars := map[string]interface{}{
+		"accountId":     accountID,
+		"destinationId": id,
+		"destination":   input,
+	}
+
+	if err := client.NerdGraph.QueryWithResponse(updateNotificationDestinationMutation, vars, &resp); err != nil {
+		return nil, err
+	}
+
+	if resp.Result.Error != nil {
+		return nil, resp.Result.Error
+	}
+
+	return resp.Result.Destination, nil
+}
+
+func deleteNotificationDestination(client *nr.NewRelic, accountID int, id string) error {
+	var resp struct {
+		Result notificationDeleteResult `json:"aiNotificationsDeleteDestination"`
+	}
+
+	vars := map[string]interface{}{
+		"accountId":     accountID,
+		"destinationId": id,
+	}
+
+	if err := client.NerdGraph.QueryWithResponse(deleteNotificationDestinationMutation, vars, &resp); err != nil {
+		return err
+	}
+
+	if resp.Result.Error != nil {
+		if _, err := getNotificationDestination(client, accountID, id); err != nil {
+			if _, ok := err.(*errors.NotFound); ok {
+				return err
+			}
+		}
+
+		return resp.Result.Error
+	}
+
+	return nil
+}
+
+func getNotificationChannel(client *nr.NewRelic, accountID int, id string) (*notificationChannel, error) {
+	var resp struct {
+		Actor struct {
+			Account struct {
+				AINotifications struct {
+					Channels struct {
+						Entities []notificationChannel `json:"entities"`
+						Error    *notificationError    `json:"error"`
+					} `json:"channels"`
+				} `json:"aiNotifications"`
+			} `json:"account"`
+		} `json:"actor"`
+	}
+
+	vars := map[string]interface{}{
+		"accountId": accountID,
+		"id":        id,
+	}
+
+	if err := client.NerdGraph.QueryWithResponse(getNotificationChannelQuery, vars, &resp); err != nil {
+		return nil, err
+	}
+
+	channels := resp.Actor.Account.AINotifications.Channels
+	if channels.Error != nil {
+		return nil, channels.Error
+	}
+
+	if len(channels.Entities) == 0 {
+		return nil, errors.NewNotFoundf("notification channel %s not found", id)
+	}
+
+	return &channels.Entities[0], nil
+}
+
+func createNotificationChannel(client *nr.NewRelic, accountID int, input notificationChannelInput) (*notificationChannel, error) {
+	var resp struct {
+		Result notificationChannelResult `json:"aiNotificationsCreateChannel"`
+	}
+
+	vars := map[string]interface{}{
+		"accountId": accountID,
+		"channel":   input,
+	}
+
+	if err := client.NerdGraph.QueryWithResponse(createNotificationChannelMutation, vars, &resp); err != nil {
+		return nil, err
+	}
+
+	if resp.Result.Error != nil {
+		return nil, resp.Result.Error
+	}
+
+	return resp.Result.Channel, nil
+}
+
+func updateNotificationChannel(client *nr.NewRelic, accountID int, id string, input notificationChannelUpdate) (*notificationChannel, error) {
+	var resp struct {
+		Result notificationChannelResult `json:"aiNotificationsUpdateChannel"`
+	}
+
+	vars := map[string]interface{}{
+		"accountId": accountID,
+		"channelId": id,
+		"channel":   input,
+	}
+
+	if err := client.NerdGraph.QueryWithResponse(updateNotificationChannelMutation, vars, &resp); err != nil {
+		return nil, err
+	}
+
+	if resp.Result.Error != nil {
+		return nil, resp.Result.Error
+	}
+
+	return resp.Result.Channel, nil
+}
+
+func deleteNotificationChannel(client *nr.NewRelic, accountID int, id string) error {
+	var resp struct {
+		Result notificationDeleteResult `json:"aiNotificationsDeleteChannel"`
+	}
+
+	vars := map[string]interface{}{
+		"accountId": accountID,
+		"channelId": id,
+	}
+
+	if err := client.NerdGraph.QueryWithResponse(deleteNotificationChannelMutation, vars, &resp); err != nil {
+		return err
+	}
+
+	if resp.Result.Error != nil {
+		if _, err := getNotificationChannel(client, accountID, id); err != nil {
+			if _, ok := err.(*errors.NotFound); ok {
+				return err
+			}
+		}
+
+		return resp.Result.Error
+	}
+
+	return nil
+}
@@ -0,0 +1,551 @@
+package newrelic
+
+import (
+	nr "github.com/newrelic/newrelic-client-go/newrelic"
+	"github.com/newrelic/newrelic-client-go/pkg/errors"
+)
+
+// The user and authorization management APIs are not yet exposed by
+// newrelic-client-go, so the provider issues the NerdGraph requests itself.
+// Users and groups belong to an authentication domain of the organization,
+// and groups are granted roles on accounts.
+
+// The user types as requested when writing a user, and as displayed when
+// reading it back.
+var userTypes = map[string]string{
+	"BASIC_USER_TIER": "Basic",
+	"CORE_USER_TIER":  "Core",
+	"FULL_USER_TIER":  "Full platform",
+}
+
+type userType struct {
+	ID          string `json:"id"`
+	DisplayName string `json:"displayName"`
+}
+
+type user struct {
+	ID    string   `json:"id"`
+	Name  string   `json:"name"`
+	Email string   `json:"email"`
+	Type  userType `json:"type"`
+}
+
+type userCreateInput struct {
+	AuthenticationDomainID string `json:"authenticationDomainId"`
+	Name                   string `json:"name"`
+	Email                  string `json:"email"`
+	UserType               string `json:"userType"`
+}
+
+type userUpdateInput struct {
+	ID       string `json:"id"`
+	Name     string `json:"name"`
+	Email    string `json:"email"`
+	UserType string `json:"userType"`
+}
+
+type groupUser struct {
+	ID string `json:"id"`
+}
+
+// groupUsersPage and groupRolesPage hold a page of the members and roles of
+// a group.
+type groupUsersPage struct {
+	NextCursor string      `json:"nextCursor"`
+	Users      []groupUser `json:"users"`
+}
+
+type group struct {
+	ID          string         `json:"id"`
+	DisplayName string         `json:"displayName"`
+	Users       groupUsersPage `json:"users"`
+}
+
+type groupCreateInput struct {
+	AuthenticationDomainID string `json:"authenticationDomainId"`
+	DisplayName            string `json:"displayName"`
+}
+
+type groupUpdateInput struct {
+	ID          string `json:"id"`
+	DisplayName string `json:"displayName"`
+}
+
+type groupUsersInput struct {
+	GroupIDs []string `json:"groupIds"`
+	UserIDs  []string `json:"userIds"`
+}
+
+type groupRole struct {
+	AccountID int    `json:"accountId"`
+	RoleID    string `json:"roleId"`
+}
+
+type groupRolesPage struct {
+	NextCursor string      `json:"nextCursor"`
+	Roles      []groupRole `json:"roles"`
+}
+
+type groupAccessInput struct {
+	GroupID             string      `json:"groupId"`
+	AccountAccessGrants []groupRole `json:"accountAccessGrants"`
+}
+
+const (
+	userFields  = `id name email type { id displayName }`
+	groupFields = `id displayName users { nextCursor users { id } }`
+)
+
+const getUserQuery = `query($id: [ID!], $cursor: String) {
+	actor { organization { userManagement {
+		authenticationDomains(cursor: $cursor) {
+			nextCursor
+			authenticationDomains { id users(id: $id) { users {` + userFields + `} } }
+		}
+	} } }
+}`
+
+const getGroupQuery = `query($id: [ID!], $cursor: String) {
+	actor { organization { userManagement {
+		authenticationDomains(cursor: $cursor) {
+			nextCursor
+			authenticationDomains { id groups(id: $id) { groups {` + groupFields + `} } }
+		}
+	} } }
+}`
+
+// The following pages of the members of a group are read from its
+// authentication domain.
+const getGroupUsersQuery = `query($domainId: [ID!], $id: [ID!], $usersCursor: String) {
+	actor { organization { userManagement {
+		authenticationDomains(id: $domainId) {
+			authenticationDomains { id groups(id: $id) { groups { id users(cursor: $usersCursor) { nextCursor users { id } } } } }
+		}
+	} } }
+}`
+
+const getGroupRolesQuery = `query($id: [ID!], $cursor: String) {
+	actor { organization { authorizationManagement {
+		authenticationDomains(cursor: $cursor) {
+			nextCursor
+			authenticationDomains { id groups(id: $id) { groups { id roles { nextCursor roles { accountId roleId } } } } }
+		}
+	} } }
+}`
+
+// The following pages of the roles of a group are read from its
+// authentication domain.
+const getGroupRolesPageQuery = `query($domainId: [ID!], $id: [ID!], $rolesCursor: String) {
+	actor { organization { authorizationManagement {
+		authenticationDomains(id: $domainId) {
+			authenticationDomains { id groups(id: $id) { groups { id roles(cursor: $rolesCursor) { nextCursor roles { accountId roleId } } } } }
+		}
+	} } }
+}`
+
+const createUserMutation = `mutation($createUserOptions: UserManagementCreateUser!) {
+	userManagementCreateUser(createUserOptions: $createUserOptions) {
+		createdUser {` + userFields + `}
+	}
+}`
+
+const updateUserMutation = `mutation($updateUserOptions: UserManagementUpdateUser!) {
+	userManagementUpdateUser(updateUserOptions: $updateUserOptions) {
+		user {` + userFields + `}
+	}
+}`
+
+const deleteUserMutation = `mutation($deleteUserOptions: UserManagementDeleteUser!) {
+	userManagementDeleteUser(deleteUserOptions: $deleteUserOptions) {
+		deletedUser { id }
+	}
+}`
+
+const createGroupMutation = `mutation($createGroupOptions: UserManagementCreateGroup!) {
+	userManagementCreateGroup(createGroupOptions: $createGroupOptions) {
+		group { id displayName }
+	}
+}`
+
+const updateGroupMutation = `mutation($updateGroupOptions: UserManagementUpdateGroup!) {
+	userManagementUpdateGroup(updateGroupOptions: $updateGroupOptions) {
+		group { id displayName }
+	}
+}`
+
+const deleteGroupMutation = `mutation($groupOptions: UserManagementDeleteGroup!) {
+	userManagementDeleteGroup(groupOptions: $groupOptions) {
+		group { id }
+	}
+}`
+
+const addUsersToGroupsMutation = `mutation($addUsersToGroupsOptions: UserManagementUsersGroupsInput!) {
+	userManagementAddUsersToGroups(addUsersToGroupsOptions: $addUsersToGroupsOptions) {
+		groups { id }
+	}
+}`
+
+const removeUsersFromGroupsMutation = `mutation($removeUsersFromGroupsOptions: UserManagementUsersGroupsInput!) {
+	userManagementRemoveUsersFromGroups(removeUsersFromGroupsOptions: $removeUsersFromGroupsOptions) {
+		groups { id }
+	}
+}`
+
+const grantAccessMutation = `mutation($grantAccessOptions: AuthorizationManagementGrantAccess!) {
+	authorizationManagementGrantAccess(grantAccessOptions: $grantAccessOptions) {
+		roles { id }
+	}
+}`
+
+const revokeAccessMutation = `mutation($revokeAccessOptions: AuthorizationManagementRevokeAccess!) {
+	authorizationManagementRevokeAccess(revokeAccessOptions: $revokeAccessOptions) {
+		roles { id }
+	}
+}`
+
+// The users, groups and group roles of the organization are listed per
+// authentication domain, a page of domains at a time.
+
+type userDomainsPage struct {
+	NextCursor            string `json:"nextCursor"`
+	AuthenticationDomains []struct {
+		ID    string `json:"id"`
+		Users struct {
+			Users []user `json:"users"`
+		} `json:"users"`
+	} `json:"authenticationDomains"`
+}
+
+type groupDomainsPage struct {
+	NextCursor            string `json:"nextCursor"`
+	AuthenticationDomains []struct {
+		ID     string `json:"id"`
+		Groups struct {
+			Groups []group `json:"groups"`
+		} `json:"groups"`
+	} `json:"authenticationDomains"`
+}
+
+type groupRoleDomainsPage struct {
+	NextCursor            string `json:"nextCursor"`
+	AuthenticationDomains []struct {
+		ID     string `json:"id"`
+		Groups struct {
+			Groups []struct {
+				ID    string         `json:"id"`
+				Roles groupRolesPage `json:"roles"`
+			} `json:"groups"`
+		} `json:"groups"`
+	} `json:"authenticationDomains"`
+}
+
+// getUser returns a user along with the ID of its authentication domain.
+func getUser(client *nr.NewRelic, id string) (*user, string, error) {
+	vars := map[string]interface{}{
+		"id": []string{id},
+	}
+
+	for {
+		var resp struct {
+			Actor struct {
+				Organization struct {
+					UserManagement struct {
+						AuthenticationDomains userDomainsPage `json:"authenticationDomains"`
+					} `json:"userManagement"`
+				} `json:"organization"`
+			} `json:"actor"`
+		}
+
+		if err := client.NerdGraph.QueryWithResponse(getUserQuery, vars, &resp); err != nil {
+			return nil, "", err
+		}
+
+		page := resp.Actor.Organization.UserManagement.AuthenticationDomains
+		for _, domain := range page.AuthenticationDomains {
+			for _, u := range domain.Users.Users {
+				if u.ID == id {
+					return &u, domain.ID, nil
+				}
+			}
+		}
+
+		if page.NextCursor == "" {
+			return nil, "", errors.NewNotFoundf("user %s not found", id)
+		}
+
+		vars["cursor"] = page.NextCursor
+	}
+}
+
+// getGroup returns a group along with the ID of its authentication domain.
+func getGroup(client *nr.NewRelic, id string) (*group, string, error) {
+	vars := map[string]interface{}{
+		"id": []string{id},
+	}
+
+	for {
+		var resp struct {
+			Actor struct {
+				Organization struct {
+					UserManagement struct {
+						AuthenticationDomains groupDomainsPage `json:"authenticationDomains"`
+					} `json:"userManagement"`
+				} `json:"organization"`
+			} `json:"actor"`
+		}
+
+		if err := client.NerdGraph.QueryWithResponse(getGroupQuery, vars, &resp); err != nil {
+			return nil, "", err
+		}
+
+		page := resp.Actor.Organization.UserManagement.AuthenticationDomains
+		for _, domain := range page.AuthenticationDomains {
+			for _, g := range domain.Groups.Groups {
+				if g.ID == id {
+					users, err := getGroupUsers(client, domain.ID, g.ID, g.Users)
+					if err != nil {
+						return nil, "", err
+					}
+
+					g.Users = groupUsersPage{Users: users}
+
+					return &g, domain.ID, nil
+				}
+			}
+		}
+
+		if page.NextCursor == "" {
+			return nil, "", errors.NewNotFoundf("group %s not found", id)
+		}
+
+		vars["cursor"] = page.NextCursor
+	}
+}
+
+// getGroupRoles returns the roles a group is granted on accounts.
+func getGroupRoles(client *nr.NewRelic, groupID string) ([]groupRole, error) {
+	vars := map[string]interface{}{
+		"id": []string{groupID},
+	}
+
+	for {
+		var resp struct {
+			Actor struct {
+				Organization struct {
+					AuthorizationManagement struct {
+						AuthenticationDomains groupRoleDomainsPage `json:"authenticationDomains"`
+					} `json:"authorizationManagement"`
+				} `json:"organization"`
+			} `json:"actor"`
+		}
+
+		if err := client.NerdGraph.QueryWithResponse(getGroupRolesQuery, vars, &resp); err != nil {
+			return nil, err
+		}
+
+		page := resp.Actor.Organization.AuthorizationManagement.AuthenticationDomains
+		for _, domain := range page.AuthenticationDomains {
+			for _, g := range domain.Groups.Groups {
+				if g.ID == groupID {
+					return getGroupRolePages(client, domain.ID, g.ID, g.Roles)
+				}
+			}
+		}
+
+		if page.NextCursor == "" {
+			return nil, errors.NewNotFoundf("group %s not found", groupID)
+		}
+
+		vars["cursor"] = page.NextCursor
+	}
+}
+
+// getGroupUsers returns the members of a group, reading the pages following
+// the first one.
+func getGroupUsers(client *nr.NewRelic, domainID string, groupID string, first groupUsersPage) ([]groupUser, error) {
+	users := first.Users
+
+	vars := map[string]interface{}{
+		"domainId": []string{domainID},
+		"id":       []string{groupID},
+	}
+
+	for cursor := first.NextCursor; cursor != ""; {
+		var resp struct {
+			Actor struct {
+				Organization struct {
+					UserManagement struct {
+						AuthenticationDomains groupDomainsPage `json:"authenticationDomains"`
+					} `json:"userManagement"`
+				} `json:"organization"`
+			} `json:"actor"`
+		}
+
+		vars["usersCursor"] = cursor
+		if err := client.NerdGraph.QueryWithResponse(getGroupUsersQuery, vars, &resp); err != nil {
+			return nil, err
+		}
+
+		found := false
+		for _, domain := range resp.Actor.Organization.UserManagement.AuthenticationDomains.AuthenticationDomains {
+			for _, g := range domain.Groups.Groups {
+				if g.ID == groupID {
+					users = append(users, g.Users.Users...)
+					cursor = g.Users.NextCursor
+					found = true
+				}
+			}
+		}
+
+		if !found {
+			return nil, errors.NewNotFoundf("group %s not found", groupID)
+		}
+	}
+
+	return users, nil
+}
+
+// getGroupRolePages returns the roles of a group, reading the pages following
+// the first one.
+func getGroupRolePages(client *nr.NewRelic, domainID string, groupID string, first groupRolesPage) ([]groupRole, error) {
+	roles := first.Roles
+
+	vars := map[string]interface{}{
+		"domainId": []string{domainID},
+		"id":       []string{groupID},
+	}
+
+	for cursor := first.NextCursor; cursor != ""; {
+		var resp struct {
+			Actor struct {
+				Organization struct {
+					AuthorizationManagement struct {
+						AuthenticationDomains groupRoleDomainsPage `json:"authenticationDomains"`
+					} `json:"authorizationManagement"`
+				} `json:"organization"`
+			} `json:"actor"`
+		}
+
+		vars["rolesCursor"] = cursor
+		if err := client.NerdGraph.QueryWithResponse(getGroupRolesPageQuery, vars, &resp); err != nil {
+			return nil, err
+		}
+
+		found := false
+		for _, domain := range resp.Actor.Organization.AuthorizationManagement.AuthenticationDomains.AuthenticationDomains {
+			for _, g := range domain.Groups.Groups {
+				if g.ID == groupID {
+					roles = append(roles, g.Roles.Roles...)
+					cursor = g.Roles.NextCursor
+					found = true
+				}
+			}
+		}
+
+		if !found {
+			return nil, errors.NewNotFoundf("group %s not found", groupID)
+		}
+	}
+
+	return roles, nil
+}
+
+func createUser(client *nr.NewRelic, input userCreateInput) (*user, error) {
+	var resp struct {
+		Result struct {
+			CreatedUser *user `json:"createdUser"`
+		} `json:"userManagementCreateUser"`
+	}
+
+	vars := map[string]interface{}{
+		"createUserOptions": input,
+	}
+
+	if err := client.NerdGraph.QueryWithResponse(createUserMutation, vars, &resp); err != nil {
+		return nil, err
+	}
+
+	return resp.Result.CreatedUser, nil
+}
+
+func updateUser(client *nr.NewRelic, input userUpdateInput) error {
+	vars := map[string]interface{}{
+		"updateUserOptions": input,
+	}
+
+	return client.NerdGraph.QueryWithResponse(updateUserMutation, vars, &struct{}{})
+}
+
+func deleteUser(client *nr.NewRelic, id string) error {
+	vars := map[string]interface{}{
+		"deleteUserOptions": map[string]interface{}{"id": id},
+	}
+
+	return client.NerdGraph.QueryWithResponse(deleteUserMutation, vars, &struct{}{})
+}
+
+func createGroup(client *nr.NewRelic, input groupCreateInput) (*group, error) {
+	var resp struct {
+		Result struct {
+			Group *group `json:"group"`
+		} `json:"userManagementCreateGroup"`
+	}
+
+	vars := map[string]interface{}{
+		"createGroupOptions": input,
+	}
+
+	if err := client.NerdGraph.QueryWithResponse(createGroupMutation, vars, &resp); err != nil {
+		return nil, err
+	}
+
+	return resp.Result.Group, nil
+}
+
+func updateGroup(client *nr.NewRelic, input groupUpdateInput) error {
+	vars := map[string]interface{}{
+		"updateGroupOptions": input,
+	}
+
+	return client.NerdGraph.QueryWithResponse(updateGroupMutation, vars, &struct{}{})
+}
+
+func deleteGroup(client *nr.NewRelic, id string) error {
+	vars := map[string]interface{}{
+		"groupOptions": map[string]interface{}{"id": id},
+	}
+
+	return client.NerdGraph.QueryWithResponse(deleteGroupMutation, vars, &struct{}{})
+}
+
+func addUsersToGroup(client *nr.NewRelic, groupID string, userIDs []string) error {
+	vars := map[string]interface{}{
+		"addUsersToGroupsOptions": groupUsersInput{GroupIDs: []string{groupID}, UserIDs: userIDs},
+	}
+
+	return client.NerdGraph.QueryWithResponse(addUsersToGroupsMutation, vars, &struct{}{})
+}
+
+func removeUsersFromGroup(client *nr.NewRelic, groupID string, userIDs []string) error {
+	vars := map[string]interface{}{
+		"removeUsersFromGroupsOptions": groupUsersInput{GroupIDs: []string{groupID}, UserIDs: userIDs},
+	}
+
+	return client.NerdGraph.QueryWithResponse(removeUsersFromGroupsMutation, vars, &struct{}{})
+}
+
+func grantGroupRole(client *nr.NewRelic, groupID string, role groupRole) error {
+	vars := map[string]interface{}{
+		"grantAccessOptions": groupAccessInput{GroupID: groupID, AccountAccessGrants: []groupRole{role}},
+	}
+
+	return client.NerdGraph.QueryWithResponse(grantAccessMutation, vars, &struct{}{})
+}
+
+func revokeGroupRole(client *nr.NewRelic, groupID string, role groupRole) error {
+	vars := map[string]interface{}{
+		"revokeAccessOptions": groupAccessInput{GroupID: groupID, AccountAccessGrants: []groupRole{role}},
+	}
+
+	return client.NerdGraph.QueryWithResponse(revokeAccessMutation, vars, &struct{}{})
+}
@@ -0,0 +1,270 @@
+package newrelic
+
+import (
+	"strings"
+
+	nr "github.com/newrelic/newrelic-client-go/newrelic"
+	"github.com/newrelic/newrelic-client-go/pkg/errors"
+)
+
+// The workflows API is not yet exposed by newrelic-client-go, so the
+// provider issues the NerdGraph requests itself.
+
+type workflowPredicate struct {
+	Attribute string   `json:"attribute"`
+	Operator  string   `json:"operator"`
+	Values    []string `json:"values"`
+}
+
+type workflowIssuesFilter struct {
+	ID         string              `json:"id,omitempty"`
+	Name       string              `json:"name"`
+	Type       string              `json:"type"`
+	Predicates []workflowPredicate `json:"predicates"`
+}
+
+type workflowNrqlConfiguration struct {
+	Query string `json:"query"`
+}
+
+type workflowEnrichment struct {
+	ID             string                      `json:"id"`
+	Name           string                      `json:"name"`
+	Type           string                      `json:"type"`
+	Configurations []workflowNrqlConfiguration `json:"configurations"`
+}
+
+type workflowDestinationConfiguration struct {
+	ChannelID string `json:"channelId"`
+	Name      string `json:"name,omitempty"`
+	Type      string `json:"type,omitempty"`
+}
+
+type workflow struct {
+	ID                        string                             `json:"id"`
+	AccountID                 int                                `json:"accountId"`
+	Name                      string                             `json:"name"`
+	WorkflowEnabled           bool                               `json:"workflowEnabled"`
+	DestinationsEnabled       bool                               `json:"destinationsEnabled"`
+	EnrichmentsEnabled        bool                               `json:"enrichmentsEnabled"`
+	MutingRulesHandling       string                             `json:"mutingRulesHandling"`
+	IssuesFilter              *workflowIssuesFilter              `json:"issuesFilter"`
+	Enrichments               []workflowEnrichment               `json:"enrichments"`
+	DestinationConfigurations []workflowDestinationConfiguration `json:"destinationConfigurations"`
+}
+
+type workflowFilterInput struct {
+	Name       string              `json:"name"`
+	Type       string              `json:"type"`
+	Predicates []workflowPredicate `json:"predicates"`
+}
+
+type workflowUpdatedFilterInput struct {
+	ID          string              `json:"id"`
+	FilterInput workflowFilterInput `json:"filterInput"`
+}
+
+type workflowNrqlEnrichmentInput struct {
+	ID            string                      `json:"id,omitempty"`
+	Name          string                      `json:"name"`
+	Configuration []workflowNrqlConfiguration `json:"configuration"`
+}
+
+type workflowEnrichmentsInput struct {
+	NRQL []workflowNrqlEnrichmentInput `json:"nrql"`
+}
+
+type workflowDestinationConfigurationInput struct {
+	ChannelID string `json:"channelId"`
+}
+
+type workflowCreateInput struct {
+	Name                      string                                  `json:"name"`
+	WorkflowEnabled           bool                                    `json:"workflowEnabled"`
+	DestinationsEnabled       bool                                    `json:"destinationsEnabled"`
+	EnrichmentsEnabled        bool                                    `json:"enrichmentsEnabled"`
+	MutingRulesHandling       string                                  `json:"mutingRulesHandling"`
+	IssuesFilter              workflowFilterInput                     `json:"issuesFilter"`
+	Enrichments               *workflowEnrichmentsInput               `json:"enrichments,omitempty"`
+	DestinationConfigurations []workflowDestinationConfigurationInput `json:"destinationConfigurations"`
+}
+
+type workflowUpdateInput struct {
+	ID                        string                                  `json:"id"`
+	Name                      string                                  `json:"name"`
+	WorkflowEnabled           bool                                    `json:"workflowEnabled"`
+	DestinationsEnabled       bool                                    `json:"destinationsEnabled"`
+	EnrichmentsEnabled        bool                                    `json:"enrichmentsEnabled"`
+	MutingRulesHandling       string                                  `json:"mutingRulesHandling"`
+	IssuesFilter              workflowUpdatedFilterInput              `json:"issuesFilter"`
+	Enrichments               *workflowEnrichmentsInput               `json:"enrichments,omitempty"`
+	DestinationConfigurations []workflowDestinationConfigurationInput `json:"destinationConfigurations"`
+}
+
+// workflowErrors are the errors returned in the payload of workflow
+// mutations.
+type workflowErrors []struct {
+	Description string `json:"description"`
+	Type        string `json:"type"`
+}
+
+func (e workflowErrors) Error() string {
+	msgs := make([]string, len(e))
+
+	for i, err := range e {
+		msgs[i] = err.Type + ": " + err.Description
+	}
+
+	return strings.Join(msgs, "; ")
+}
+
+const (
+	workflowFields = `
+		id accountId name workflowEnabled destinationsEnabled enrichmentsEnabled mutingRulesHandling
+		issuesFilter { id name type predicates { attribute operator values } }
+		enrichments { id name type configurations { ... on AiWorkflowsNrqlConfiguration { query } } }
+		destinationConfigurations { channelId name type }`
+
+	workflowErrorFields = `errors { description type }`
+)
+
+const getWorkflowQuery = `query($accountId: Int!, $id: ID!) {
+	actor { account(id: $accountId) { aiWorkflows {
+		workflows(filters: { id: $id }) {
+			entities {` + workflowFields + `}
+		}
+	} } }
+}`
+
+const createWorkflowMutation = `mutation($accountId: Int!, $createWorkflowData: AiWorkflowsCreateWorkflowInput!) {
+	aiWorkflowsCreateWorkflow(accountId: $accountId, createWorkflowData: $createWorkflowData) {
+		workflow {` + workflowFields + `}
+		` + workflowErrorFields + `
+	}
+}`
+
+const updateWorkflowMutation = `mutation($accountId: Int!, $updateWorkflowData: AiWorkflowsUpdateWorkflowInput!) {
+	aiWorkflowsUpdateWorkflow(accountId: $accountId, updateWorkflowData: $updateWorkflowData) {
+		workflow {` + workflowFields + `}
+		` + workflowErrorFields + `
+	}
+}`
+
+const deleteWorkflowMutation = `mutation($accountId: Int!, $id: ID!, $deleteChannels: Boolean!) {
+	aiWorkflowsDeleteWorkflow(accountId: $accountId, id: $id, deleteChannels: $deleteChannels) {
+		id
+		` + workflowErrorFields + `
+	}
+}`
+
+type workflowResult struct {
+	Workflow *workflow      `json:"workflow"`
+	Errors   workflowErrors `json:"errors"`
+}
+
+func getWorkflow(client *nr.NewRelic, accountID int, id string) (*workflow, error) {
+	var resp struct {
+		Actor struct {
+			Account struct {
+				AIWorkflows struct {
+					Workflows struct {
+						Entities []workflow `json:"entities"`
+					} `json:"workflows"`
+				} `json:"aiWorkflows"`
+			} `json:"account"`
+		} `json:"actor"`
+	}
+
+	vars := map[string]interface{}{
+		"accountId": accountID,
+		"id":        id,
+	}
+
+	if err := client.NerdGraph.QueryWithResponse(getWorkflowQuery, vars, &resp); err != nil {
+		return nil, err
+	}
+
+	workflows := resp.Actor.Account.AIWorkflows.Workflows
+	if len(workflows.Entities) == 0 {
+		return nil, errors.NewNotFoundf("workflow %s not found", id)
+	}
+
+	return &workflows.Entities[0], nil
+}
+
+func createWorkflow(client *nr.NewRelic, accountID int, input workflowCreateInput) (*workflow, error) {
+	var resp struct {
+		Result workflowResult `json:"aiWorkflowsCreateWorkflow"`
+	}
+
+	vars := map[string]interface{}{
+		"accountId":          accountID,
+		"createWorkflowData": input,
+	}
+
+	if err := client.NerdGraph.QueryWithResponse(createWorkflowMutation, vars, &resp); err != nil {
+		return nil, err
+	}
+
+	if len(resp.Result.Errors) > 0 {
+		return nil, resp.Result.Errors
+	}
+
+	return resp.Result.Workflow, nil
+}
+
+func updateWorkflow(client *nr.NewRelic, accountID int, input workflowUpdateInput) (*workflow, error) {
+	var resp struct {
+		Result workflowResult `json:"aiWorkflowsUpdateWorkflow"`
+	}
+
+	vars := map[string]interface{}{
+		"accountId":          accountID,
+		"updateWorkflowData": input,
+	}
+
+	if err := client.NerdGraph.QueryWithResponse(updateWorkflowMutation, vars, &resp); err != nil {
+		return nil, err
+	}
+
+	if len(resp.Result.Errors) > 0 {
+		return nil, resp.Result.Errors
+	}
+
+	return resp.Result.Workflow, nil
+}
+
+func deleteWorkflow(client *nr.NewRelic, accountID int, id string) error {
+	var resp struct {
+		Result struct {
+			ID     string         `json:"id"`
+			Errors workflowErrors `json:"errors"`
+		} `json:"aiWorkflowsDeleteWorkflow"`
+	}
+
+	// Channels are managed by their own resources and must outlive the
+	// workflows that reference them.
+	vars := map[string]interface{}{
+		"accountId":      accountID,
+		"id":             id,
+		"deleteChannels": false,
+	}
+
+	if err := client.NerdGraph.QueryWithResponse(deleteWorkflowMutation, vars, &resp); err != nil {
+		return err
+	}
+
+	if len(resp.Result.Errors) > 0 {
+		// A workflow which no longer exists is reported as an invalid
+		// parameter, so look it up to tell the two apart.
+		if _, err := getWorkflow(client, accountID, id); err != nil {
+			if _, ok := err.(*errors.NotFound); ok {
+				return err
+			}
+		}
+
+		return resp.Result.Errors
+	}
+
+	return nil
+}
@@ -0,0 +1,170 @@
+package newrelic
+
+import (
+	nr "github.com/newrelic/newrelic-client-go/newrelic"
+	"github.com/newrelic/newrelic-client-go/pkg/workloads"
+)
+
+// The description and status configuration of workloads are not yet exposed
+// by newrelic-client-go, so the provider issues the NerdGraph requests itself.
+
+type workloadRollup struct {
+	GroupBy        string `json:"groupBy,omitempty"`
+	Strategy       string `json:"strategy"`
+	ThresholdType  string `json:"thresholdType,omitempty"`
+	ThresholdValue int    `json:"thresholdValue,omitempty"`
+}
+
+type workloadRemainingEntitiesRule struct {
+	Rollup workloadRollup `json:"rollup"`
+}
+
+type workloadRule struct {
+	Entities            []workloads.EntityRef              `json:"entities,omitempty"`
+	EntityGUIDs         []string                           `json:"entityGuids,omitempty"`
+	EntitySearchQueries []workloads.EntitySearchQueryInput `json:"entitySearchQueries,omitempty"`
+	Rollup              workloadRollup                     `json:"rollup"`
+}
+
+type workloadAutomaticStatus struct {
+	Enabled               bool                           `json:"enabled"`
+	RemainingEntitiesRule *workloadRemainingEntitiesRule `json:"remainingEntitiesRule,omitempty"`
+	Rules                 []workloadRule                 `json:"rules,omitempty"`
+}
+
+type workloadStaticStatus struct {
+	Description string `json:"description,omitempty"`
+	Enabled     bool   `json:"enabled"`
+	Status      string `json:"status"`
+	Summary     string `json:"summary,omitempty"`
+}
+
+type workloadStatusConfig struct {
+	Automatic *workloadAutomaticStatus `json:"automatic,omitempty"`
+	Static    []workloadStaticStatus   `json:"static,omitempty"`
+}
+
+// workloadInput is the WorkloadCreateInput or WorkloadUpdateInput of a
+// workload, along with the fields newrelic-client-go does not support.
+type workloadInput struct {
+	workloads.CreateInput
+	Description  string                `json:"description"`
+	StatusConfig *workloadStatusConfig `json:"statusConfig,omitempty"`
+}
+
+// workload is a workload along with the fields newrelic-client-go does not
+// support.
+type workload struct {
+	*workloads.Workload
+	Description  string                `json:"description"`
+	StatusConfig *workloadStatusConfig `json:"statusConfig"`
+}
+
+const workloadStatusConfigFields = `
+	statusConfig {
+		automatic {
+			enabled
+			remainingEntitiesRule { rollup { groupBy strategy thresholdType thresholdValue } }
+			rules {
+				entities { guid }
+				entitySearchQueries { query }
+				rollup { strategy thresholdType thresholdValue }
+			}
+		}
+		static { description enabled status summary }
+	}`
+
+const createWorkloadMutation = `mutation($accountId: Int!, $workload: WorkloadCreateInput!) {
+	workloadCreate(accountId: $accountId, workload: $workload) {
+		guid
+		id
+	}
+}`
+
+const updateWorkloadMutation = `mutation($guid: EntityGuid!, $workload: WorkloadUpdateInput!) {
+	workloadUpdate(guid: $guid, workload: $workload) {
+		guid
+		id
+	}
+}`
+
+const getWorkloadStatusConfigQuery = `query($guid: EntityGuid!, $accountId: Int!) {
+	actor {
+		account(id: $accountId) {
+			workload {
+				collection(guid: $guid) {
+					description` + workloadStatusConfigFields + `
+				}
+			}
+		}
+	}
+}`
+
+func createWorkload(client *nr.NewRelic, accountID int, input workloadInput) (*workloads.Workload, error) {
+	var resp struct {
+		Result workloads.Workload `json:"workloadCreate"`
+	}
+
+	vars := map[string]interface{}{
+		"accountId": accountID,
+		"workload":  input,
+	}
+
+	if err := client.NerdGraph.QueryWithResponse(createWorkloadMutation, vars, &resp); err != nil {
+		return nil, err
+	}
+
+	return &resp.Result, nil
+}
+
+func updateWorkload(client *nr.NewRelic, guid string, input workloadInput) (*workloads.Workload, error) {
+	var resp struct {
+		Result workloads.Workload `json:"workloadUpdate"`
+	}
+
+	vars := map[string]interface{}{
+		"guid":     guid,
+		"workload": input,
+	}
+
+	if err := client.NerdGraph.QueryWithResponse(updateWorkloadMutation, vars, &resp); err != nil {
+		return nil, err
+	}
+
+	return &resp.Result, nil
+}
+
+func getWorkload(client *nr.NewRelic, accountID int, guid string) (*workload, error) {
+	w, err := client.Workloads.GetWorkload(accountID, guid)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp struct {
+		Actor struct {
+			Account struct {
+				Workload struct {
+					Collection struct {
+						Description  string                `json:"description"`
+						StatusConfig *workloadStatusConfig `json:"statusConfig"`
+					} `json:"collection"`
+				} `json:"workload"`
+			} `json:"account"`
+		} `json:"actor"`
+	}
+
+	vars := map[string]interface{}{
+		"accountId": accountID,
+		"guid":      guid,
+	}
+
+	if err := client.NerdGraph.QueryWithResponse(getWorkloadStatusConfigQuery, vars, &resp); err != nil {
+		return nil, err
+	}
+
+	return &workload{
+		Workload:     w,
+		Description:  resp.Actor.Account.Workload.Collection.Description,
+		StatusConfig: resp.Actor.Account.Workload.Collection.StatusConfig,
+	}, nil
+}
@@ -0,0 +1,153 @@
+package newrelic
+
+import (
+	"strconv"
+	"sync"
+
+	"github.com/newrelic/newrelic-client-go/newrelic"
+	"github.com/newrelic/newrelic-client-go/pkg/alerts"
+	"github.com/newrelic/newrelic-client-go/pkg/errors"
+)
+
+// nrqlConditionCache holds the NRQL alert conditions of the policies read
+// during a run of the provider. Reading a condition fetches every condition
+// of its policy in one search, so refreshing many conditions takes one
+// request per policy rather than one per condition. Any write to NRQL
+// conditions or policies clears the cache.
+type nrqlConditionCache struct {
+	mu       sync.Mutex
+	policies map[nrqlConditionCacheKey]*nrqlConditionCacheEntry
+}
+
+type nrqlConditionCacheKey struct {
+	accountID int
+	policyID  int
+}
+
+// nrqlConditionCacheEntry is filled by the first read of a policy. Reads of
+// the same policy made in the meantime wait for it rather than fetching the
+// policy again.
+type nrqlConditionCacheEntry struct {
+	done       chan struct{}
+	conditions map[string]*alerts.NrqlAlertCondition
+	err        error
+}
+
+func newNrqlConditionCache() *nrqlConditionCache {
+	return &nrqlConditionCache{
+		policies: map[nrqlConditionCacheKey]*nrqlConditionCacheEntry{},
+	}
+}
+
+// get returns a NRQL alert condition of a policy, or a NotFound error when
+// the policy has no such condition. A condition missing from conditions which
+// were cached earlier may have been created since, so its policy is searched
+// again before the condition is reported as not found.
+func (c *nrqlConditionCache) get(client *newrelic.NewRelic, accountID int, policyID int, conditionID int) (*alerts.NrqlAlertCondition, error) {
+	if c == nil {
+		return searchPolicyNrqlCondition(client, accountID, policyID, conditionID)
+	}
+
+	key := nrqlConditionCacheKey{accountID: accountID, policyID: policyID}
+
+	entry, fetched := c.load(client, key)
+	if entry.err != nil {
+		return nil, entry.err
+	}
+
+	condition, err := findNrqlCondition(entry.conditions, policyID, conditionID)
+	if err == nil || fetched {
+		return condition, err
+	}
+
+	c.drop(key, entry)
+
+	if entry, _ = c.load(client, key); entry.err != nil {
+		return nil, entry.err
+	}
+
+	return findNrqlCondition(entry.conditions, policyID, conditionID)
+}
+
+// load returns the cache entry of a policy, searching the policy when it is
+// not cached yet. It reports whether this call made the search.
+func (c *nrqlConditionCache) load(client *newrelic.NewRelic, key nrqlConditionCacheKey) (*nrqlConditionCacheEntry, bool) {
+	c.mu.Lock()
+	entry, ok := c.policies[key]
+	if !ok {
+		entry = &nrqlConditionCacheEntry{done: make(chan struct{})}
+		c.policies[key] = entry
+	}
+	c.mu.Unlock()
+
+	if ok {
+		<-entry.done
+		return entry, false
+	}
+
+	entry.conditions, entry.err = searchPolicyNrqlConditions(client, key.accountID, key.policyID)
+	close(entry.done)
+
+	// Failed searches are not kept, so the next read tries again.
+	if entry.err != nil {
+		c.drop(key, entry)
+	}
+
+	return entry, true
+}
+
+// drop removes the entry of a policy, unless it was replaced already.
+func (c *nrqlConditionCache) drop(key nrqlConditionCacheKey, entry *nrqlConditionCacheEntry) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.policies[key] == entry {
+		delete(c.policies, key)
+	}
+}
+
+// invalidate clears the cache after a write.
+func (c *nrqlConditionCache) invalidate() {
+	if c == nil {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.policies = map[nrqlConditionCacheKey]*nrqlConditionCacheEntry{}
+}
+
+func searchPolicyNrqlConditions(client *newrelic.NewRelic, accountID int, policyID int) (map[string]*alerts.NrqlAlertCondition, error) {
+	found, err := client.Alerts.SearchNrqlConditionsQuery(accountID, alerts.NrqlConditionsSearchCriteria{
+		PolicyID: strconv.Itoa(policyID),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	conditions := make(map[string]*alerts.NrqlAlertCondition, len(found))
+	for _, condition := range found {
+		conditions[condition.ID] = condition
+	}
+
+	return conditions, nil
+}
+
+func searchPolicyNrqlCondition(client *newrelic.NewRelic, accountID int, policyID int, conditionID int) (*alerts.NrqlAlertCondition, error) {
+	conditions, err := searchPolicyNrqlConditions(client, accountID, policyID)
+	if err != nil {
+		return nil, err
+	}
+
+	return findNrqlCondition(conditions, policyID, conditionID)
+}
+
+func findNrqlCondition(conditions map[string]*alerts.NrqlAlertCondition, policyID int, conditionID int) (*alerts.NrqlAlertCondition, error) {
+	condition, ok := conditions[strconv.Itoa(conditionID)]
+	if !ok {
+		return nil, errors.NewNotFoundf("NRQL alert condition %d not found in policy %d", conditionID, policyID)
+	}
+
+	return condition, nil
+}
@@ -4,11 +4,14 @@ import (
 	"fmt"
 	"log"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
 	"github.com/hashicorp/terraform-plugin-sdk/meta"
 	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+	nr "github.com/newrelic/newrelic-client-go/newrelic"
 )
 
 var (
@@ -114,6 +117,86 @@ func Provider() terraform.ResourceProvider {
 				Optional:    true,
 				DefaultFunc: schema.EnvDefaultFunc("NEW_RELIC_API_CACERT", ""),
 			},
+			"account": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "An additional account managed with its own credentials. Resources target it with their account_id argument.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"account_id": {
+							Type:        schema.TypeInt,
+							Required:    true,
+							Description: "The ID of the account.",
+						},
+						"api_key": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Sensitive:   true,
+							Description: "The Personal API key used for the account.",
+						},
+						"admin_api_key": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Sensitive:   true,
+							Description: "The Admin API key used for the account.",
+						},
+						"region": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							Description:  "The data center of the account. Defaults to the region of the provider. Can not differ from it when the API URLs of the provider are overridden.",
+							ValidateFunc: validation.StringInSlice([]string{"US", "EU", "Staging"}, true),
+						},
+					},
+				},
+			},
+			"rate_limit": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "Throttles the requests made to one of the New Relic APIs.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"api": {
+							Type:         schema.TypeString,
+							Required:     true,
+							Description:  "The API to throttle. Valid values are rest, infrastructure, nerdgraph, and synthetics.",
+							ValidateFunc: validation.StringInSlice(throttledAPIs, false),
+						},
+						"requests_per_second": {
+							Type:         schema.TypeFloat,
+							Optional:     true,
+							Description:  "The maximum number of requests sent to the API per second. Unlimited when 0.",
+							ValidateFunc: validation.FloatAtLeast(0),
+						},
+						"max_in_flight": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Description:  "The maximum number of requests to the API in flight at once. Unlimited when 0.",
+							ValidateFunc: validation.IntAtLeast(0),
+						},
+					},
+				},
+			},
+			"max_retries": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("NEW_RELIC_MAX_RETRIES", defaultMaxRetries),
+				Description:  "The number of times a read failing with a 429 or 5xx response, or a resource not found right after being written, is retried.",
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"retry_wait_min": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("NEW_RELIC_RETRY_WAIT_MIN", defaultRetryWaitMin),
+				Description:  "The number of seconds to wait before the first retry. The wait doubles for each following retry.",
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"retry_wait_max": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("NEW_RELIC_RETRY_WAIT_MAX", defaultRetryWaitMax),
+				Description:  "The maximum number of seconds to wait between two retries.",
+				ValidateFunc: validation.IntAtLeast(0),
+			},
 		},
 
 		DataSourcesMap: map[string]*schema.Resource{
@@ -121,6 +204,7 @@ func Provider() terraform.ResourceProvider {
 			"newrelic_alert_channel":                dataSourceNewRelicAlertChannel(),
 			"newrelic_alert_policy":                 dataSourceNewRelicAlertPolicy(),
 			"newrelic_application":                  dataSourceNewRelicApplication(),
+			"newrelic_entities":                     dataSourceNewRelicEntities(),
 			"newrelic_entity":                       dataSourceNewRelicEntity(),
 			"newrelic_key_transaction":              dataSourceNewRelicKeyTransaction(),
 			"newrelic_plugin":                       dataSourceNewRelicPlugin(),
@@ -128,9 +212,11 @@ func Provider() terraform.ResourceProvider {
 			"newrelic_synthetics_monitor":           dataSourceNewRelicSyntheticsMonitor(),
 			"newrelic_synthetics_monitor_location":  dataSourceNewRelicSyntheticsMonitorLocation(),
 			"newrelic_synthetics_secure_credential": dataSourceNewRelicSyntheticsSecureCredential(),
+			"newrelic_workload":                     dataSourceNewRelicWorkload(),
 		},
 
 		ResourcesMap: map[string]*schema.Resource{
+			"newrelic_account":                                  resourceNewRelicAccount(),
 			"newrelic_alert_channel":                            resourceNewRelicAlertChannel(),
 			"newrelic_alert_condition":                          resourceNewRelicAlertCondition(),
 			"newrelic_alert_muting_rule":                        resourceNewRelicAlertMutingRule(),
@@ -139,22 +225,35 @@ func Provider() terraform.ResourceProvider {
 			"newrelic_api_access_key":                           resourceNewRelicAPIAccessKey(),
 			"newrelic_application_settings":                     resourceNewRelicApplicationSettings(),
 			"newrelic_dashboard":                                resourceNewRelicDashboard(),
+			"newrelic_entity_tag":                               resourceNewRelicEntityTag(),
 			"newrelic_entity_tags":                              resourceNewRelicEntityTags(),
+			"newrelic_entity_tags_by_query":                     resourceNewRelicEntityTagsByQuery(),
 			"newrelic_events_to_metrics_rule":                   resourceNewRelicEventsToMetricsRule(),
+			"newrelic_group":                                    resourceNewRelicGroup(),
+			"newrelic_group_role":                               resourceNewRelicGroupRole(),
 			"newrelic_infra_alert_condition":                    resourceNewRelicInfraAlertCondition(),
 			"newrelic_insights_event":                           resourceNewRelicInsightsEvent(),
+			"newrelic_notification_channel":                     resourceNewRelicNotificationChannel(),
+			"newrelic_notification_destination":                 resourceNewRelicNotificationDestination(),
 			"newrelic_nrql_alert_condition":                     resourceNewRelicNrqlAlertCondition(),
 			"newrelic_one_dashboard":                            resourceNewRelicOneDashboard(),
+			"newrelic_one_dashboard_json":                       resourceNewRelicOneDashboardJSON(),
 			"newrelic_plugins_alert_condition":                  resourceNewRelicPluginsAlertCondition(),
 			"newrelic_synthetics_alert_condition":               resourceNewRelicSyntheticsAlertCondition(),
 			"newrelic_synthetics_monitor":                       resourceNewRelicSyntheticsMonitor(),
 			"newrelic_synthetics_monitor_script":                resourceNewRelicSyntheticsMonitorScript(),
 			"newrelic_synthetics_multilocation_alert_condition": resourceNewRelicSyntheticsMultiLocationAlertCondition(),
 			"newrelic_synthetics_secure_credential":             resourceNewRelicSyntheticsSecureCredential(),
+			"newrelic_user":                                     resourceNewRelicUser(),
+			"newrelic_workflow":                                 resourceNewRelicWorkflow(),
 			"newrelic_workload":                                 resourceNewRelicWorkload(),
 		},
 	}
 
+	for _, r := range provider.ResourcesMap {
+		withRetryPolicy(r)
+	}
+
 	provider.ConfigureFunc = func(d *schema.ResourceData) (interface{}, error) {
 		terraformVersion := provider.TerraformVersion
 		if terraformVersion == "" {
@@ -176,6 +275,11 @@ func providerConfigure(data *schema.ResourceData, terraformVersion string) (inte
 
 	log.Printf("[INFO] UserAgent: %s", userAgent)
 
+	rateLimits, err := expandRateLimits(data.Get("rate_limit").([]interface{}))
+	if err != nil {
+		return nil, err
+	}
+
 	cfg := Config{
 		AdminAPIKey:          adminAPIKey,
 		PersonalAPIKey:       personalAPIKey,
@@ -187,7 +291,16 @@ func providerConfigure(data *schema.ResourceData, terraformVersion string) (inte
 		userAgent:            userAgent,
 		InsecureSkipVerify:   data.Get("insecure_skip_verify").(bool),
 		CACertFile:           data.Get("cacert_file").(string),
+		RateLimits:           rateLimits,
 	}
+	// The clients of the provider and its accounts share one transport, so
+	// the rate limits apply to all of their requests.
+	transport, err := cfg.Transport()
+	if err != nil {
+		return nil, fmt.Errorf("error initializing newrelic-client-go: %w", err)
+	}
+	cfg.HTTPTransport = transport
+
 	log.Println("[INFO] Initializing newrelic-client-go")
 
 	client, err := cfg.Client()
@@ -195,6 +308,11 @@ func providerConfigure(data *schema.ResourceData, terraformVersion string) (inte
 		return nil, fmt.Errorf("error initializing newrelic-client-go: %w", err)
 	}
 
+	accountClients, err := newAccountClients(cfg, accountID, data.Get("account").([]interface{}))
+	if err != nil {
+		return nil, err
+	}
+
 	insightsInsertConfig := Config{
 		InsightsAccountID: strconv.Itoa(accountID),
 		InsightsInsertKey: data.Get("insights_insert_key").(string),
@@ -210,11 +328,81 @@ func providerConfigure(data *schema.ResourceData, terraformVersion string) (inte
 		InsightsInsertClient: clientInsightsInsert,
 		PersonalAPIKey:       personalAPIKey,
 		AccountID:            accountID,
+		accountClients:       accountClients,
+		nrqlConditions:       newNrqlConditionCache(),
+		RetryPolicy: retryPolicy{
+			MaxRetries: data.Get("max_retries").(int),
+			WaitMin:    time.Duration(data.Get("retry_wait_min").(int)) * time.Second,
+			WaitMax:    time.Duration(data.Get("retry_wait_max").(int)) * time.Second,
+		},
 	}
 
 	return &providerConfig, nil
 }
 
+// newAccountClients initializes a client for each account block, configured
+// like the client of the provider apart from the account's credentials and
+// region. The clients share the transport of the provider's client, and its
+// API URLs when they are overridden.
+func newAccountClients(cfg Config, providerAccountID int, accounts []interface{}) (map[int]*nr.NewRelic, error) {
+	clients := map[int]*nr.NewRelic{}
+
+	for _, v := range accounts {
+		a := v.(map[string]interface{})
+		accountID := a["account_id"].(int)
+
+		if accountID == providerAccountID {
+			return nil, fmt.Errorf("account %d is the provider's account and can not be set in an account block", accountID)
+		}
+
+		if _, ok := clients[accountID]; ok {
+			return nil, fmt.Errorf("account %d is configured more than once", accountID)
+		}
+
+		accountCfg := cfg
+		accountCfg.PersonalAPIKey = a["api_key"].(string)
+		accountCfg.AdminAPIKey = a["admin_api_key"].(string)
+
+		if region := a["region"].(string); region != "" {
+			// Overridden API URLs apply to every region.
+			if !strings.EqualFold(region, cfg.Region) && cfg.hasAPIURLOverrides() {
+				return nil, fmt.Errorf("the region of account %d can not differ from the provider's when the API URLs are overridden", accountID)
+			}
+
+			accountCfg.Region = region
+		}
+
+		client, err := accountCfg.Client()
+		if err != nil {
+			return nil, fmt.Errorf("error initializing newrelic-client-go for account %d: %w", accountID, err)
+		}
+
+		clients[accountID] = client
+	}
+
+	return clients, nil
+}
+
+func expandRateLimits(cfg []interface{}) (map[string]RateLimit, error) {
+	rateLimits := map[string]RateLimit{}
+
+	for _, v := range cfg {
+		l := v.(map[string]interface{})
+		api := l["api"].(string)
+
+		if _, ok := rateLimits[api]; ok {
+			return nil, fmt.Errorf("rate_limit is set more than once for the %s API", api)
+		}
+
+		rateLimits[api] = RateLimit{
+			RequestsPerSecond: l["requests_per_second"].(float64),
+			MaxInFlight:       l["max_in_flight"].(int),
+		}
+	}
+
+	return rateLimits, nil
+}
+
 func getInfraAPIURL(data *schema.ResourceData) string {
 	newURL, newURLOk := data.GetOk("infrastructure_api_url")
 
@@ -3,9 +3,14 @@
 package newrelic
 
 import (
+	"fmt"
+	"strings"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+	nr "github.com/newrelic/newrelic-client-go/newrelic"
 )
 
 func TestProvider(t *testing.T) {
@@ -26,3 +31,443 @@ func TestProviderConfig(t *testing.T) {
 		t.Error("hasNerdGraphCreds should be true")
 	}
 }
+
+func TestProviderConfigClient(t *testing.T) {
+	providerClient := &nr.NewRelic{}
+	subAccountClient := &nr.NewRelic{}
+
+	c := ProviderConfig{
+		NewClient:      providerClient,
+		AccountID:      123,
+		accountClients: map[int]*nr.NewRelic{456: subAccountClient},
+	}
+
+	if c.client(123) != providerClient {
+		t.Error("the provider's account should use the client of the provider")
+	}
+
+	if c.client(456) != subAccountClient {
+		t.Error("a configured account should use its own client")
+	}
+
+	if c.client(789) != providerClient {
+		t.Error("an account which is not configured should use the client of the provider")
+	}
+
+	// MTIzfEFQTXxBUFBMSUNBVElPTnwx and NDU2fEFQTXxBUFBMSUNBVElPTnwx encode
+	// APM application GUIDs of the accounts 123 and 456.
+	if entityClient(&c, "MTIzfEFQTXxBUFBMSUNBVElPTnwx") != providerClient {
+		t.Error("an entity of the provider's account should use the client of the provider")
+	}
+
+	if entityClient(&c, "NDU2fEFQTXxBUFBMSUNBVElPTnwx") != subAccountClient {
+		t.Error("an entity of a configured account should use the account's client")
+	}
+
+	if entityClient(&c, "not a guid") != providerClient {
+		t.Error("an invalid GUID should use the client of the provider")
+	}
+}
+
+// offlineResourceTest takes a resource through a create, an update and an
+// import against the fake API. The config is a format string rendered with
+// the create and then the update arguments. Resources with behavior beyond
+// plain CRUD are covered by the unit tests next to them instead.
+type offlineResourceTest struct {
+	config                  string
+	createArgs              []interface{}
+	createChecks            map[string]string
+	updateArgs              []interface{}
+	updateChecks            map[string]string
+	kinds                   []string
+	application             string
+	importStateIDFunc       func(resourceName string) resource.ImportStateIdFunc
+	importStateVerifyIgnore []string
+}
+
+var offlineResourceTests = map[string]offlineResourceTest{
+	"newrelic_alert_policy_channel.foo": {
+		config: `
+resource "newrelic_alert_policy" "foo" {
+	name = "tf-test"
+}
+
+resource "newrelic_alert_channel" "foo" {
+	name = "tf-test-foo"
+	type = "email"
+
+	config {
+		recipients = "terraform-acctest+foo@hashicorp.com"
+	}
+}
+
+resource "newrelic_alert_channel" "bar" {
+	name = "tf-test-bar"
+	type = "email"
+
+	config {
+		recipients = "terraform-acctest+bar@hashicorp.com"
+	}
+
+	# Creating the channels in order keeps their IDs in config order.
+	depends_on = [newrelic_alert_channel.foo]
+}
+
+resource "newrelic_alert_policy_channel" "foo" {
+	policy_id   = newrelic_alert_policy.foo.id
+	channel_ids = [%s]
+}
+`,
+		createArgs:   []interface{}{"newrelic_alert_channel.foo.id"},
+		createChecks: map[string]string{"channel_ids.#": "1"},
+		updateArgs:   []interface{}{"newrelic_alert_channel.foo.id, newrelic_alert_channel.bar.id"},
+		updateChecks: map[string]string{"channel_ids.#": "2"},
+		kinds:        []string{fakeKindPolicy, fakeKindChannel},
+	},
+	"newrelic_api_access_key.foo": {
+		config: `
+resource "newrelic_api_access_key" "foo" {
+	account_id  = %d
+	key_type    = "INGEST"
+	ingest_type = "LICENSE"
+	name        = "tf-test"
+	notes       = "%s"
+}
+`,
+		createArgs:   []interface{}{fakeAccountID, "first notes"},
+		createChecks: map[string]string{"ingest_type": keyTypeIngestLicense, "notes": "first notes"},
+		updateArgs:   []interface{}{fakeAccountID, "second notes"},
+		updateChecks: map[string]string{"notes": "second notes"},
+		kinds:        []string{fakeKindAPIKey},
+		importStateIDFunc: func(resourceName string) resource.ImportStateIdFunc {
+			return func(s *terraform.State) (string, error) {
+				rs := s.RootModule().Resources[resourceName]
+				return fmt.Sprintf("%s:%s", rs.Primary.ID, rs.Primary.Attributes["key_type"]), nil
+			}
+		},
+	},
+	"newrelic_application_settings.app": {
+		config: `
+resource "newrelic_application_settings" "app" {
+	name                        = "tf-test-app"
+	app_apdex_threshold         = "%s"
+	end_user_apdex_threshold    = "0.8"
+	enable_real_user_monitoring = %t
+}
+`,
+		createArgs:   []interface{}{"0.9", true},
+		createChecks: map[string]string{"app_apdex_threshold": "0.9", "enable_real_user_monitoring": "true"},
+		updateArgs:   []interface{}{"0.8", false},
+		updateChecks: map[string]string{"app_apdex_threshold": "0.8", "enable_real_user_monitoring": "false"},
+		// The application itself is expected to outlive the resource.
+		application: "tf-test-app",
+	},
+	"newrelic_dashboard.foo": {
+		config: `
+resource "newrelic_dashboard" "foo" {
+	title = "%s"
+
+	filter {
+		event_types = ["Transaction"]
+		attributes  = ["appName", "envName"]
+	}
+
+	widget {
+		title         = "Transaction Count"
+		visualization = "billboard"
+		nrql          = "SELECT count(*) from Transaction since 5 minutes ago facet appName"
+		row           = 1
+		column        = 1
+	}
+%s
+	grid_column_count = 12
+}
+`,
+		createArgs:   []interface{}{"tf-test", ""},
+		createChecks: map[string]string{"title": "tf-test", "widget.#": "1"},
+		updateArgs: []interface{}{"tf-test-updated", `
+	widget {
+		title         = "Dashboard Note"
+		visualization = "markdown"
+		source        = "#h1 Heading"
+		row           = 2
+		column        = 1
+	}`},
+		updateChecks: map[string]string{"title": "tf-test-updated", "widget.#": "2"},
+		kinds:        []string{fakeKindDashboard},
+		// grid_column_count is not returned in the GET response
+		importStateVerifyIgnore: []string{"grid_column_count"},
+	},
+	"newrelic_events_to_metrics_rule.foo": {
+		config: `
+resource "newrelic_events_to_metrics_rule" "foo" {
+	account_id  = %d
+	name        = "tf_test"
+	description = "test description"
+	nrql        = "SELECT uniqueCount(account_id) AS Transaction_account_id FROM Transaction FACET appName, name"
+	enabled     = %t
+}
+`,
+		createArgs:   []interface{}{fakeAccountID, true},
+		createChecks: map[string]string{"enabled": "true"},
+		updateArgs:   []interface{}{fakeAccountID, false},
+		updateChecks: map[string]string{"enabled": "false"},
+		kinds:        []string{fakeKindEventsToMetricsRule},
+		// enabled is only read back when it is already set in state.
+		importStateVerifyIgnore: []string{"enabled"},
+	},
+	"newrelic_infra_alert_condition.foo": {
+		config: `
+resource "newrelic_alert_policy" "foo" {
+	name = "tf-test"
+}
+
+resource "newrelic_infra_alert_condition" "foo" {
+	policy_id   = newrelic_alert_policy.foo.id
+	name        = "tf-test"
+	runbook_url = "%s"
+	type        = "infra_metric"
+	event       = "StorageSample"
+	select      = "diskFreePercent"
+	comparison  = "below"
+
+	critical {
+		duration      = 10
+		value         = %d
+		time_function = "any"
+	}
+}
+`,
+		createArgs:   []interface{}{"https://foo.example.com", 10},
+		createChecks: map[string]string{"runbook_url": "https://foo.example.com", "critical.0.value": "10"},
+		updateArgs:   []interface{}{"https://bar.example.com", 20},
+		updateChecks: map[string]string{"runbook_url": "https://bar.example.com", "critical.0.value": "20"},
+		kinds:        []string{fakeKindPolicy, fakeKindInfraCondition},
+	},
+	"newrelic_insights_event.foo": {
+		config: `
+resource "newrelic_insights_event" "foo" {
+	event {
+		type = "tf_test"
+
+		attribute {
+			key   = "event_test"
+			value = "checking strings"
+		}
+	}
+
+	event {
+		type = "tf_test"
+
+		attribute {
+			key   = "a_float"
+			value = 101.1
+			type  = "float"
+		}
+	}
+}
+`,
+		createChecks: map[string]string{"event.#": "2"},
+	},
+	"newrelic_plugins_alert_condition.foo": {
+		config: `
+resource "newrelic_alert_policy" "foo" {
+	name = "tf-test"
+}
+
+resource "newrelic_plugins_alert_condition" "foo" {
+	policy_id          = newrelic_alert_policy.foo.id
+	name               = "tf-test"
+	enabled            = %t
+	entities           = [123456]
+	metric             = "Component/Connection/Clients[connections]"
+	metric_description = "my-metric-description"
+	plugin_id          = "21709"
+	plugin_guid        = "net.kenjij.newrelic_redis_plugin"
+	value_function     = "average"
+
+	term {
+		duration      = 5
+		operator      = "below"
+		priority      = "critical"
+		threshold     = "%s"
+		time_function = "all"
+	}
+}
+`,
+		createArgs:   []interface{}{false, "0.75"},
+		createChecks: map[string]string{"enabled": "false"},
+		updateArgs:   []interface{}{true, "0.65"},
+		updateChecks: map[string]string{"enabled": "true"},
+		kinds:        []string{fakeKindPolicy, fakeKindPluginsCondition},
+	},
+	"newrelic_synthetics_alert_condition.foo": {
+		config: `
+resource "newrelic_synthetics_monitor" "foo" {
+	name      = "tf-test"
+	type      = "SIMPLE"
+	frequency = 15
+	status    = "DISABLED"
+	locations = ["AWS_US_EAST_1"]
+	uri       = "https://example.com"
+}
+
+resource "newrelic_alert_policy" "foo" {
+	name = "tf-test"
+}
+
+resource "newrelic_synthetics_alert_condition" "foo" {
+	policy_id   = newrelic_alert_policy.foo.id
+	name        = "tf-test"
+	monitor_id  = newrelic_synthetics_monitor.foo.id
+	runbook_url = "%s"
+	enabled     = %t
+}
+`,
+		createArgs:   []interface{}{"www.example.com", true},
+		createChecks: map[string]string{"runbook_url": "www.example.com", "enabled": "true"},
+		updateArgs:   []interface{}{"www.example-updated.com", false},
+		updateChecks: map[string]string{"runbook_url": "www.example-updated.com", "enabled": "false"},
+		kinds:        []string{fakeKindPolicy, fakeKindMonitor, fakeKindSyntheticsCondition},
+	},
+	"newrelic_synthetics_monitor_script.foo": {
+		config: `
+resource "newrelic_synthetics_monitor" "foo" {
+	name      = "tf-test"
+	type      = "SCRIPT_BROWSER"
+	frequency = 1
+	status    = "DISABLED"
+	locations = ["AWS_US_EAST_1"]
+	uri       = "https://example.com"
+}
+
+resource "newrelic_synthetics_monitor_script" "foo" {
+	monitor_id = newrelic_synthetics_monitor.foo.id
+	text       = "%s"
+}
+`,
+		createArgs:   []interface{}{"console.log('foo')"},
+		createChecks: map[string]string{"text": "console.log('foo')"},
+		updateArgs:   []interface{}{"console.log('bar')"},
+		updateChecks: map[string]string{"text": "console.log('bar')"},
+		kinds:        []string{fakeKindMonitor, fakeKindMonitorScript},
+	},
+	"newrelic_synthetics_multilocation_alert_condition.foo": {
+		config: `
+resource "newrelic_synthetics_monitor" "foo" {
+	name      = "tf-test"
+	type      = "SIMPLE"
+	frequency = 15
+	status    = "DISABLED"
+	locations = ["AWS_US_EAST_1", "AWS_US_WEST_1"]
+	uri       = "https://example.com"
+}
+
+resource "newrelic_alert_policy" "foo" {
+	name = "tf-test"
+}
+
+resource "newrelic_synthetics_multilocation_alert_condition" "foo" {
+	policy_id                    = newrelic_alert_policy.foo.id
+	name                         = "tf-test"
+	violation_time_limit_seconds = 3600
+	entities                     = [newrelic_synthetics_monitor.foo.id]
+
+	critical {
+		threshold = %d
+	}
+
+	warning {
+		threshold = %d
+	}
+}
+`,
+		createArgs:   []interface{}{1, 2},
+		createChecks: map[string]string{"critical.0.threshold": "1", "warning.0.threshold": "2"},
+		updateArgs:   []interface{}{11, 12},
+		updateChecks: map[string]string{"critical.0.threshold": "11", "warning.0.threshold": "12"},
+		kinds:        []string{fakeKindPolicy, fakeKindMonitor, fakeKindLocationFailureCondition},
+	},
+	"newrelic_synthetics_secure_credential.foo": {
+		config: `
+resource "newrelic_synthetics_secure_credential" "foo" {
+	key         = "TF_TEST"
+	value       = "%s"
+	description = "%s"
+}
+`,
+		createArgs:   []interface{}{"Test Value", "Test Description"},
+		createChecks: map[string]string{"description": "Test Description"},
+		updateArgs:   []interface{}{"Test Value Updated", "Test Description Updated"},
+		updateChecks: map[string]string{"value": "Test Value Updated", "description": "Test Description Updated"},
+		kinds:        []string{fakeKindSecureCredential},
+		// not returned from the API
+		importStateVerifyIgnore: []string{"value"},
+	},
+}
+
+func TestAccNewRelicProvider_OfflineResources(t *testing.T) {
+	resources := Provider().(*schema.Provider).ResourcesMap
+
+	for resourceName, tc := range offlineResourceTests {
+		resourceName, tc := resourceName, tc
+
+		t.Run(resourceName, func(t *testing.T) {
+			api := newFakeNewRelicAPI(t)
+			if tc.application != "" {
+				api.seedApplication(tc.application)
+			}
+
+			steps := []resource.TestStep{
+				// Test: Create
+				{
+					Config: api.config(fmt.Sprintf(tc.config, tc.createArgs...)),
+					Check:  testAccCheckResourceAttrs(resourceName, tc.createChecks),
+				},
+			}
+
+			config := steps[0].Config
+			if tc.updateArgs != nil {
+				config = api.config(fmt.Sprintf(tc.config, tc.updateArgs...))
+
+				// Test: Update
+				steps = append(steps, resource.TestStep{
+					Config: config,
+					Check:  testAccCheckResourceAttrs(resourceName, tc.updateChecks),
+				})
+			}
+
+			if resources[strings.Split(resourceName, ".")[0]].Importer != nil {
+				step := resource.TestStep{
+					Config:                  config,
+					ResourceName:            resourceName,
+					ImportState:             true,
+					ImportStateVerify:       true,
+					ImportStateVerifyIgnore: tc.importStateVerifyIgnore,
+				}
+				if tc.importStateIDFunc != nil {
+					step.ImportStateIdFunc = tc.importStateIDFunc(resourceName)
+				}
+
+				// Test: Import
+				steps = append(steps, step)
+			}
+
+			resource.ParallelTest(t, resource.TestCase{
+				IsUnitTest:   true,
+				Providers:    api.providers(),
+				CheckDestroy: api.checkDestroyed(tc.kinds...),
+				Steps:        steps,
+			})
+		})
+	}
+}
+
+func testAccCheckResourceAttrs(resourceName string, attrs map[string]string) resource.TestCheckFunc {
+	checks := make([]resource.TestCheckFunc, 0, len(attrs))
+	for k, v := range attrs {
+		checks = append(checks, resource.TestCheckResourceAttr(resourceName, k, v))
+	}
+
+	return resource.ComposeTestCheckFunc(checks...)
+}
@@ -1,12 +1,14 @@
 package newrelic
 
 import (
+	"encoding/base64"
 	"fmt"
 	"log"
 	"strconv"
 	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	nr "github.com/newrelic/newrelic-client-go/newrelic"
 )
 
 // Generates a compound ID out of a slice of strings.
@@ -107,7 +109,8 @@ func resourceImportStateWithMetadata(defaultIDCount int, attribute string) schem
 // Selects the proper accountID for usage within a resource. An account ID provided
 // within a `resource` block will override a `provider` block account ID. This ensures
 // resources can be scoped to specific accounts. Bear in mind those accounts must be
-// accessible with the provided Personal API Key (APIKS).
+// accessible with the provided Personal API Key (APIKS), or be configured with
+// their own key in an `account` block of the provider.
 func selectAccountID(providerCondig *ProviderConfig, d *schema.ResourceData) int {
 	resourceAccountIDAttr := d.Get("account_id")
 
@@ -121,3 +124,43 @@ func selectAccountID(providerCondig *ProviderConfig, d *schema.ResourceData) int
 
 	return providerCondig.AccountID
 }
+
+// accountClient returns the client for the account selected by
+// selectAccountID.
+func accountClient(providerConfig *ProviderConfig, d *schema.ResourceData) *nr.NewRelic {
+	return providerConfig.client(selectAccountID(providerConfig, d))
+}
+
+// entityClient returns the client for the account of an entity, which is
+// encoded in the entity's GUID.
+func entityClient(providerConfig *ProviderConfig, guid string) *nr.NewRelic {
+	decoded, err := base64.RawStdEncoding.DecodeString(strings.TrimRight(guid, "="))
+	if err != nil {
+		return providerConfig.NewClient
+	}
+
+	accountID, err := strconv.Atoi(strings.SplitN(string(decoded), "|", 2)[0])
+	if err != nil {
+		return providerConfig.NewClient
+	}
+
+	return providerConfig.client(accountID)
+}
+
+// restAccountClient returns the client for the account selected by
+// selectAccountID, for resources managed with the REST APIs. Those APIs act
+// on the account of the API key, so accounts other than the one of the
+// provider can only be reached with the key of their `account` block.
+func restAccountClient(providerConfig *ProviderConfig, d *schema.ResourceData) (*nr.NewRelic, error) {
+	accountID := selectAccountID(providerConfig, d)
+	if accountID == providerConfig.AccountID {
+		return providerConfig.NewClient, nil
+	}
+
+	client, ok := providerConfig.accountClients[accountID]
+	if !ok {
+		return nil, fmt.Errorf("account %d must be configured in an account block of the provider to be managed with the New Relic REST APIs", accountID)
+	}
+
+	return client, nil
+}
@@ -0,0 +1,119 @@
+package newrelic
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/newrelic/newrelic-client-go/pkg/errors"
+)
+
+var accountRegionCodes = []string{"eu01", "us01"}
+
+func resourceNewRelicAccount() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceNewRelicAccountCreate,
+		Read:   resourceNewRelicAccountRead,
+		Update: resourceNewRelicAccountUpdate,
+		Delete: resourceNewRelicAccountDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The name of the account.",
+			},
+			"region": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringInSlice(accountRegionCodes, false),
+				Description:  "The data center the account is created in. One of: (eu01, us01).",
+			},
+		},
+	}
+}
+
+func resourceNewRelicAccountCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*ProviderConfig).NewClient
+
+	input := managedAccountCreateInput{
+		Name:       d.Get("name").(string),
+		RegionCode: d.Get("region").(string),
+	}
+
+	log.Printf("[INFO] Creating New Relic account %s", input.Name)
+
+	created, err := createManagedAccount(client, input)
+	if err != nil {
+		return err
+	}
+
+	if created == nil {
+		return fmt.Errorf("no account was returned after creating account %s", input.Name)
+	}
+
+	d.SetId(strconv.Itoa(created.ID))
+
+	return readAfterWrite(resourceNewRelicAccountRead, d, meta)
+}
+
+func resourceNewRelicAccountRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*ProviderConfig).NewClient
+
+	log.Printf("[INFO] Reading New Relic account %s", d.Id())
+
+	id, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return err
+	}
+
+	account, err := getManagedAccount(client, id)
+	if err != nil {
+		if _, ok := err.(*errors.NotFound); ok {
+			d.SetId("")
+			return nil
+		}
+
+		return err
+	}
+
+	d.Set("name", account.Name)
+	d.Set("region", account.RegionCode)
+
+	return nil
+}
+
+func resourceNewRelicAccountUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*ProviderConfig).NewClient
+
+	id, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return err
+	}
+
+	input := managedAccountUpdateInput{
+		ID:   id,
+		Name: d.Get("name").(string),
+	}
+
+	log.Printf("[INFO] Updating New Relic account %d", id)
+
+	if _, err := updateManagedAccount(client, input); err != nil {
+		return err
+	}
+
+	return readAfterWrite(resourceNewRelicAccountRead, d, meta)
+}
+
+// Accounts can not be deleted through the API, so deleting the resource only
+// removes the account from the state.
+func resourceNewRelicAccountDelete(d *schema.ResourceData, meta interface{}) error {
+	log.Printf("[WARN] New Relic account %s can not be deleted through the API and is only removed from the state", d.Id())
+
+	return nil
+}
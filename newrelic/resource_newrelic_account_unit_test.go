@@ -0,0 +1,61 @@
+// +build unit
+
+package newrelic
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+)
+
+func TestAccNewRelicAccount_Offline(t *testing.T) {
+	api := newFakeNewRelicAPI(t)
+	resourceName := "newrelic_account.foo"
+	rName := fmt.Sprintf("tf-test-%s", acctest.RandString(5))
+
+	resource.ParallelTest(t, resource.TestCase{
+		IsUnitTest: true,
+		Providers:  api.providers(),
+		// Accounts can not be deleted, so destroying only forgets them.
+		CheckDestroy: func(s *terraform.State) error {
+			if n := api.count(fakeKindManagedAccount); n != 1 {
+				return fmt.Errorf("expected the account to outlive its resource, found %d accounts", n)
+			}
+			return nil
+		},
+		Steps: []resource.TestStep{
+			// Test: Create
+			{
+				Config: api.config(testAccNewRelicAccountUnitConfig(rName)),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "name", rName),
+					resource.TestCheckResourceAttr(resourceName, "region", "us01"),
+				),
+			},
+			// Test: Rename
+			{
+				Config: api.config(testAccNewRelicAccountUnitConfig(rName + "-updated")),
+				Check:  resource.TestCheckResourceAttr(resourceName, "name", rName+"-updated"),
+			},
+			// Test: Import
+			{
+				Config:            api.config(testAccNewRelicAccountUnitConfig(rName + "-updated")),
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccNewRelicAccountUnitConfig(name string) string {
+	return fmt.Sprintf(`
+resource "newrelic_account" "foo" {
+	name   = "%s"
+	region = "us01"
+}
+`, name)
+}
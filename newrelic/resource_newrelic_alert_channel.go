@@ -8,6 +8,7 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/newrelic/newrelic-client-go/pkg/alerts"
 	"github.com/newrelic/newrelic-client-go/pkg/errors"
 )
 
@@ -55,16 +56,23 @@ func resourceNewRelicAlertChannel() *schema.Resource {
 	return &schema.Resource{
 		Create: resourceNewRelicAlertChannelCreate,
 		Read:   resourceNewRelicAlertChannelRead,
-		// Update: Not currently supported in API
+		Update: resourceNewRelicAlertChannelUpdate,
 		Delete: resourceNewRelicAlertChannelDelete,
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
 		},
+		CustomizeDiff: resourceNewRelicAlertChannelCustomizeDiff,
 		Schema: map[string]*schema.Schema{
+			"account_id": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "The New Relic account ID to operate on. Accounts other than the one of the provider must be configured in an account block of the provider.",
+			},
 			"name": {
 				Type:        schema.TypeString,
 				Required:    true,
-				ForceNew:    true,
 				Description: "(Required) The name of the channel.",
 			},
 			"type": {
@@ -77,7 +85,6 @@ func resourceNewRelicAlertChannel() *schema.Resource {
 			"config": {
 				Type:        schema.TypeList,
 				Optional:    true,
-				ForceNew:    true,
 				MaxItems:    1,
 				Description: "The configuration block for the alert channel.",
 				Elem: &schema.Resource{
@@ -86,14 +93,12 @@ func resourceNewRelicAlertChannel() *schema.Resource {
 							Type:        schema.TypeString,
 							Optional:    true,
 							Sensitive:   true,
-							ForceNew:    true,
 							Description: "The API key for integrating with OpsGenie.",
 						},
 						"auth_password": {
 							Type:        schema.TypeString,
 							Optional:    true,
 							Sensitive:   true,
-							ForceNew:    true,
 							Description: "Specifies an authentication password for use with a channel. Supported by the webhook channel type.",
 						},
 						"auth_type": {
@@ -101,26 +106,22 @@ func resourceNewRelicAlertChannel() *schema.Resource {
 							Optional:     true,
 							Sensitive:    true,
 							ValidateFunc: validation.StringInSlice([]string{"BASIC"}, false),
-							ForceNew:     true,
 							Description:  "Specifies an authentication method for use with a channel. Supported by the webhook channel type. Only HTTP basic authentication is currently supported via the value BASIC.",
 						},
 						"auth_username": {
 							Type:        schema.TypeString,
 							Optional:    true,
-							ForceNew:    true,
 							Description: "Specifies an authentication username for use with a channel. Supported by the webhook channel type.",
 						},
 						"base_url": {
 							Type:        schema.TypeString,
 							Optional:    true,
 							Sensitive:   true,
-							ForceNew:    true,
 							Description: "The base URL of the webhook destination.",
 						},
 						"channel": {
 							Type:        schema.TypeString,
 							Optional:    true,
-							ForceNew:    true,
 							Description: "The Slack channel to send notifications to.",
 						},
 						"headers": {
@@ -128,7 +129,6 @@ func resourceNewRelicAlertChannel() *schema.Resource {
 							Elem:          &schema.Schema{Type: schema.TypeString},
 							Optional:      true,
 							Sensitive:     true,
-							ForceNew:      true,
 							ConflictsWith: []string{"config.0.headers_string"},
 							Description:   "A map of key/value pairs that represents extra HTTP headers to be sent along with the webhook payload.",
 						},
@@ -136,7 +136,6 @@ func resourceNewRelicAlertChannel() *schema.Resource {
 							Type:          schema.TypeString,
 							Optional:      true,
 							Sensitive:     true,
-							ForceNew:      true,
 							ConflictsWith: []string{"config.0.headers"},
 							Description:   "Use instead of headers if the desired payload is more complex than a list of key/value pairs (e.g. a set of headers that makes use of nested objects). The value provided should be a valid JSON string with escaped double quotes. Conflicts with headers.",
 							// Suppress the diff shown if the differences are solely due to whitespace
@@ -148,13 +147,11 @@ func resourceNewRelicAlertChannel() *schema.Resource {
 							Type:        schema.TypeString,
 							Optional:    true,
 							Sensitive:   true,
-							ForceNew:    true,
 							Description: "The key for integrating with VictorOps.",
 						},
 						"include_json_attachment": {
 							Type:        schema.TypeString,
 							Optional:    true,
-							ForceNew:    true,
 							Description: "0 or 1. Flag for whether or not to attach a JSON document containing information about the associated alert to the email that is sent to recipients.",
 						},
 						"payload": {
@@ -162,7 +159,6 @@ func resourceNewRelicAlertChannel() *schema.Resource {
 							Elem:          &schema.Schema{Type: schema.TypeString},
 							Sensitive:     true,
 							Optional:      true,
-							ForceNew:      true,
 							ConflictsWith: []string{"config.0.payload_string"},
 							Description:   "A map of key/value pairs that represents the webhook payload. Must provide payload_type if setting this argument.",
 						},
@@ -170,7 +166,6 @@ func resourceNewRelicAlertChannel() *schema.Resource {
 							Type:          schema.TypeString,
 							Optional:      true,
 							Sensitive:     true,
-							ForceNew:      true,
 							ConflictsWith: []string{"config.0.payload"},
 							Description:   "Use instead of payload if the desired payload is more complex than a list of key/value pairs (e.g. a payload that makes use of nested objects). The value provided should be a valid JSON string with escaped double quotes. Conflicts with payload.",
 							// Suppress the diff shown if the differences are solely due to whitespace
@@ -181,76 +176,82 @@ func resourceNewRelicAlertChannel() *schema.Resource {
 						"payload_type": {
 							Type:         schema.TypeString,
 							Optional:     true,
-							ForceNew:     true,
 							ValidateFunc: validation.StringInSlice([]string{"application/json", "application/x-www-form-urlencoded"}, false),
 							Description:  "Can either be application/json or application/x-www-form-urlencoded. The payload_type argument is required if payload is set.",
 						},
 						"recipients": {
 							Type:        schema.TypeString,
 							Optional:    true,
-							ForceNew:    true,
 							Description: "A set of recipients for targeting notifications. Multiple values are comma separated.",
 						},
 						"region": {
 							Type:         schema.TypeString,
 							Optional:     true,
 							ValidateFunc: validation.StringInSlice([]string{"US", "EU"}, false),
-							ForceNew:     true,
 							Description:  "The data center region to store your data. Valid values are US and EU. Default is US.",
 						},
 						"route_key": {
 							Type:        schema.TypeString,
 							Optional:    true,
 							Sensitive:   true,
-							ForceNew:    true,
 							Description: "The route key for integrating with VictorOps.",
 						},
 						"service_key": {
 							Type:        schema.TypeString,
 							Optional:    true,
 							Sensitive:   true,
-							ForceNew:    true,
 							Description: "Specifies the service key for integrating with Pagerduty.",
 						},
 						"tags": {
 							Type:        schema.TypeString,
 							Optional:    true,
-							ForceNew:    true,
 							Description: "A set of tags for targeting notifications. Multiple values are comma separated.",
 						},
 						"teams": {
 							Type:        schema.TypeString,
 							Optional:    true,
-							ForceNew:    true,
 							Description: "A set of teams for targeting notifications. Multiple values are comma separated.",
 						},
 						"url": {
 							Type:        schema.TypeString,
 							Optional:    true,
 							Sensitive:   true,
-							ForceNew:    true,
 							Description: "Your organization's Slack URL.",
 						},
 						"user_id": {
 							Type:        schema.TypeString,
 							Optional:    true,
-							ForceNew:    true,
 							Description: "The user ID for use with the user channel type.",
 						},
 					},
 				},
 			},
+			"config_fingerprints": {
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "SHA-256 fingerprints of the sensitive config values last applied, used to detect changes made outside of Terraform.",
+			},
 		},
 	}
 }
 
 func resourceNewRelicAlertChannelCreate(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*ProviderConfig).NewClient
+	client, err := restAccountClient(meta.(*ProviderConfig), d)
+	if err != nil {
+		return err
+	}
+
 	channel, err := expandAlertChannel(d)
 	if err != nil {
 		return err
 	}
 
+	fingerprints, err := fingerprintAlertChannelConfiguration(&channel.Configuration)
+	if err != nil {
+		return err
+	}
+
 	log.Printf("[INFO] Creating New Relic alert channel %s", channel.Name)
 
 	channel, err = client.Alerts.CreateChannel(*channel)
@@ -260,11 +261,18 @@ func resourceNewRelicAlertChannelCreate(d *schema.ResourceData, meta interface{}
 
 	d.SetId(strconv.Itoa(channel.ID))
 
-	return resourceNewRelicAlertChannelRead(d, meta)
+	if err := d.Set("config_fingerprints", fingerprints); err != nil {
+		return err
+	}
+
+	return readAfterWrite(resourceNewRelicAlertChannelRead, d, meta)
 }
 
 func resourceNewRelicAlertChannelRead(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*ProviderConfig).NewClient
+	client, err := restAccountClient(meta.(*ProviderConfig), d)
+	if err != nil {
+		return err
+	}
 
 	id, err := strconv.ParseInt(d.Id(), 10, 32)
 	if err != nil {
@@ -283,11 +291,62 @@ func resourceNewRelicAlertChannelRead(d *schema.ResourceData, meta interface{})
 		return err
 	}
 
-	return flattenAlertChannel(channel, d)
+	if err := d.Set("account_id", selectAccountID(meta.(*ProviderConfig), d)); err != nil {
+		return err
+	}
+
+	if err := flattenAlertChannel(channel, d); err != nil {
+		return err
+	}
+
+	fingerprints, err := mergeAlertChannelFingerprints(d, &channel.Configuration)
+	if err != nil {
+		return err
+	}
+
+	return d.Set("config_fingerprints", fingerprints)
+}
+
+func resourceNewRelicAlertChannelUpdate(d *schema.ResourceData, meta interface{}) error {
+	providerConfig := meta.(*ProviderConfig)
+	client, err := restAccountClient(providerConfig, d)
+	if err != nil {
+		return err
+	}
+
+	channel, err := expandAlertChannel(d)
+	if err != nil {
+		return err
+	}
+
+	input, err := expandAlertChannelUpdate(channel)
+	if err != nil {
+		return err
+	}
+
+	fingerprints, err := fingerprintAlertChannelConfiguration(&channel.Configuration)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[INFO] Updating New Relic alert channel %s", d.Id())
+
+	if err := updateAlertChannel(client, selectAccountID(providerConfig, d), d.Id(), *input); err != nil {
+		return err
+	}
+
+	if err := d.Set("config_fingerprints", fingerprints); err != nil {
+		return err
+	}
+
+	return readAfterWrite(resourceNewRelicAlertChannelRead, d, meta)
 }
 
 func resourceNewRelicAlertChannelDelete(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*ProviderConfig).NewClient
+	client, err := restAccountClient(meta.(*ProviderConfig), d)
+	if err != nil {
+		return err
+	}
 
 	id, err := strconv.ParseInt(d.Id(), 10, 32)
 	if err != nil {
@@ -302,3 +361,21 @@ func resourceNewRelicAlertChannelDelete(d *schema.ResourceData, meta interface{}
 
 	return nil
 }
+
+// User channels belong to a New Relic user and cannot be modified through the
+// API, so any change to one still replaces it.
+func resourceNewRelicAlertChannelCustomizeDiff(d *schema.ResourceDiff, meta interface{}) error {
+	if d.Get("type").(string) != string(alerts.ChannelTypes.User) {
+		return nil
+	}
+
+	for _, k := range []string{"name", "config"} {
+		if d.HasChange(k) {
+			if err := d.ForceNew(k); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,478 @@
+// +build unit
+
+package newrelic
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+	"github.com/newrelic/newrelic-client-go/pkg/alerts"
+)
+
+func TestAccNewRelicAlertChannel_Offline(t *testing.T) {
+	api := newFakeNewRelicAPI(t)
+	resourceName := "newrelic_alert_channel.foo"
+	rName := fmt.Sprintf("tf-test-%s", acctest.RandString(5))
+
+	resource.ParallelTest(t, resource.TestCase{
+		IsUnitTest:   true,
+		Providers:    api.providers(),
+		CheckDestroy: api.checkDestroyed(fakeKindChannel),
+		Steps: []resource.TestStep{
+			// Test: Create
+			{
+				Config: api.config(testAccNewRelicAlertChannelUnitConfig(rName, "foo", "1")),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "name", rName),
+					resource.TestCheckResourceAttr(resourceName, "type", "email"),
+					resource.TestCheckResourceAttr(resourceName, "config.0.recipients", "terraform-acctest+foo@hashicorp.com"),
+					resource.TestCheckResourceAttr(resourceName, "config.0.include_json_attachment", "1"),
+				),
+			},
+			// Test: Update
+			{
+				Config: api.config(testAccNewRelicAlertChannelUnitConfig(rName, "bar", "0")),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "config.0.recipients", "terraform-acctest+bar@hashicorp.com"),
+					resource.TestCheckResourceAttr(resourceName, "config.0.include_json_attachment", "0"),
+				),
+			},
+			// Test: Import
+			{
+				Config:            api.config(testAccNewRelicAlertChannelUnitConfig(rName, "bar", "0")),
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccNewRelicAlertChannelUnitConfig(name string, recipient string, includeJSON string) string {
+	return fmt.Sprintf(`
+resource "newrelic_alert_channel" "foo" {
+	name = "%[1]s"
+	type = "email"
+
+	config {
+		recipients              = "terraform-acctest+%[2]s@hashicorp.com"
+		include_json_attachment = "%[3]s"
+	}
+}
+`, name, recipient, includeJSON)
+}
+
+func TestAccNewRelicAlertChannel_OfflineAccount(t *testing.T) {
+	api := newFakeNewRelicAPI(t)
+	resourceName := "newrelic_alert_channel.foo"
+	rName := fmt.Sprintf("tf-test-%s", acctest.RandString(5))
+
+	resource.ParallelTest(t, resource.TestCase{
+		IsUnitTest:   true,
+		Providers:    api.providers(),
+		CheckDestroy: api.checkDestroyed(fakeKindChannel),
+		Steps: []resource.TestStep{
+			// Test: An account without an account block
+			{
+				Config:      api.config(testAccNewRelicAlertChannelUnitAccountConfig(rName, fakeSubAccountID)),
+				ExpectError: regexp.MustCompile(fmt.Sprintf("account %d must be configured in an account block", fakeSubAccountID)),
+			},
+			// Test: Create in a sub-account
+			{
+				Config: api.configWithProvider(testAccNewRelicUnitAccountBlock(fakeSubAccountID), testAccNewRelicAlertChannelUnitAccountConfig(rName, fakeSubAccountID)),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "account_id", strconv.Itoa(fakeSubAccountID)),
+					func(s *terraform.State) error {
+						if api.requestsWithAPIKey("NRAK-FAKE-SUB") == 0 {
+							return fmt.Errorf("expected the channel to be managed with the sub-account API key")
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+func testAccNewRelicAlertChannelUnitAccountConfig(name string, accountID int) string {
+	return fmt.Sprintf(`
+resource "newrelic_alert_channel" "foo" {
+	account_id = %[2]d
+	name       = "%[1]s"
+	type       = "email"
+
+	config {
+		recipients = "terraform-acctest+foo@hashicorp.com"
+	}
+}
+`, name, accountID)
+}
+
+func TestAccNewRelicAlertChannel_OfflineUpdatePreservesPolicies(t *testing.T) {
+	api := newFakeNewRelicAPI(t)
+	resourceName := "newrelic_alert_channel.foo"
+	rName := fmt.Sprintf("tf-test-%s", acctest.RandString(5))
+	var channelID string
+
+	resource.ParallelTest(t, resource.TestCase{
+		IsUnitTest:   true,
+		Providers:    api.providers(),
+		CheckDestroy: api.checkDestroyed(fakeKindChannel, fakeKindPolicy),
+		Steps: []resource.TestStep{
+			// Test: Create
+			{
+				Config: api.config(testAccNewRelicAlertChannelWebhookUnitConfig(rName, "https://example.com/foo", "foo")),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCaptureResourceID(resourceName, &channelID),
+					resource.TestCheckResourceAttr(resourceName, "config.0.headers.x-test", "foo"),
+				),
+			},
+			// Test: Update
+			{
+				Config: api.config(testAccNewRelicAlertChannelWebhookUnitConfig(rName+"-updated", "https://example.com/bar", "bar")),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckResourceIDUnchanged(resourceName, &channelID),
+					resource.TestCheckResourceAttr(resourceName, "name", rName+"-updated"),
+					resource.TestCheckResourceAttr(resourceName, "config.0.base_url", "https://example.com/bar"),
+					resource.TestCheckResourceAttr(resourceName, "config.0.headers.x-test", "bar"),
+					resource.TestCheckResourceAttr(resourceName, "config.0.payload.message", "bar"),
+					resource.TestCheckResourceAttrPair("newrelic_alert_policy_channel.foo", "channel_ids.0", resourceName, "id"),
+				),
+			},
+			// Test: Remove payload and base URL
+			{
+				Config: api.config(testAccNewRelicAlertChannelWebhookUnitConfigNoPayload(rName + "-updated")),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckResourceIDUnchanged(resourceName, &channelID),
+					resource.TestCheckResourceAttr(resourceName, "config.0.base_url", ""),
+					resource.TestCheckResourceAttr(resourceName, "config.0.payload.%", "0"),
+					resource.TestCheckResourceAttr(resourceName, "config.0.payload_type", ""),
+				),
+			},
+		},
+	})
+}
+
+func TestAccNewRelicAlertChannel_OfflineUpdateInPlace(t *testing.T) {
+	api := newFakeNewRelicAPI(t)
+	rName := fmt.Sprintf("tf-test-%s", acctest.RandString(5))
+	channelIDs := map[string]*string{}
+
+	var captureIDs, checkIDs []resource.TestCheckFunc
+	for _, channelType := range []string{"email", "opsgenie", "pagerduty", "slack", "victorops"} {
+		resourceName := "newrelic_alert_channel." + channelType
+		channelIDs[channelType] = new(string)
+
+		captureIDs = append(captureIDs, testAccCaptureResourceID(resourceName, channelIDs[channelType]))
+		checkIDs = append(checkIDs,
+			testAccCheckResourceIDUnchanged(resourceName, channelIDs[channelType]),
+			resource.TestCheckResourceAttr(resourceName, "name", rName+"-updated"),
+		)
+	}
+
+	resource.ParallelTest(t, resource.TestCase{
+		IsUnitTest:   true,
+		Providers:    api.providers(),
+		CheckDestroy: api.checkDestroyed(fakeKindChannel),
+		Steps: []resource.TestStep{
+			// Test: Create
+			{
+				Config: api.config(testAccNewRelicAlertChannelTypesUnitConfig(rName, "foo")),
+				Check:  resource.ComposeTestCheckFunc(captureIDs...),
+			},
+			// Test: Update
+			{
+				Config: api.config(testAccNewRelicAlertChannelTypesUnitConfig(rName+"-updated", "bar")),
+				Check: resource.ComposeTestCheckFunc(append(checkIDs[:len(checkIDs):len(checkIDs)],
+					resource.TestCheckResourceAttr("newrelic_alert_channel.email", "config.0.recipients", "bar@example.com"),
+					resource.TestCheckResourceAttr("newrelic_alert_channel.opsgenie", "config.0.tags", "bar"),
+					resource.TestCheckResourceAttr("newrelic_alert_channel.slack", "config.0.channel", "bar"),
+				)...),
+			},
+			// Test: Remove the optional fields
+			{
+				Config: api.config(testAccNewRelicAlertChannelTypesUnitConfig(rName+"-updated", "")),
+				Check: resource.ComposeTestCheckFunc(append(checkIDs[:len(checkIDs):len(checkIDs)],
+					resource.TestCheckResourceAttr("newrelic_alert_channel.opsgenie", "config.0.region", ""),
+					resource.TestCheckResourceAttr("newrelic_alert_channel.slack", "config.0.channel", ""),
+					resource.TestCheckResourceAttr("newrelic_alert_channel.victorops", "config.0.route_key", ""),
+					testAccCheckNewRelicAlertChannelUnitConfigCleared(api, "newrelic_alert_channel.opsgenie", "region"),
+					testAccCheckNewRelicAlertChannelUnitConfigCleared(api, "newrelic_alert_channel.slack", "channel"),
+					testAccCheckNewRelicAlertChannelUnitConfigCleared(api, "newrelic_alert_channel.victorops", "route_key"),
+				)...),
+			},
+		},
+	})
+}
+
+// testAccNewRelicAlertChannelTypesUnitConfig configures a channel of each
+// type, leaving out their optional fields when value is empty.
+func testAccNewRelicAlertChannelTypesUnitConfig(name string, value string) string {
+	region, channel, routeKey := "", "", ""
+	if value != "" {
+		region = `region = "EU"`
+		channel = fmt.Sprintf("channel = %q", value)
+		routeKey = fmt.Sprintf("route_key = %q", "/"+value)
+	}
+
+	return fmt.Sprintf(`
+resource "newrelic_alert_channel" "email" {
+	name = "%[1]s"
+	type = "email"
+
+	config {
+		recipients = "%[2]s@example.com"
+	}
+}
+
+resource "newrelic_alert_channel" "opsgenie" {
+	name = "%[1]s"
+	type = "opsgenie"
+
+	config {
+		api_key    = "abc123"
+		teams      = "team1,team2"
+		tags       = "%[2]s"
+		recipients = "%[2]s@example.com"
+		%[3]s
+	}
+}
+
+resource "newrelic_alert_channel" "pagerduty" {
+	name = "%[1]s"
+	type = "pagerduty"
+
+	config {
+		service_key = "abc123"
+	}
+}
+
+resource "newrelic_alert_channel" "slack" {
+	name = "%[1]s"
+	type = "slack"
+
+	config {
+		url     = "https://hooks.slack.com/services/XXXXXXX/XXXXXXX/XXXXXXXXXX"
+		%[4]s
+	}
+}
+
+resource "newrelic_alert_channel" "victorops" {
+	name = "%[1]s"
+	type = "victorops"
+
+	config {
+		key = "abc123"
+		%[5]s
+	}
+}
+`, name, value, region, channel, routeKey)
+}
+
+// testAccCheckNewRelicAlertChannelUnitConfigCleared checks that a field of
+// the channel's configuration was removed from the fake API.
+func testAccCheckNewRelicAlertChannelUnitConfigCleared(api *fakeNewRelicAPI, resourceName string, key string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("not found: %s", resourceName)
+		}
+
+		rec := api.get(fakeKindChannel, rs.Primary.ID)
+		if rec == nil {
+			return fmt.Errorf("%s not found in the fake API", resourceName)
+		}
+
+		if v, ok := fakeMap(rec["configuration"])[key]; ok {
+			return fmt.Errorf("%s still has %s %v", resourceName, key, v)
+		}
+
+		return nil
+	}
+}
+
+func testAccCaptureResourceID(resourceName string, id *string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("not found: %s", resourceName)
+		}
+
+		*id = rs.Primary.ID
+
+		return nil
+	}
+}
+
+func testAccCheckResourceIDUnchanged(resourceName string, id *string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("not found: %s", resourceName)
+		}
+
+		if rs.Primary.ID != *id {
+			return fmt.Errorf("%s was replaced: ID changed from %s to %s", resourceName, *id, rs.Primary.ID)
+		}
+
+		return nil
+	}
+}
+
+func testAccNewRelicAlertChannelWebhookUnitConfig(name string, baseURL string, value string) string {
+	return fmt.Sprintf(`
+resource "newrelic_alert_policy" "foo" {
+	name = "%[1]s"
+}
+
+resource "newrelic_alert_channel" "foo" {
+	name = "%[1]s"
+	type = "webhook"
+
+	config {
+		base_url     = "%[2]s"
+		payload_type = "application/json"
+
+		headers = {
+			x-test = "%[3]s"
+		}
+
+		payload = {
+			message = "%[3]s"
+		}
+	}
+}
+
+resource "newrelic_alert_policy_channel" "foo" {
+	policy_id   = newrelic_alert_policy.foo.id
+	channel_ids = [newrelic_alert_channel.foo.id]
+}
+`, name, baseURL, value)
+}
+
+func testAccNewRelicAlertChannelWebhookUnitConfigNoPayload(name string) string {
+	return fmt.Sprintf(`
+resource "newrelic_alert_policy" "foo" {
+	name = "%[1]s"
+}
+
+resource "newrelic_alert_channel" "foo" {
+	name = "%[1]s"
+	type = "webhook"
+
+	config {
+		headers = {
+			x-test = "bar"
+		}
+	}
+}
+
+resource "newrelic_alert_policy_channel" "foo" {
+	policy_id   = newrelic_alert_policy.foo.id
+	channel_ids = [newrelic_alert_channel.foo.id]
+}
+`, name)
+}
+
+func TestAccNewRelicAlertChannel_OfflineSecretDrift(t *testing.T) {
+	api := newFakeNewRelicAPI(t)
+	resourceName := "newrelic_alert_channel.foo"
+	rName := fmt.Sprintf("tf-test-%s", acctest.RandString(5))
+	var channelID string
+
+	resource.ParallelTest(t, resource.TestCase{
+		IsUnitTest:   true,
+		Providers:    api.providers(),
+		CheckDestroy: api.checkDestroyed(fakeKindChannel),
+		Steps: []resource.TestStep{
+			// Test: Create
+			{
+				Config: api.config(testAccNewRelicAlertChannelPagerDutyUnitConfig(rName, "abc123")),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCaptureResourceID(resourceName, &channelID),
+					resource.TestCheckResourceAttr(resourceName, "config.0.service_key", "abc123"),
+					resource.TestCheckResourceAttr(resourceName, "config_fingerprints.service_key",
+						"6ca13d52ca70c883e0f0bb101e425a89e8624de51db2d2392593af6a84118090"),
+				),
+			},
+			// Test: Rotating the key outside of Terraform is detected and reverted
+			{
+				PreConfig: func() {
+					api.update(fakeKindChannel, channelID, func(channel map[string]interface{}) {
+						fakeMap(channel["configuration"])["service_key"] = "rotated"
+					})
+				},
+				Config: api.config(testAccNewRelicAlertChannelPagerDutyUnitConfig(rName, "abc123")),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckResourceIDUnchanged(resourceName, &channelID),
+					resource.TestCheckResourceAttr(resourceName, "config.0.service_key", "abc123"),
+					testAccCheckNewRelicAlertChannelFakeServiceKey(api, &channelID, "abc123"),
+				),
+			},
+		},
+	})
+}
+
+// State written before fingerprints existed has none to compare with, which
+// must not lead to the plaintext returned by the API being stored.
+func TestFlattenAlertChannel_KeepsConfiguredSecrets(t *testing.T) {
+	d := resourceNewRelicAlertChannel().TestResourceData()
+	d.Set("config", []interface{}{map[string]interface{}{
+		"service_key": "abc123",
+		"headers":     map[string]interface{}{"x-secret": "abc123"},
+	}})
+
+	channel := &alerts.Channel{
+		Name: "foo",
+		Type: alerts.ChannelTypes.PagerDuty,
+		Configuration: alerts.ChannelConfiguration{
+			ServiceKey: "remote",
+			Headers:    map[string]interface{}{"x-secret": "remote"},
+		},
+	}
+
+	if err := flattenAlertChannel(channel, d); err != nil {
+		t.Fatal(err)
+	}
+
+	if v := d.Get("config.0.service_key"); v != "abc123" {
+		t.Errorf("expected the configured service_key to be kept, got %q", v)
+	}
+
+	if v := d.Get("config.0.headers.x-secret"); v != "abc123" {
+		t.Errorf("expected the configured headers to be kept, got %q", v)
+	}
+}
+
+func testAccCheckNewRelicAlertChannelFakeServiceKey(api *fakeNewRelicAPI, id *string, expected string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		channel := api.get(fakeKindChannel, *id)
+		if channel == nil {
+			return fmt.Errorf("alert channel %s not found", *id)
+		}
+
+		if actual := fakeMap(channel["configuration"])["service_key"]; actual != expected {
+			return fmt.Errorf("expected service_key %q, got %q", expected, actual)
+		}
+
+		return nil
+	}
+}
+
+func testAccNewRelicAlertChannelPagerDutyUnitConfig(name string, serviceKey string) string {
+	return fmt.Sprintf(`
+resource "newrelic_alert_channel" "foo" {
+	name = "%[1]s"
+	type = "pagerduty"
+
+	config {
+		service_key = "%[2]s"
+	}
+}
+`, name, serviceKey)
+}
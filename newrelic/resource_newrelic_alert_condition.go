@@ -84,6 +84,13 @@ func resourceNewRelicAlertCondition() *schema.Resource {
 			State: schema.ImportStatePassthrough,
 		},
 		Schema: map[string]*schema.Schema{
+			"account_id": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "The New Relic account ID to operate on. Accounts other than the one of the provider must be configured in an account block of the provider.",
+			},
 			"policy_id": {
 				Type:        schema.TypeInt,
 				Required:    true,
@@ -200,7 +207,11 @@ func resourceNewRelicAlertCondition() *schema.Resource {
 }
 
 func resourceNewRelicAlertConditionCreate(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*ProviderConfig).NewClient
+	client, err := restAccountClient(meta.(*ProviderConfig), d)
+	if err != nil {
+		return err
+	}
+
 	condition, err := expandAlertCondition(d)
 	if err != nil {
 		return err
@@ -217,12 +228,16 @@ func resourceNewRelicAlertConditionCreate(d *schema.ResourceData, meta interface
 
 	d.SetId(serializeIDs([]int{policyID, condition.ID}))
 
-	return nil
+	return readAfterWrite(resourceNewRelicAlertConditionRead, d, meta)
 }
 
 func resourceNewRelicAlertConditionRead(d *schema.ResourceData, meta interface{}) error {
 	providerConfig := meta.(*ProviderConfig)
-	client := meta.(*ProviderConfig).NewClient
+	client, err := restAccountClient(providerConfig, d)
+	if err != nil {
+		return err
+	}
+
 	accountID := selectAccountID(providerConfig, d)
 
 	log.Printf("[INFO] Reading New Relic alert condition %s", d.Id())
@@ -256,11 +271,19 @@ func resourceNewRelicAlertConditionRead(d *schema.ResourceData, meta interface{}
 
 	d.Set("policy_id", policyID)
 
+	if err := d.Set("account_id", accountID); err != nil {
+		return err
+	}
+
 	return flattenAlertCondition(condition, d)
 }
 
 func resourceNewRelicAlertConditionUpdate(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*ProviderConfig).NewClient
+	client, err := restAccountClient(meta.(*ProviderConfig), d)
+	if err != nil {
+		return err
+	}
+
 	condition, err := expandAlertCondition(d)
 	if err != nil {
 		return err
@@ -271,24 +294,23 @@ func resourceNewRelicAlertConditionUpdate(d *schema.ResourceData, meta interface
 		return err
 	}
 
-	policyID := ids[0]
 	id := ids[1]
 	condition.ID = id
 
 	log.Printf("[INFO] Updating New Relic alert condition %d", id)
 
-	updatedCondition, err := client.Alerts.UpdateCondition(*condition)
-	if err != nil {
+	if _, err := client.Alerts.UpdateCondition(*condition); err != nil {
 		return err
 	}
 
-	d.Set("policy_id", policyID)
-
-	return flattenAlertCondition(updatedCondition, d)
+	return readAfterWrite(resourceNewRelicAlertConditionRead, d, meta)
 }
 
 func resourceNewRelicAlertConditionDelete(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*ProviderConfig).NewClient
+	client, err := restAccountClient(meta.(*ProviderConfig), d)
+	if err != nil {
+		return err
+	}
 
 	ids, err := parseIDs(d.Id(), 2)
 	if err != nil {
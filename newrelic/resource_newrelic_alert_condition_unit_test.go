@@ -76,3 +76,72 @@ func TestAccNewRelicAlertCondition_EmptyName(t *testing.T) {
 		},
 	})
 }
+
+func TestAccNewRelicAlertCondition_Offline(t *testing.T) {
+	api := newFakeNewRelicAPI(t)
+	resourceName := "newrelic_alert_condition.foo"
+	rName := fmt.Sprintf("tf-test-%s", acctest.RandString(5))
+	appName := fmt.Sprintf("tf-test-app-%s", acctest.RandString(5))
+	api.seedApplication(appName)
+
+	resource.ParallelTest(t, resource.TestCase{
+		IsUnitTest:   true,
+		Providers:    api.providers(),
+		CheckDestroy: api.checkDestroyed(fakeKindPolicy, fakeKindCondition),
+		Steps: []resource.TestStep{
+			// Test: Create
+			{
+				Config: api.config(testAccNewRelicAlertConditionUnitConfig(rName, appName, "apdex", "0.75")),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "name", rName),
+					resource.TestCheckResourceAttr(resourceName, "metric", "apdex"),
+					resource.TestCheckResourceAttr(resourceName, "term.#", "1"),
+				),
+			},
+			// Test: Update
+			{
+				Config: api.config(testAccNewRelicAlertConditionUnitConfig(rName, appName, "error_percentage", "1")),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "metric", "error_percentage"),
+				),
+			},
+			// Test: Import
+			{
+				Config:            api.config(testAccNewRelicAlertConditionUnitConfig(rName, appName, "error_percentage", "1")),
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccNewRelicAlertConditionUnitConfig(name string, appName string, metric string, threshold string) string {
+	return fmt.Sprintf(`
+data "newrelic_application" "app" {
+	name = "%[2]s"
+}
+resource "newrelic_alert_policy" "foo" {
+	name = "%[1]s"
+}
+resource "newrelic_alert_condition" "foo" {
+	policy_id = newrelic_alert_policy.foo.id
+
+	name            = "%[1]s"
+	enabled         = true
+	type            = "apm_app_metric"
+	entities        = [data.newrelic_application.app.id]
+	metric          = "%[3]s"
+	runbook_url     = "https://foo.example.com"
+	condition_scope = "application"
+
+	term {
+		duration      = 5
+		operator      = "above"
+		priority      = "critical"
+		threshold     = "%[4]s"
+		time_function = "all"
+	}
+}
+`, name, appName, metric, threshold)
+}
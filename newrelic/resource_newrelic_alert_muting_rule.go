@@ -36,6 +36,7 @@ func resourceNewRelicAlertMutingRule() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
 		},
+		CustomizeDiff: resourceNewRelicAlertMutingRuleCustomizeDiff,
 		Schema: map[string]*schema.Schema{
 			"account_id": {
 				Type:        schema.TypeInt,
@@ -101,15 +102,90 @@ func resourceNewRelicAlertMutingRule() *schema.Resource {
 				Optional:    true,
 				Description: "The description of the MutingRule.",
 			},
+			"schedule": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Description: "The time window when the MutingRule should actively mute violations.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"start_time": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: validateNaiveDateTime,
+							Description:  "The datetime stamp when the MutingRule schedule should begin, in the format YYYY-MM-DDThh:mm:ss in the schedule's time zone.",
+						},
+						"end_time": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: validateNaiveDateTime,
+							Description:  "The datetime stamp when the MutingRule schedule should end, in the format YYYY-MM-DDThh:mm:ss in the schedule's time zone.",
+						},
+						"time_zone": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validateTimeZone,
+							Description:  "The time zone that applies to the MutingRule schedule, e.g. America/Los_Angeles.",
+						},
+						"repeat": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: validation.StringInSlice([]string{"DAILY", "WEEKLY", "MONTHLY"}, false),
+							Description:  "The frequency the MutingRule schedule repeats. One of: (DAILY, WEEKLY, MONTHLY). Omit for a one-time schedule.",
+						},
+						"end_repeat": {
+							Type:          schema.TypeString,
+							Optional:      true,
+							ValidateFunc:  validateNaiveDateTime,
+							ConflictsWith: []string{"schedule.0.repeat_count"},
+							Description:   "The datetime stamp when the MutingRule schedule stops repeating, in the format YYYY-MM-DDThh:mm:ss in the schedule's time zone.",
+						},
+						"repeat_count": {
+							Type:          schema.TypeInt,
+							Optional:      true,
+							ValidateFunc:  validation.IntAtLeast(1),
+							ConflictsWith: []string{"schedule.0.end_repeat"},
+							Description:   "The number of times the MutingRule schedule should repeat.",
+						},
+						"weekly_repeat_days": {
+							Type:        schema.TypeSet,
+							Optional:    true,
+							MaxItems:    7,
+							Description: "The day(s) of the week that a MutingRule should repeat when the repeat field is set to WEEKLY.",
+							Elem: &schema.Schema{
+								Type:         schema.TypeString,
+								ValidateFunc: validation.StringInSlice([]string{"MONDAY", "TUESDAY", "WEDNESDAY", "THURSDAY", "FRIDAY", "SATURDAY", "SUNDAY"}, false),
+							},
+						},
+					},
+				},
+			},
 		},
 	}
 }
 
+// An update only replaces the schedule when one is given, so removing the
+// schedule block recreates the MutingRule.
+func resourceNewRelicAlertMutingRuleCustomizeDiff(d *schema.ResourceDiff, meta interface{}) error {
+	if d.Id() == "" || !d.HasChange("schedule") {
+		return nil
+	}
+
+	if o, n := d.GetChange("schedule"); len(o.([]interface{})) > 0 && len(n.([]interface{})) == 0 {
+		return d.ForceNew("schedule")
+	}
+
+	return nil
+}
+
 func resourceNewRelicAlertMutingRuleCreate(d *schema.ResourceData, meta interface{}) error {
 	providerConfig := meta.(*ProviderConfig)
-	client := providerConfig.NewClient
+	client := accountClient(providerConfig, d)
 
-	createInput := expandMutingRuleCreateInput(d)
+	createInput, err := expandMutingRuleCreateInput(d)
+	if err != nil {
+		return err
+	}
 
 	accountID := selectAccountID(providerConfig, d)
 
@@ -122,11 +198,11 @@ func resourceNewRelicAlertMutingRuleCreate(d *schema.ResourceData, meta interfac
 
 	d.SetId(serializeIDs([]int{accountID, created.ID}))
 
-	return resourceNewRelicAlertMutingRuleRead(d, meta)
+	return readAfterWrite(resourceNewRelicAlertMutingRuleRead, d, meta)
 }
 
 func resourceNewRelicAlertMutingRuleRead(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*ProviderConfig).NewClient
+	client := accountClient(meta.(*ProviderConfig), d)
 
 	log.Printf("[INFO] Reading New Relic MutingRule alerts")
 
@@ -152,8 +228,11 @@ func resourceNewRelicAlertMutingRuleRead(d *schema.ResourceData, meta interface{
 }
 
 func resourceNewRelicAlertMutingRuleUpdate(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*ProviderConfig).NewClient
-	updateInput := expandMutingRuleUpdateInput(d)
+	client := accountClient(meta.(*ProviderConfig), d)
+	updateInput, err := expandMutingRuleUpdateInput(d)
+	if err != nil {
+		return err
+	}
 
 	log.Printf("[INFO] Updating New Relic One alert muting rule.")
 
@@ -165,17 +244,16 @@ func resourceNewRelicAlertMutingRuleUpdate(d *schema.ResourceData, meta interfac
 	accountID := ids[0]
 	mutingRuleID := ids[1]
 
-	_, err = client.Alerts.UpdateMutingRule(accountID, mutingRuleID, updateInput)
+	err = updateMutingRule(client, accountID, mutingRuleID, updateInput)
 	if err != nil {
-		d.SetId("")
-		return nil
+		return err
 	}
 
-	return resourceNewRelicAlertMutingRuleRead(d, meta)
+	return readAfterWrite(resourceNewRelicAlertMutingRuleRead, d, meta)
 }
 
 func resourceNewRelicAlertMutingRuleDelete(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*ProviderConfig).NewClient
+	client := accountClient(meta.(*ProviderConfig), d)
 
 	log.Printf("[INFO] Deleting New Relic One muting rule alert.")
 
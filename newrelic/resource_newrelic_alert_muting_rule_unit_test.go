@@ -0,0 +1,230 @@
+// +build unit
+
+package newrelic
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+)
+
+func TestAccNewRelicAlertMutingRule_Offline(t *testing.T) {
+	api := newFakeNewRelicAPI(t)
+	resourceName := "newrelic_alert_muting_rule.foo"
+	rName := acctest.RandString(5)
+
+	resource.ParallelTest(t, resource.TestCase{
+		IsUnitTest:   true,
+		Providers:    api.providers(),
+		CheckDestroy: api.checkDestroyed(fakeKindMutingRule),
+		Steps: []resource.TestStep{
+			// Test: Create
+			{
+				Config: api.config(testAccNewRelicAlertMutingRuleUnitConfig(rName, "new muting rule", "EQUALS")),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "name", "tf-test-"+rName),
+					resource.TestCheckResourceAttr(resourceName, "description", "new muting rule"),
+					resource.TestCheckResourceAttr(resourceName, "condition.0.conditions.#", "2"),
+				),
+			},
+			// Test: Update
+			{
+				Config: api.config(testAccNewRelicAlertMutingRuleUnitConfig(rName, "second muting rule", "NOT_EQUALS")),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "description", "second muting rule"),
+					resource.TestCheckResourceAttr(resourceName, "condition.0.conditions.1.operator", "NOT_EQUALS"),
+				),
+			},
+			// Test: Import
+			{
+				Config:            api.config(testAccNewRelicAlertMutingRuleUnitConfig(rName, "second muting rule", "NOT_EQUALS")),
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccNewRelicAlertMutingRuleUnitConfig(name string, description string, operator string) string {
+	return fmt.Sprintf(`
+resource "newrelic_alert_muting_rule" "foo" {
+	name        = "tf-test-%[1]s"
+	enabled     = true
+	description = "%[2]s"
+
+	condition {
+		conditions {
+			attribute = "product"
+			operator  = "EQUALS"
+			values    = ["APM"]
+		}
+		conditions {
+			attribute = "conditionType"
+			operator  = "%[3]s"
+			values    = ["static"]
+		}
+		operator = "AND"
+	}
+}
+`, name, description, operator)
+}
+
+func TestAccNewRelicAlertMutingRule_OfflineSchedule(t *testing.T) {
+	api := newFakeNewRelicAPI(t)
+	resourceName := "newrelic_alert_muting_rule.foo"
+	rName := acctest.RandString(5)
+
+	oneTime := `
+	schedule {
+		start_time = "2021-01-21T15:30:00"
+		end_time   = "2021-01-21T16:30:00"
+		time_zone  = "America/Los_Angeles"
+	}`
+
+	weekly := `
+	schedule {
+		start_time         = "2021-01-21T22:00:00"
+		end_time           = "2021-01-21T23:30:00"
+		time_zone          = "Europe/Berlin"
+		repeat             = "WEEKLY"
+		weekly_repeat_days = ["THURSDAY", "MONDAY"]
+		repeat_count       = 6
+	}`
+
+	monthly := `
+	schedule {
+		start_time = "2021-01-31T01:00:00"
+		end_time   = "2021-01-31T03:00:00"
+		time_zone  = "Asia/Tokyo"
+		repeat     = "MONTHLY"
+		end_repeat = "2021-12-31T00:00:00"
+	}`
+
+	invalidTimeZone := `
+	schedule {
+		start_time = "2021-01-21T15:30:00"
+		end_time   = "2021-01-21T16:30:00"
+		time_zone  = "America/Springfield"
+	}`
+
+	rejected := `
+	schedule {
+		start_time = "2021-01-21T15:30:00"
+		end_time   = "2021-01-21T14:30:00"
+		time_zone  = "America/Los_Angeles"
+	}`
+
+	resource.ParallelTest(t, resource.TestCase{
+		IsUnitTest:   true,
+		Providers:    api.providers(),
+		CheckDestroy: api.checkDestroyed(fakeKindMutingRule),
+		Steps: []resource.TestStep{
+			// Test: Create
+			{
+				Config: api.config(testAccNewRelicAlertMutingRuleScheduleUnitConfig(rName, oneTime)),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "schedule.0.start_time", "2021-01-21T15:30:00"),
+					resource.TestCheckResourceAttr(resourceName, "schedule.0.end_time", "2021-01-21T16:30:00"),
+					resource.TestCheckResourceAttr(resourceName, "schedule.0.time_zone", "America/Los_Angeles"),
+					resource.TestCheckResourceAttr(resourceName, "schedule.0.repeat", ""),
+				),
+			},
+			// Test: Update
+			{
+				Config: api.config(testAccNewRelicAlertMutingRuleScheduleUnitConfig(rName, weekly)),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "schedule.0.start_time", "2021-01-21T22:00:00"),
+					resource.TestCheckResourceAttr(resourceName, "schedule.0.repeat", "WEEKLY"),
+					resource.TestCheckResourceAttr(resourceName, "schedule.0.repeat_count", "6"),
+					resource.TestCheckResourceAttr(resourceName, "schedule.0.weekly_repeat_days.#", "2"),
+				),
+			},
+			// Test: Update
+			{
+				Config: api.config(testAccNewRelicAlertMutingRuleScheduleUnitConfig(rName, monthly)),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "schedule.0.repeat", "MONTHLY"),
+					resource.TestCheckResourceAttr(resourceName, "schedule.0.end_repeat", "2021-12-31T00:00:00"),
+					resource.TestCheckResourceAttr(resourceName, "schedule.0.repeat_count", "0"),
+					resource.TestCheckResourceAttr(resourceName, "schedule.0.weekly_repeat_days.#", "0"),
+				),
+			},
+			// Test: Import
+			{
+				Config:            api.config(testAccNewRelicAlertMutingRuleScheduleUnitConfig(rName, monthly)),
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Test: Clearing the repeat
+			{
+				Config: api.config(testAccNewRelicAlertMutingRuleScheduleUnitConfig(rName, oneTime)),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "schedule.0.time_zone", "America/Los_Angeles"),
+					resource.TestCheckResourceAttr(resourceName, "schedule.0.repeat", ""),
+					resource.TestCheckResourceAttr(resourceName, "schedule.0.end_repeat", ""),
+				),
+			},
+			// Test: Invalid time zone
+			{
+				Config:      api.config(testAccNewRelicAlertMutingRuleScheduleUnitConfig(rName, invalidTimeZone)),
+				ExpectError: regexp.MustCompile(`expected schedule.0.time_zone to be an IANA time zone name`),
+			},
+			// Test: Rejected update keeps the rule
+			{
+				Config:      api.config(testAccNewRelicAlertMutingRuleScheduleUnitConfig(rName, rejected)),
+				ExpectError: regexp.MustCompile(`endTime must be after startTime`),
+			},
+			{
+				Config: api.config(testAccNewRelicAlertMutingRuleScheduleUnitConfig(rName, oneTime)),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "schedule.0.end_time", "2021-01-21T16:30:00"),
+					testAccCheckNewRelicAlertMutingRuleUnitCount(api, 1),
+				),
+			},
+			// Test: Removing the schedule
+			{
+				Config: api.config(testAccNewRelicAlertMutingRuleScheduleUnitConfig(rName, "")),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "schedule.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func testAccNewRelicAlertMutingRuleScheduleUnitConfig(name string, schedule string) string {
+	return fmt.Sprintf(`
+resource "newrelic_alert_muting_rule" "foo" {
+	name    = "tf-test-%[1]s"
+	enabled = true
+
+	condition {
+		conditions {
+			attribute = "product"
+			operator  = "EQUALS"
+			values    = ["APM"]
+		}
+		operator = "AND"
+	}
+%[2]s
+}
+`, name, schedule)
+}
+
+// testAccCheckNewRelicAlertMutingRuleUnitCount checks the number of muting
+// rules stored by the fake API.
+func testAccCheckNewRelicAlertMutingRuleUnitCount(api *fakeNewRelicAPI, expected int) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		if n := api.count(fakeKindMutingRule); n != expected {
+			return fmt.Errorf("expected %d muting rules, found %d", expected, n)
+		}
+
+		return nil
+	}
+}
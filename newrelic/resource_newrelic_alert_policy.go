@@ -62,7 +62,7 @@ func resourceNewRelicAlertPolicyCreate(d *schema.ResourceData, meta interface{})
 		return errors.New("err: NerdGraph support not present, but required for Create")
 	}
 
-	client := providerConfig.NewClient
+	client := accountClient(providerConfig, d)
 	accountID := selectAccountID(providerConfig, d)
 
 	policy := alerts.AlertsPolicyInput{}
@@ -83,10 +83,6 @@ func resourceNewRelicAlertPolicyCreate(d *schema.ResourceData, meta interface{})
 	}
 
 	d.SetId(createResult.ID)
-	err = flattenAlertPolicy(createResult, d, accountID)
-	if err != nil {
-		return err
-	}
 
 	channels := d.Get("channel_ids").([]interface{})
 
@@ -110,7 +106,7 @@ func resourceNewRelicAlertPolicyCreate(d *schema.ResourceData, meta interface{})
 		}
 	}
 
-	return nil
+	return readAfterWrite(resourceNewRelicAlertPolicyRead, d, meta)
 }
 
 func resourceNewRelicAlertPolicyRead(d *schema.ResourceData, meta interface{}) error {
@@ -120,7 +116,7 @@ func resourceNewRelicAlertPolicyRead(d *schema.ResourceData, meta interface{}) e
 		return errors.New("err: NerdGraph support not present, but required for Read")
 	}
 
-	client := providerConfig.NewClient
+	client := accountClient(providerConfig, d)
 
 	ids, err := parseHashedIDs(d.Id())
 	if err != nil {
@@ -165,7 +161,7 @@ func resourceNewRelicAlertPolicyUpdate(d *schema.ResourceData, meta interface{})
 		return errors.New("err: NerdGraph support not present, but required for Update")
 	}
 
-	client := providerConfig.NewClient
+	client := accountClient(providerConfig, d)
 
 	accountID := selectAccountID(providerConfig, d)
 
@@ -183,12 +179,11 @@ func resourceNewRelicAlertPolicyUpdate(d *schema.ResourceData, meta interface{})
 		updatePolicy.Name = attr.(string)
 	}
 
-	updateResult, updateErr := client.Alerts.UpdatePolicyMutation(accountID, d.Id(), updatePolicy)
-	if updateErr != nil {
-		return updateErr
+	if _, err := client.Alerts.UpdatePolicyMutation(accountID, d.Id(), updatePolicy); err != nil {
+		return err
 	}
 
-	return flattenAlertPolicy(updateResult, d, accountID)
+	return readAfterWrite(resourceNewRelicAlertPolicyRead, d, meta)
 }
 
 func resourceNewRelicAlertPolicyDelete(d *schema.ResourceData, meta interface{}) error {
@@ -198,13 +193,17 @@ func resourceNewRelicAlertPolicyDelete(d *schema.ResourceData, meta interface{})
 		return errors.New("err: NerdGraph support not present, but required for Delete")
 	}
 
-	client := providerConfig.NewClient
+	client := accountClient(providerConfig, d)
 
 	accountID := selectAccountID(providerConfig, d)
 
 	log.Printf("[INFO] Deleting New Relic alert policy %s from account %d", d.Id(), accountID)
 
 	_, err := client.Alerts.DeletePolicyMutation(accountID, d.Id())
+
+	// Deleting a policy deletes its NRQL conditions.
+	providerConfig.nrqlConditions.invalidate()
+
 	if err != nil {
 		return err
 	}
@@ -19,6 +19,13 @@ func resourceNewRelicAlertPolicyChannel() *schema.Resource {
 			State: schema.ImportStatePassthrough,
 		},
 		Schema: map[string]*schema.Schema{
+			"account_id": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "The New Relic account ID to operate on. Accounts other than the one of the provider must be configured in an account block of the provider.",
+			},
 			"policy_id": {
 				Type:        schema.TypeInt,
 				Required:    true,
@@ -40,7 +47,11 @@ func resourceNewRelicAlertPolicyChannel() *schema.Resource {
 }
 
 func resourceNewRelicAlertPolicyChannelCreate(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*ProviderConfig).NewClient
+	client, err := restAccountClient(meta.(*ProviderConfig), d)
+	if err != nil {
+		return err
+	}
+
 	policyChannels, err := expandAlertPolicyChannels(d)
 
 	if err != nil {
@@ -67,11 +78,14 @@ func resourceNewRelicAlertPolicyChannelCreate(d *schema.ResourceData, meta inter
 
 	d.SetId(serializedID)
 
-	return resourceNewRelicAlertPolicyChannelRead(d, meta)
+	return readAfterWrite(resourceNewRelicAlertPolicyChannelRead, d, meta)
 }
 
 func resourceNewRelicAlertPolicyChannelRead(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*ProviderConfig).NewClient
+	client, err := restAccountClient(meta.(*ProviderConfig), d)
+	if err != nil {
+		return err
+	}
 
 	ids, err := parseHashedIDs(d.Id())
 	if err != nil {
@@ -96,11 +110,18 @@ func resourceNewRelicAlertPolicyChannelRead(d *schema.ResourceData, meta interfa
 		return nil
 	}
 
+	if err := d.Set("account_id", selectAccountID(meta.(*ProviderConfig), d)); err != nil {
+		return err
+	}
+
 	return flattenAlertPolicyChannels(d, policyID, parsedChannelIDs)
 }
 
 func resourceNewRelicAlertPolicyChannelDelete(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*ProviderConfig).NewClient
+	client, err := restAccountClient(meta.(*ProviderConfig), d)
+	if err != nil {
+		return err
+	}
 
 	ids, err := parseHashedIDs(d.Id())
 	if err != nil {
@@ -3,9 +3,12 @@
 package newrelic
 
 import (
+	"fmt"
 	"regexp"
+	"strconv"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
 )
 
@@ -25,3 +28,51 @@ func TestAccNewRelicAlertPolicy_ErrorThrownWhenNameEmpty(t *testing.T) {
 		},
 	})
 }
+
+func TestAccNewRelicAlertPolicy_Offline(t *testing.T) {
+	api := newFakeNewRelicAPI(t)
+	resourceName := "newrelic_alert_policy.foo"
+	rName := acctest.RandString(5)
+
+	resource.ParallelTest(t, resource.TestCase{
+		IsUnitTest:   true,
+		Providers:    api.providers(),
+		CheckDestroy: api.checkDestroyed(fakeKindPolicy, fakeKindChannel),
+		Steps: []resource.TestStep{
+			// Test: Create
+			{
+				Config: api.config(testAccNewRelicAlertPolicyConfig(rName)),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "name", fmt.Sprintf("tf-test-%s", rName)),
+					resource.TestCheckResourceAttr(resourceName, "incident_preference", "PER_POLICY"),
+					resource.TestCheckResourceAttr(resourceName, "account_id", strconv.Itoa(api.AccountID)),
+				),
+			},
+			// Test: Update
+			{
+				Config: api.config(testAccNewRelicAlertPolicyConfigUpdated(rName)),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "name", fmt.Sprintf("tf-test-updated-%s", rName)),
+					resource.TestCheckResourceAttr(resourceName, "incident_preference", "PER_CONDITION"),
+				),
+			},
+			// Test: Attach channels
+			{
+				Config: api.config(testAccNewRelicAccAlertPolicyConfigWithChannels(rName)),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "channel_ids.#", "2"),
+				),
+			},
+			// Test: Import
+			{
+				Config:            api.config(testAccNewRelicAccAlertPolicyConfigWithChannels(rName)),
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+				// Channels are attached through the policy channel API and are
+				// not read back.
+				ImportStateVerifyIgnore: []string{"channel_ids"},
+			},
+		},
+	})
+}
@@ -98,7 +98,7 @@ func resourceNewrelicAPIAccessKeyImport(d *schema.ResourceData, meta interface{}
 }
 
 func resourceNewRelicAPIAccessKeyCreate(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*ProviderConfig).NewClient
+	client := accountClient(meta.(*ProviderConfig), d)
 
 	// Define initial keys to create an API access key.
 	opts := apiaccess.APIAccessCreateInput{}
@@ -162,11 +162,11 @@ func resourceNewRelicAPIAccessKeyCreate(d *schema.ResourceData, meta interface{}
 	// Set the resource ID to be a composite of the key ID and the key type in order to lookup the newly created key
 	d.SetId(keys[0].ID)
 
-	return resourceNewRelicAPIAccessKeyRead(d, meta)
+	return readAfterWrite(resourceNewRelicAPIAccessKeyRead, d, meta)
 }
 
 func resourceNewRelicAPIAccessKeyRead(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*ProviderConfig).NewClient
+	client := accountClient(meta.(*ProviderConfig), d)
 
 	key, readErr := client.APIAccess.GetAPIAccessKey(d.Id(), apiaccess.APIAccessKeyType(getAPIAccessKeyType(d)))
 	if readErr != nil {
@@ -213,7 +213,7 @@ func resourceNewRelicAPIAccessKeyRead(d *schema.ResourceData, meta interface{})
 }
 
 func resourceNewRelicAPIAccessKeyUpdate(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*ProviderConfig).NewClient
+	client := accountClient(meta.(*ProviderConfig), d)
 
 	opts := apiaccess.APIAccessUpdateInput{}
 
@@ -262,11 +262,11 @@ func resourceNewRelicAPIAccessKeyUpdate(d *schema.ResourceData, meta interface{}
 		return fmt.Errorf("expected 1 key, got %d", len(keys))
 	}
 
-	return resourceNewRelicAPIAccessKeyRead(d, meta)
+	return readAfterWrite(resourceNewRelicAPIAccessKeyRead, d, meta)
 }
 
 func resourceNewRelicAPIAccessKeyDelete(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*ProviderConfig).NewClient
+	client := accountClient(meta.(*ProviderConfig), d)
 
 	opts := apiaccess.APIAccessDeleteInput{}
 
@@ -4,7 +4,6 @@ import (
 	"fmt"
 	"log"
 	"strconv"
-	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	"github.com/newrelic/newrelic-client-go/pkg/apm"
@@ -20,6 +19,13 @@ func resourceNewRelicApplicationSettings() *schema.Resource {
 			State: schema.ImportStatePassthrough,
 		},
 		Schema: map[string]*schema.Schema{
+			"account_id": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "The New Relic account ID to operate on. Accounts other than the one of the provider must be configured in an account block of the provider.",
+			},
 			"name": {
 				Type:     schema.TypeString,
 				Required: true,
@@ -41,7 +47,10 @@ func resourceNewRelicApplicationSettings() *schema.Resource {
 }
 
 func resourceNewRelicApplicationSettingsCreate(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*ProviderConfig).NewClient
+	client, err := restAccountClient(meta.(*ProviderConfig), d)
+	if err != nil {
+		return err
+	}
 
 	userApp := expandApplication(d)
 
@@ -66,12 +75,19 @@ func resourceNewRelicApplicationSettingsCreate(d *schema.ResourceData, meta inte
 
 	d.SetId(strconv.Itoa(app.ID))
 
+	if err := d.Set("account_id", selectAccountID(meta.(*ProviderConfig), d)); err != nil {
+		return err
+	}
+
 	log.Printf("[INFO] Importing New Relic application %v", userApp.Name)
 	return resourceNewRelicApplicationSettingsUpdate(d, meta)
 }
 
 func resourceNewRelicApplicationSettingsRead(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*ProviderConfig).NewClient
+	client, err := restAccountClient(meta.(*ProviderConfig), d)
+	if err != nil {
+		return err
+	}
 
 	userApp := expandApplication(d)
 	log.Printf("[INFO] Reading New Relic application %+v", userApp)
@@ -83,11 +99,18 @@ func resourceNewRelicApplicationSettingsRead(d *schema.ResourceData, meta interf
 
 	log.Printf("[INFO] Read found New Relic application %+v\n\n\n", app)
 
+	if err := d.Set("account_id", selectAccountID(meta.(*ProviderConfig), d)); err != nil {
+		return err
+	}
+
 	return flattenApplication(app, d)
 }
 
 func resourceNewRelicApplicationSettingsUpdate(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*ProviderConfig).NewClient
+	client, err := restAccountClient(meta.(*ProviderConfig), d)
+	if err != nil {
+		return err
+	}
 
 	userApp := expandApplication(d)
 
@@ -98,14 +121,11 @@ func resourceNewRelicApplicationSettingsUpdate(d *schema.ResourceData, meta inte
 
 	log.Printf("[INFO] Updating New Relic application %+v with params: %+v", userApp, updateParams)
 
-	app, err := client.APM.UpdateApplication(userApp.ID, updateParams)
-	if err != nil {
+	if _, err := client.APM.UpdateApplication(userApp.ID, updateParams); err != nil {
 		return err
 	}
 
-	time.Sleep(2 * time.Second)
-
-	return flattenApplication(app, d)
+	return readAfterWrite(resourceNewRelicApplicationSettingsRead, d, meta)
 }
 
 func resourceNewRelicApplicationSettingsDelete(d *schema.ResourceData, meta interface{}) error {
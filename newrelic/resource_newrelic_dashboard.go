@@ -445,7 +445,7 @@ func widgetSchemaElem() *schema.Resource {
 }
 
 func resourceNewRelicDashboardCreate(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*ProviderConfig).NewClient
+	client := accountClient(meta.(*ProviderConfig), d)
 	dashboard, err := expandDashboard(d)
 	if err != nil {
 		return err
@@ -460,11 +460,11 @@ func resourceNewRelicDashboardCreate(d *schema.ResourceData, meta interface{}) e
 
 	d.SetId(strconv.Itoa(dashboard.ID))
 
-	return resourceNewRelicDashboardRead(d, meta)
+	return readAfterWrite(resourceNewRelicDashboardRead, d, meta)
 }
 
 func resourceNewRelicDashboardRead(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*ProviderConfig).NewClient
+	client := accountClient(meta.(*ProviderConfig), d)
 
 	log.Printf("[INFO] Reading New Relic dashboard %s", d.Id())
 
@@ -487,7 +487,7 @@ func resourceNewRelicDashboardRead(d *schema.ResourceData, meta interface{}) err
 }
 
 func resourceNewRelicDashboardUpdate(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*ProviderConfig).NewClient
+	client := accountClient(meta.(*ProviderConfig), d)
 	dashboard, err := expandDashboard(d)
 	if err != nil {
 		return err
@@ -506,11 +506,11 @@ func resourceNewRelicDashboardUpdate(d *schema.ResourceData, meta interface{}) e
 		return err
 	}
 
-	return resourceNewRelicDashboardRead(d, meta)
+	return readAfterWrite(resourceNewRelicDashboardRead, d, meta)
 }
 
 func resourceNewRelicDashboardDelete(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*ProviderConfig).NewClient
+	client := accountClient(meta.(*ProviderConfig), d)
 
 	id, err := strconv.Atoi(d.Id())
 	if err != nil {
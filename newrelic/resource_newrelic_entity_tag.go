@@ -0,0 +1,185 @@
+package newrelic
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/newrelic/newrelic-client-go/pkg/entities"
+	nrErrors "github.com/newrelic/newrelic-client-go/pkg/errors"
+)
+
+func resourceNewRelicEntityTag() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceNewRelicEntityTagCreate,
+		Read:   resourceNewRelicEntityTagRead,
+		Update: resourceNewRelicEntityTagUpdate,
+		Delete: resourceNewRelicEntityTagDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+		Schema: map[string]*schema.Schema{
+			"guid": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The guid of the entity to tag.",
+			},
+			"key": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				Description:  "The tag key. The other tag keys of the entity are left untouched.",
+				ValidateFunc: validateEntityTagKey,
+			},
+			"values": {
+				Type:        schema.TypeSet,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				MinItems:    1,
+				Required:    true,
+				Description: "The tag values.",
+			},
+		},
+	}
+}
+
+func validateEntityTagKey(v interface{}, k string) (ws []string, es []error) {
+	if isDefaultEntityTag(v.(string)) {
+		es = append(es, fmt.Errorf("%s: the tag key %s is managed by New Relic and can not be changed", k, v))
+	}
+
+	return
+}
+
+func resourceNewRelicEntityTagCreate(d *schema.ResourceData, meta interface{}) error {
+	providerConfig := meta.(*ProviderConfig)
+
+	if !providerConfig.hasNerdGraphCredentials() {
+		return errors.New("err: NerdGraph support not present, but required for Create")
+	}
+
+	client := entityClient(providerConfig, d.Get("guid").(string))
+
+	guid := entities.EntityGUID(d.Get("guid").(string))
+	tag := expandEntityTagResource(d)
+
+	log.Printf("[INFO] Creating New Relic entity tag %s for entity guid %s", tag.Key, guid)
+
+	if err := client.Entities.AddTags(guid, []entities.Tag{tag}); err != nil {
+		return err
+	}
+
+	d.SetId(fmt.Sprintf("%s:%s", guid, tag.Key))
+
+	return readAfterWrite(resourceNewRelicEntityTagRead, d, meta)
+}
+
+func resourceNewRelicEntityTagRead(d *schema.ResourceData, meta interface{}) error {
+	providerConfig := meta.(*ProviderConfig)
+
+	if !providerConfig.hasNerdGraphCredentials() {
+		return errors.New("err: NerdGraph support not present, but required for Read")
+	}
+
+	client := entityClient(providerConfig, strings.SplitN(d.Id(), ":", 2)[0])
+
+	guid, key, err := parseCompositeID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[INFO] Reading New Relic entity tag %s for entity guid %s", key, guid)
+
+	tags, err := client.Entities.ListTags(entities.EntityGUID(guid))
+	if err != nil {
+		if _, ok := err.(*nrErrors.NotFound); ok {
+			d.SetId("")
+			return nil
+		}
+
+		return err
+	}
+
+	tag := getTag(tags, key)
+	if tag == nil {
+		log.Printf("[WARN] New Relic entity tag %s for entity guid %s not found, removing from state", key, guid)
+		d.SetId("")
+		return nil
+	}
+
+	if err := d.Set("guid", guid); err != nil {
+		return err
+	}
+
+	if err := d.Set("key", tag.Key); err != nil {
+		return err
+	}
+
+	return d.Set("values", tag.Values)
+}
+
+func resourceNewRelicEntityTagUpdate(d *schema.ResourceData, meta interface{}) error {
+	providerConfig := meta.(*ProviderConfig)
+
+	if !providerConfig.hasNerdGraphCredentials() {
+		return errors.New("err: NerdGraph support not present, but required for Update")
+	}
+
+	client := entityClient(providerConfig, d.Get("guid").(string))
+
+	guid := entities.EntityGUID(d.Get("guid").(string))
+	tag := expandEntityTagResource(d)
+
+	log.Printf("[INFO] Updating New Relic entity tag %s for entity guid %s", tag.Key, guid)
+
+	o, _ := d.GetChange("values")
+	oldTag := entities.Tag{
+		Key:    tag.Key,
+		Values: expandEntityTagValues(o.(*schema.Set).List()),
+	}
+
+	var removed []entities.TagValue
+	for _, v := range getTagValues([]entities.Tag{oldTag}) {
+		if !stringInSlice(tag.Values, v.Value) {
+			removed = append(removed, v)
+		}
+	}
+
+	if len(removed) > 0 {
+		if err := client.Entities.DeleteTagValues(guid, removed); err != nil {
+			return err
+		}
+	}
+
+	if err := client.Entities.AddTags(guid, []entities.Tag{tag}); err != nil {
+		return err
+	}
+
+	return readAfterWrite(resourceNewRelicEntityTagRead, d, meta)
+}
+
+func resourceNewRelicEntityTagDelete(d *schema.ResourceData, meta interface{}) error {
+	providerConfig := meta.(*ProviderConfig)
+
+	if !providerConfig.hasNerdGraphCredentials() {
+		return errors.New("err: NerdGraph support not present, but required for Delete")
+	}
+
+	client := entityClient(providerConfig, d.Get("guid").(string))
+
+	guid := entities.EntityGUID(d.Get("guid").(string))
+	key := d.Get("key").(string)
+
+	log.Printf("[INFO] Deleting New Relic entity tag %s from entity guid %s", key, guid)
+
+	return client.Entities.DeleteTags(guid, []string{key})
+}
+
+func expandEntityTagResource(d *schema.ResourceData) entities.Tag {
+	return entities.Tag{
+		Key:    d.Get("key").(string),
+		Values: expandEntityTagValues(d.Get("values").(*schema.Set).List()),
+	}
+}
@@ -0,0 +1,84 @@
+// +build integration
+
+package newrelic
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+	"github.com/newrelic/newrelic-client-go/pkg/entities"
+)
+
+func TestAccNewRelicEntityTag_Basic(t *testing.T) {
+	resourceName := "newrelic_entity_tag.foo"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckNewRelicEntityTagDestroy,
+		Steps: []resource.TestStep{
+			// Test: Create
+			{
+				Config: testAccNewRelicEntityTagConfig(testAccExpectedApplicationName, "test_value"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "values.#", "1"),
+				),
+			},
+			// Test: Update
+			{
+				Config: testAccNewRelicEntityTagConfig(testAccExpectedApplicationName, "test_value_2"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "values.#", "1"),
+				),
+			},
+			// Test: Import
+			{
+				ImportState:       true,
+				ImportStateVerify: true,
+				ResourceName:      resourceName,
+			},
+		},
+	})
+}
+
+func testAccCheckNewRelicEntityTagDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*ProviderConfig).NewClient
+	for _, r := range s.RootModule().Resources {
+		if r.Type != "newrelic_entity_tag" {
+			continue
+		}
+
+		guid, key, err := parseCompositeID(r.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		tags, err := client.Entities.ListTags(entities.EntityGUID(guid))
+		if err != nil {
+			return err
+		}
+
+		if tag := getTag(tags, key); tag != nil {
+			return fmt.Errorf("entity tag %s still exists for GUID %s", key, guid)
+		}
+	}
+	return nil
+}
+
+func testAccNewRelicEntityTagConfig(appName string, value string) string {
+	return fmt.Sprintf(`
+data "newrelic_entity" "foo" {
+  name = "%s"
+  type = "APPLICATION"
+  domain = "APM"
+}
+
+resource "newrelic_entity_tag" "foo" {
+  guid   = data.newrelic_entity.foo.guid
+  key    = "test_tag_key"
+  values = ["%s"]
+}
+`, appName, value)
+}
@@ -0,0 +1,85 @@
+// +build unit
+
+package newrelic
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+)
+
+func TestAccNewRelicEntityTag_Offline(t *testing.T) {
+	api := newFakeNewRelicAPI(t)
+	resourceName := "newrelic_entity_tag.foo"
+	appName := fmt.Sprintf("tf-test-app-%s", acctest.RandString(5))
+	_, guid := api.seedApplication(appName)
+
+	// A tag added to the entity by another team.
+	api.update(fakeKindEntity, guid, func(entity map[string]interface{}) {
+		entity["tags"] = append(entity["tags"].([]interface{}),
+			map[string]interface{}{"key": "owner", "values": []interface{}{"platform"}},
+		)
+	})
+
+	resource.ParallelTest(t, resource.TestCase{
+		IsUnitTest: true,
+		Providers:  api.providers(),
+		CheckDestroy: resource.ComposeTestCheckFunc(
+			testAccCheckNewRelicEntityUnitTag(api, guid, "owner", "platform"),
+			testAccCheckNewRelicEntityUnitTag(api, guid, "team"),
+		),
+		Steps: []resource.TestStep{
+			// Test: Tag keys managed by New Relic
+			{
+				Config:      api.config(testAccNewRelicEntityTagUnitConfig(appName, "accountId", `["1"]`)),
+				ExpectError: regexp.MustCompile("the tag key accountId is managed by New Relic"),
+			},
+			// Test: Create
+			{
+				Config: api.config(testAccNewRelicEntityTagUnitConfig(appName, "team", `["checkout"]`)),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "guid", guid),
+					resource.TestCheckResourceAttr(resourceName, "id", guid+":team"),
+					resource.TestCheckResourceAttr(resourceName, "values.#", "1"),
+					testAccCheckNewRelicEntityUnitTag(api, guid, "owner", "platform"),
+					testAccCheckNewRelicEntityUnitTag(api, guid, "team", "checkout"),
+				),
+			},
+			// Test: Update
+			{
+				Config: api.config(testAccNewRelicEntityTagUnitConfig(appName, "team", `["payments", "billing"]`)),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "values.#", "2"),
+					testAccCheckNewRelicEntityUnitTag(api, guid, "owner", "platform"),
+					testAccCheckNewRelicEntityUnitTag(api, guid, "team", "payments", "billing"),
+				),
+			},
+			// Test: Import
+			{
+				Config:            api.config(testAccNewRelicEntityTagUnitConfig(appName, "team", `["payments", "billing"]`)),
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccNewRelicEntityTagUnitConfig(appName string, key string, values string) string {
+	return fmt.Sprintf(`
+data "newrelic_entity" "foo" {
+	name   = "%[1]s"
+	type   = "APPLICATION"
+	domain = "APM"
+}
+
+resource "newrelic_entity_tag" "foo" {
+	guid   = data.newrelic_entity.foo.guid
+	key    = "%[2]s"
+	values = %[3]s
+}
+`, appName, key, values)
+}
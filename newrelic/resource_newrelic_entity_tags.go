@@ -2,25 +2,32 @@ package newrelic
 
 import (
 	"errors"
-	"fmt"
 	"log"
-	"time"
 
-	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	nr "github.com/newrelic/newrelic-client-go/newrelic"
 	"github.com/newrelic/newrelic-client-go/pkg/entities"
 	nrErrors "github.com/newrelic/newrelic-client-go/pkg/errors"
 )
 
 var (
+	// defaultTags are the tag keys New Relic manages on an entity's behalf,
+	// which are never read into or written from the configuration.
 	defaultTags = []string{
 		"account",
 		"accountId",
+		"guid",
 		"language",
 		"trustedAccountId",
 	}
 )
 
+const (
+	entityTagsModeAuthoritative = "authoritative"
+	entityTagsModeAdditive      = "additive"
+)
+
 func resourceNewRelicEntityTags() *schema.Resource {
 	return &schema.Resource{
 		Create: resourceNewRelicEntityTagsCreate,
@@ -28,7 +35,7 @@ func resourceNewRelicEntityTags() *schema.Resource {
 		Update: resourceNewRelicEntityTagsUpdate,
 		Delete: resourceNewRelicEntityTagsDelete,
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			State: importEntityTags,
 		},
 		Schema: map[string]*schema.Schema{
 			"guid": {
@@ -36,6 +43,13 @@ func resourceNewRelicEntityTags() *schema.Resource {
 				Required:    true,
 				Description: "The guid of the entity to tag.",
 			},
+			"mode": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      entityTagsModeAuthoritative,
+				Description:  "How the tags of the entity are managed. With authoritative, the declared tags replace all the mutable tags of the entity. With additive, only the declared keys and values are managed, and the other tags are left untouched.",
+				ValidateFunc: validation.StringInSlice([]string{entityTagsModeAuthoritative, entityTagsModeAdditive}, false),
+			},
 			"tag": {
 				Type:        schema.TypeSet,
 				MinItems:    1,
@@ -59,12 +73,19 @@ func resourceNewRelicEntityTags() *schema.Resource {
 				},
 			},
 		},
-		Timeouts: &schema.ResourceTimeout{
-			Create: schema.DefaultTimeout(10 * time.Second),
-		},
 	}
 }
 
+// importEntityTags imports all of the mutable tags of an entity, as the
+// authoritative mode manages them.
+func importEntityTags(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	if err := d.Set("mode", entityTagsModeAuthoritative); err != nil {
+		return nil, err
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
+
 func resourceNewRelicEntityTagsCreate(d *schema.ResourceData, meta interface{}) error {
 	providerConfig := meta.(*ProviderConfig)
 
@@ -72,38 +93,25 @@ func resourceNewRelicEntityTagsCreate(d *schema.ResourceData, meta interface{})
 		return errors.New("err: NerdGraph support not present, but required for Create")
 	}
 
-	client := providerConfig.NewClient
+	client := entityClient(providerConfig, d.Get("guid").(string))
 
 	guid := entities.EntityGUID(d.Get("guid").(string))
 	tags := expandEntityTags(d.Get("tag").(*schema.Set).List())
 
-	err := client.Entities.AddTags(guid, tags)
+	var err error
+	if d.Get("mode").(string) == entityTagsModeAdditive {
+		err = client.Entities.AddTags(guid, tags)
+	} else {
+		err = client.Entities.ReplaceTags(guid, tags)
+	}
+
 	if err != nil {
 		return err
 	}
 
 	d.SetId(string(guid))
 
-	return resource.Retry(d.Timeout(schema.TimeoutCreate), func() *resource.RetryError {
-		currentTags, err := client.Entities.ListTags(guid)
-
-		if err != nil {
-			return resource.NonRetryableError(fmt.Errorf("error retrieving entity tags for guid %s: %s", d.Id(), err))
-		}
-
-		for _, t := range tags {
-			var tag *entities.Tag
-			if tag = getTag(currentTags, t.Key); tag == nil {
-				return resource.RetryableError(fmt.Errorf("expected entity tag %s to have been updated but was not found", t.Key))
-			}
-
-			if ok := tagValuesExist(tag, t.Values); !ok {
-				return resource.RetryableError(fmt.Errorf("expected entity tag values %s to have been updated for tag %s but were not found", t.Values, t.Key))
-			}
-		}
-
-		return resource.NonRetryableError(resourceNewRelicEntityTagsRead(d, meta))
-	})
+	return readAfterWrite(readEntityTagsAfterWrite(tags), d, meta)
 }
 
 func resourceNewRelicEntityTagsRead(d *schema.ResourceData, meta interface{}) error {
@@ -113,7 +121,7 @@ func resourceNewRelicEntityTagsRead(d *schema.ResourceData, meta interface{}) er
 		return errors.New("err: NerdGraph support not present, but required for Read")
 	}
 
-	client := providerConfig.NewClient
+	client := entityClient(providerConfig, d.Id())
 
 	log.Printf("[INFO] Reading New Relic entity tags for entity guid %s", d.Id())
 
@@ -128,6 +136,10 @@ func resourceNewRelicEntityTagsRead(d *schema.ResourceData, meta interface{}) er
 		return err
 	}
 
+	if d.Get("mode").(string) == entityTagsModeAdditive {
+		tags = filterManagedEntityTags(tags, expandEntityTags(d.Get("tag").(*schema.Set).List()))
+	}
+
 	return flattenEntityTags(d, tags)
 }
 
@@ -138,36 +150,66 @@ func resourceNewRelicEntityTagsUpdate(d *schema.ResourceData, meta interface{})
 		return errors.New("err: NerdGraph support not present, but required for Update")
 	}
 
-	client := providerConfig.NewClient
+	client := entityClient(providerConfig, d.Id())
 
 	log.Printf("[INFO] Updating New Relic entity tags for entity guid %s", d.Id())
 
 	tags := expandEntityTags(d.Get("tag").(*schema.Set).List())
 
-	if err := client.Entities.ReplaceTags(entities.EntityGUID(d.Id()), tags); err != nil {
+	if d.Get("mode").(string) == entityTagsModeAdditive {
+		o, _ := d.GetChange("tag")
+		oldTags := expandEntityTags(o.(*schema.Set).List())
+
+		if err := deleteUnmanagedEntityTags(client, entities.EntityGUID(d.Id()), oldTags, tags); err != nil {
+			return err
+		}
+
+		if err := client.Entities.AddTags(entities.EntityGUID(d.Id()), tags); err != nil {
+			return err
+		}
+	} else if err := client.Entities.ReplaceTags(entities.EntityGUID(d.Id()), tags); err != nil {
 		return err
 	}
 
-	return resource.Retry(d.Timeout(schema.TimeoutCreate), func() *resource.RetryError {
-		currentTags, err := client.Entities.ListTags(entities.EntityGUID(d.Id()))
+	return readAfterWrite(readEntityTagsAfterWrite(tags), d, meta)
+}
 
-		if err != nil {
-			return resource.NonRetryableError(fmt.Errorf("error retrieving entity tags for guid %s: %s", d.Id(), err))
+// readEntityTagsAfterWrite returns a read of the entity's tags which reports
+// the written tags as not found until all of their values show up, as tags
+// take a moment to be indexed after a write.
+func readEntityTagsAfterWrite(written []entities.Tag) schema.ReadFunc {
+	return func(d *schema.ResourceData, meta interface{}) error {
+		// A previous attempt may have read the tags without the values not
+		// indexed yet, which the additive mode would then no longer read.
+		tags := make([]*entities.Tag, len(written))
+		for i := range written {
+			tags[i] = &written[i]
 		}
 
-		for _, t := range tags {
-			var tag *entities.Tag
-			if tag = getTag(currentTags, t.Key); tag == nil {
-				return resource.RetryableError(fmt.Errorf("expected entity tag %s to have been updated but was not found", t.Key))
+		if err := flattenEntityTags(d, tags); err != nil {
+			return err
+		}
+
+		if err := resourceNewRelicEntityTagsRead(d, meta); err != nil || d.Id() == "" {
+			return err
+		}
+
+		current := expandEntityTags(d.Get("tag").(*schema.Set).List())
+		for _, t := range written {
+			found := false
+			for i := range current {
+				if current[i].Key == t.Key && tagValuesExist(&current[i], t.Values) {
+					found = true
+				}
 			}
 
-			if ok := tagValuesExist(tag, t.Values); !ok {
-				return resource.RetryableError(fmt.Errorf("expected entity tag values %s to have been created for tag %s but were not found", t.Values, t.Key))
+			if !found {
+				return errNotFoundAfterWrite
 			}
 		}
 
-		return resource.NonRetryableError(resourceNewRelicEntityTagsRead(d, meta))
-	})
+		return nil
+	}
 }
 
 func resourceNewRelicEntityTagsDelete(d *schema.ResourceData, meta interface{}) error {
@@ -177,11 +219,16 @@ func resourceNewRelicEntityTagsDelete(d *schema.ResourceData, meta interface{})
 		return errors.New("err: NerdGraph support not present, but required for Delete")
 	}
 
-	client := providerConfig.NewClient
+	client := entityClient(providerConfig, d.Id())
 
 	log.Printf("[INFO] Deleting New Relic entity tags from entity guid %s", d.Id())
 
 	tags := expandEntityTags(d.Get("tag").(*schema.Set).List())
+
+	if d.Get("mode").(string) == entityTagsModeAdditive {
+		return client.Entities.DeleteTagValues(entities.EntityGUID(d.Id()), getTagValues(tags))
+	}
+
 	tagKeys := getTagKeys(tags)
 
 	if err := client.Entities.DeleteTags(entities.EntityGUID(d.Id()), tagKeys); err != nil {
@@ -191,6 +238,61 @@ func resourceNewRelicEntityTagsDelete(d *schema.ResourceData, meta interface{})
 	return nil
 }
 
+// deleteUnmanagedEntityTags deletes the values which were managed before an
+// update but no longer are, leaving the other values of their keys untouched.
+func deleteUnmanagedEntityTags(client *nr.NewRelic, guid entities.EntityGUID, oldTags []entities.Tag, newTags []entities.Tag) error {
+	var values []entities.TagValue
+
+	managed := map[string][]string{}
+	for _, n := range newTags {
+		managed[n.Key] = n.Values
+	}
+
+	for _, o := range oldTags {
+		for _, v := range o.Values {
+			if !stringInSlice(managed[o.Key], v) {
+				values = append(values, entities.TagValue{Key: o.Key, Value: v})
+			}
+		}
+	}
+
+	if len(values) == 0 {
+		return nil
+	}
+
+	return client.Entities.DeleteTagValues(guid, values)
+}
+
+// filterManagedEntityTags returns the keys and values of the tags which are
+// managed, or all of the tags when none is managed yet, as when importing.
+func filterManagedEntityTags(tags []*entities.Tag, managed []entities.Tag) []*entities.Tag {
+	if len(managed) == 0 {
+		return tags
+	}
+
+	out := []*entities.Tag{}
+
+	for _, m := range managed {
+		t := getTag(tags, m.Key)
+		if t == nil {
+			continue
+		}
+
+		tag := &entities.Tag{Key: t.Key}
+		for _, v := range t.Values {
+			if stringInSlice(m.Values, v) {
+				tag.Values = append(tag.Values, v)
+			}
+		}
+
+		if len(tag.Values) > 0 {
+			out = append(out, tag)
+		}
+	}
+
+	return out
+}
+
 func expandEntityTags(tags []interface{}) []entities.Tag {
 	out := make([]entities.Tag, len(tags))
 
@@ -220,7 +322,7 @@ func expandEntityTagValues(values []interface{}) []string {
 func flattenEntityTags(d *schema.ResourceData, tags []*entities.Tag) error {
 	out := []map[string]interface{}{}
 	for _, t := range tags {
-		if stringInSlice(defaultTags, t.Key) {
+		if isDefaultEntityTag(t.Key) {
 			continue
 		}
 
@@ -242,6 +344,11 @@ func flattenEntityTags(d *schema.ResourceData, tags []*entities.Tag) error {
 	return nil
 }
 
+// isDefaultEntityTag reports whether a tag key is managed by New Relic.
+func isDefaultEntityTag(key string) bool {
+	return stringInSlice(defaultTags, key)
+}
+
 func getTagKeys(tags []entities.Tag) []string {
 	tagKeys := []string{}
 
@@ -251,6 +358,18 @@ func getTagKeys(tags []entities.Tag) []string {
 	return tagKeys
 }
 
+func getTagValues(tags []entities.Tag) []entities.TagValue {
+	tagValues := []entities.TagValue{}
+
+	for _, t := range tags {
+		for _, v := range t.Values {
+			tagValues = append(tagValues, entities.TagValue{Key: t.Key, Value: v})
+		}
+	}
+
+	return tagValues
+}
+
 func tagValuesExist(t *entities.Tag, values []string) bool {
 	for _, v := range values {
 		if !stringInSlice(t.Values, v) {
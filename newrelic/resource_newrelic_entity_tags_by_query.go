@@ -0,0 +1,261 @@
+package newrelic
+
+import (
+	"errors"
+	"log"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/hashcode"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/newrelic/newrelic-client-go/pkg/entities"
+)
+
+// The tagging mutations apply to one entity at a time, so entities are tagged
+// in batches with a pause in between to stay clear of the NerdGraph rate
+// limits.
+const (
+	entityTagsByQueryBatchSize     = 25
+	entityTagsByQueryBatchInterval = time.Second
+)
+
+func resourceNewRelicEntityTagsByQuery() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceNewRelicEntityTagsByQueryCreate,
+		Read:   resourceNewRelicEntityTagsByQueryRead,
+		Update: resourceNewRelicEntityTagsByQueryUpdate,
+		Delete: resourceNewRelicEntityTagsByQueryDelete,
+		Schema: map[string]*schema.Schema{
+			"query": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The entity search query matching the entities to tag.",
+			},
+			"tag": {
+				Type:        schema.TypeSet,
+				MinItems:    1,
+				Required:    true,
+				Description: "A set of key-value pairs to represent a tag. For example: Team:TeamName",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"key": {
+							Type:         schema.TypeString,
+							Required:     true,
+							Description:  "The tag key.",
+							ValidateFunc: validateEntityTagKey,
+						},
+						"values": {
+							Type:        schema.TypeSet,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+							MinItems:    1,
+							Required:    true,
+							Description: "The tag values.",
+						},
+					},
+				},
+			},
+			"guids": {
+				Type:        schema.TypeSet,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "The guids of the entities matched by the query.",
+			},
+		},
+	}
+}
+
+func resourceNewRelicEntityTagsByQueryCreate(d *schema.ResourceData, meta interface{}) error {
+	providerConfig := meta.(*ProviderConfig)
+
+	if !providerConfig.hasNerdGraphCredentials() {
+		return errors.New("err: NerdGraph support not present, but required for Create")
+	}
+
+	client := accountClient(providerConfig, d)
+
+	query := d.Get("query").(string)
+	tags := expandEntityTags(d.Get("tag").(*schema.Set).List())
+
+	log.Printf("[INFO] Tagging New Relic entities matching %s", query)
+
+	matched, err := searchEntities(client, query)
+	if err != nil {
+		return err
+	}
+
+	err = forEachEntityBatch(entitiesMissingTags(matched, tags), func(guid entities.EntityGUID) error {
+		return entityClient(providerConfig, string(guid)).Entities.AddTags(guid, tags)
+	})
+	if err != nil {
+		return err
+	}
+
+	d.SetId(strconv.Itoa(hashcode.String(query)))
+
+	return readAfterWrite(resourceNewRelicEntityTagsByQueryRead, d, meta)
+}
+
+func resourceNewRelicEntityTagsByQueryRead(d *schema.ResourceData, meta interface{}) error {
+	providerConfig := meta.(*ProviderConfig)
+
+	if !providerConfig.hasNerdGraphCredentials() {
+		return errors.New("err: NerdGraph support not present, but required for Read")
+	}
+
+	client := accountClient(providerConfig, d)
+
+	query := d.Get("query").(string)
+
+	log.Printf("[INFO] Reading New Relic entity tags of the entities matching %s", query)
+
+	matched, err := searchEntities(client, query)
+	if err != nil {
+		return err
+	}
+
+	tags := expandEntityTags(d.Get("tag").(*schema.Set).List())
+
+	return flattenEntityTagsByQuery(d, matched, tags)
+}
+
+func resourceNewRelicEntityTagsByQueryUpdate(d *schema.ResourceData, meta interface{}) error {
+	providerConfig := meta.(*ProviderConfig)
+
+	if !providerConfig.hasNerdGraphCredentials() {
+		return errors.New("err: NerdGraph support not present, but required for Update")
+	}
+
+	client := accountClient(providerConfig, d)
+
+	query := d.Get("query").(string)
+	tags := expandEntityTags(d.Get("tag").(*schema.Set).List())
+
+	log.Printf("[INFO] Updating New Relic entity tags of the entities matching %s", query)
+
+	matched, err := searchEntities(client, query)
+	if err != nil {
+		return err
+	}
+
+	if d.HasChange("tag") {
+		o, _ := d.GetChange("tag")
+		oldTags := expandEntityTags(o.(*schema.Set).List())
+
+		err = forEachEntityBatch(entityOutlineGUIDs(matched), func(guid entities.EntityGUID) error {
+			return deleteUnmanagedEntityTags(entityClient(providerConfig, string(guid)), guid, oldTags, tags)
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	err = forEachEntityBatch(entitiesMissingTags(matched, tags), func(guid entities.EntityGUID) error {
+		return entityClient(providerConfig, string(guid)).Entities.AddTags(guid, tags)
+	})
+	if err != nil {
+		return err
+	}
+
+	return readAfterWrite(resourceNewRelicEntityTagsByQueryRead, d, meta)
+}
+
+func resourceNewRelicEntityTagsByQueryDelete(d *schema.ResourceData, meta interface{}) error {
+	providerConfig := meta.(*ProviderConfig)
+
+	if !providerConfig.hasNerdGraphCredentials() {
+		return errors.New("err: NerdGraph support not present, but required for Delete")
+	}
+
+	client := accountClient(providerConfig, d)
+
+	query := d.Get("query").(string)
+	tagValues := getTagValues(expandEntityTags(d.Get("tag").(*schema.Set).List()))
+
+	log.Printf("[INFO] Deleting New Relic entity tags from the entities matching %s", query)
+
+	matched, err := searchEntities(client, query)
+	if err != nil {
+		return err
+	}
+
+	return forEachEntityBatch(entityOutlineGUIDs(matched), func(guid entities.EntityGUID) error {
+		return entityClient(providerConfig, string(guid)).Entities.DeleteTagValues(guid, tagValues)
+	})
+}
+
+// flattenEntityTagsByQuery sets the tags carried by every matched entity, so
+// a plan reports the tags some entities gained or lost as a difference.
+func flattenEntityTagsByQuery(d *schema.ResourceData, matched []entityOutline, tags []entities.Tag) error {
+	out := []map[string]interface{}{}
+
+	for _, t := range tags {
+		var values []string
+		for _, v := range t.Values {
+			if len(entitiesMissingTags(matched, []entities.Tag{{Key: t.Key, Values: []string{v}}})) == 0 {
+				values = append(values, v)
+			}
+		}
+
+		if len(values) > 0 {
+			out = append(out, map[string]interface{}{
+				"key":    t.Key,
+				"values": values,
+			})
+		}
+	}
+
+	if err := d.Set("tag", out); err != nil {
+		return err
+	}
+
+	return d.Set("guids", entityOutlineGUIDs(matched))
+}
+
+// entitiesMissingTags returns the guids of the entities which do not carry
+// all of the tags.
+func entitiesMissingTags(found []entityOutline, tags []entities.Tag) []string {
+	var guids []string
+
+	for _, e := range found {
+		current := make([]*entities.Tag, len(e.Tags))
+		for i, t := range e.Tags {
+			current[i] = &entities.Tag{Key: t.Key, Values: t.Values}
+		}
+
+		for _, t := range tags {
+			if tag := getTag(current, t.Key); tag == nil || !tagValuesExist(tag, t.Values) {
+				guids = append(guids, e.GUID)
+				break
+			}
+		}
+	}
+
+	return guids
+}
+
+func entityOutlineGUIDs(found []entityOutline) []string {
+	guids := make([]string, len(found))
+
+	for i, e := range found {
+		guids[i] = e.GUID
+	}
+
+	return guids
+}
+
+// forEachEntityBatch calls fn for each entity, pausing between each batch of
+// entityTagsByQueryBatchSize entities.
+func forEachEntityBatch(guids []string, fn func(guid entities.EntityGUID) error) error {
+	for i, guid := range guids {
+		if i > 0 && i%entityTagsByQueryBatchSize == 0 {
+			time.Sleep(entityTagsByQueryBatchInterval)
+		}
+
+		if err := fn(entities.EntityGUID(guid)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
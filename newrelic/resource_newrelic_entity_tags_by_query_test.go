@@ -0,0 +1,49 @@
+// +build integration
+
+package newrelic
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+)
+
+func TestAccNewRelicEntityTagsByQuery_Basic(t *testing.T) {
+	resourceName := "newrelic_entity_tags_by_query.foo"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			// Test: Create
+			{
+				Config: testAccNewRelicEntityTagsByQueryConfig(testAccExpectedApplicationName, "test_value"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "guids.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "tag.#", "1"),
+				),
+			},
+			// Test: Update
+			{
+				Config: testAccNewRelicEntityTagsByQueryConfig(testAccExpectedApplicationName, "test_value_2"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "tag.#", "1"),
+				),
+			},
+		},
+	})
+}
+
+func testAccNewRelicEntityTagsByQueryConfig(appName string, value string) string {
+	return fmt.Sprintf(`
+resource "newrelic_entity_tags_by_query" "foo" {
+  query = "name = '%s' AND domain = 'APM' AND type = 'APPLICATION'"
+
+  tag {
+	key = "test_by_query_key"
+	values = ["%s"]
+  }
+}
+`, appName, value)
+}
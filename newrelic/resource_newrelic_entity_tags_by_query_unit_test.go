@@ -0,0 +1,134 @@
+// +build unit
+
+package newrelic
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+)
+
+func TestAccNewRelicEntityTagsByQuery_Offline(t *testing.T) {
+	api := newFakeNewRelicAPI(t)
+	resourceName := "newrelic_entity_tags_by_query.foo"
+	prefix := fmt.Sprintf("tf-test-%s", acctest.RandString(5))
+	_, otherGUID := api.seedApplication(fmt.Sprintf("tf-test-other-%s", acctest.RandString(5)))
+
+	var guids []string
+	for i := 0; i < 3; i++ {
+		_, guid := api.seedApplication(fmt.Sprintf("%s-app-%d", prefix, i))
+		guids = append(guids, guid)
+	}
+
+	// A tag added to one of the entities by another team.
+	api.update(fakeKindEntity, guids[0], func(entity map[string]interface{}) {
+		entity["tags"] = append(entity["tags"].([]interface{}),
+			map[string]interface{}{"key": "owner", "values": []interface{}{"platform"}},
+		)
+	})
+
+	// checkTagged ensures every entity matching the query, and only those,
+	// carry the team tag.
+	checkTagged := func(values ...string) resource.TestCheckFunc {
+		checks := []resource.TestCheckFunc{
+			testAccCheckNewRelicEntityUnitTag(api, otherGUID, "team"),
+			testAccCheckNewRelicEntityUnitTag(api, guids[0], "owner", "platform"),
+		}
+		for _, guid := range guids {
+			checks = append(checks, testAccCheckNewRelicEntityUnitTag(api, guid, "team", values...))
+		}
+
+		return resource.ComposeTestCheckFunc(checks...)
+	}
+
+	resource.ParallelTest(t, resource.TestCase{
+		IsUnitTest:   true,
+		Providers:    api.providers(),
+		CheckDestroy: checkTagged(),
+		Steps: []resource.TestStep{
+			// Test: Create
+			{
+				Config: api.config(testAccNewRelicEntityTagsByQueryUnitConfig(prefix, "checkout")),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "guids.#", "3"),
+					resource.TestCheckResourceAttr(resourceName, "tag.#", "1"),
+					checkTagged("checkout"),
+				),
+			},
+			// Test: Drift of an entity which lost the tags
+			{
+				PreConfig: func() {
+					api.update(fakeKindEntity, guids[1], func(entity map[string]interface{}) {
+						entity["tags"] = entity["tags"].([]interface{})[:2]
+					})
+				},
+				Config:             api.config(testAccNewRelicEntityTagsByQueryUnitConfig(prefix, "checkout")),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			// Test: Tag an entity which started matching the query
+			{
+				PreConfig: func() {
+					_, guid := api.seedApplication(fmt.Sprintf("%s-app-3", prefix))
+					guids = append(guids, guid)
+				},
+				Config: api.config(testAccNewRelicEntityTagsByQueryUnitConfig(prefix, "checkout")),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "guids.#", "4"),
+					checkTagged("checkout"),
+				),
+			},
+			// Test: Update
+			{
+				Config: api.config(testAccNewRelicEntityTagsByQueryUnitConfig(prefix, "payments")),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "guids.#", "4"),
+					checkTagged("payments"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccNewRelicEntityTagsByQuery_OfflineAccount(t *testing.T) {
+	api := newFakeNewRelicAPI(t)
+	prefix := fmt.Sprintf("tf-test-%s", acctest.RandString(5))
+	guid := api.seedSubAccountApplication(fmt.Sprintf("%s-app", prefix))
+
+	resource.ParallelTest(t, resource.TestCase{
+		IsUnitTest:   true,
+		Providers:    api.providers(),
+		CheckDestroy: testAccCheckNewRelicEntityUnitTag(api, guid, "team"),
+		Steps: []resource.TestStep{
+			// Test: Tag an entity of a sub-account with its own key
+			{
+				Config: api.configWithProvider(testAccNewRelicUnitAccountBlock(fakeSubAccountID), testAccNewRelicEntityTagsByQueryUnitConfig(prefix, "checkout")),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckNewRelicEntityUnitTag(api, guid, "team", "checkout"),
+					func(s *terraform.State) error {
+						if api.requestsWithAPIKey("NRAK-FAKE-SUB") == 0 {
+							return fmt.Errorf("expected the sub-account entity to be tagged with the sub-account API key")
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+func testAccNewRelicEntityTagsByQueryUnitConfig(prefix string, team string) string {
+	return fmt.Sprintf(`
+resource "newrelic_entity_tags_by_query" "foo" {
+	query = "name like '%[1]s'"
+
+	tag {
+		key    = "team"
+		values = ["%[2]s"]
+	}
+}
+`, prefix, team)
+}
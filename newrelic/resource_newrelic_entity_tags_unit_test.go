@@ -0,0 +1,244 @@
+// +build unit
+
+package newrelic
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+)
+
+func TestAccNewRelicEntityTags_Offline(t *testing.T) {
+	api := newFakeNewRelicAPI(t)
+	resourceName := "newrelic_entity_tags.foo"
+	appName := fmt.Sprintf("tf-test-app-%s", acctest.RandString(5))
+	_, guid := api.seedApplication(appName)
+
+	resource.ParallelTest(t, resource.TestCase{
+		IsUnitTest:   true,
+		Providers:    api.providers(),
+		CheckDestroy: testAccCheckNewRelicEntityTagsUnitDestroy(api, guid),
+		Steps: []resource.TestStep{
+			// Test: Create
+			{
+				Config: api.config(testAccNewRelicEntityTagsUnitConfig(appName, "test_key", "test_value")),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "guid", guid),
+					resource.TestCheckResourceAttr(resourceName, "tag.#", "1"),
+				),
+			},
+			// Test: Update
+			{
+				Config: api.config(testAccNewRelicEntityTagsUnitConfig(appName, "test_key_2", "test_value_2")),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "tag.#", "1"),
+				),
+			},
+			// Test: Import
+			{
+				Config:            api.config(testAccNewRelicEntityTagsUnitConfig(appName, "test_key_2", "test_value_2")),
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+// testAccCheckNewRelicEntityTagsUnitDestroy ensures only the immutable tags
+// are left on the entity once the resource is gone.
+func testAccCheckNewRelicEntityTagsUnitDestroy(api *fakeNewRelicAPI, guid string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		entity := api.get(fakeKindEntity, guid)
+		if entity == nil {
+			return fmt.Errorf("entity %s no longer exists", guid)
+		}
+
+		for _, t := range entity["tags"].([]interface{}) {
+			key := t.(map[string]interface{})["key"].(string)
+			if !fakeImmutableTagKeys[key] {
+				return fmt.Errorf("tag %s still exists on entity %s", key, guid)
+			}
+		}
+
+		return nil
+	}
+}
+
+func testAccNewRelicEntityTagsUnitConfig(appName string, key string, value string) string {
+	return fmt.Sprintf(`
+data "newrelic_entity" "foo" {
+	name   = "%[1]s"
+	type   = "APPLICATION"
+	domain = "APM"
+}
+
+resource "newrelic_entity_tags" "foo" {
+	guid = data.newrelic_entity.foo.guid
+
+	tag {
+		key    = "%[2]s"
+		values = ["%[3]s"]
+	}
+}
+`, appName, key, value)
+}
+
+func TestAccNewRelicEntityTags_OfflineAdditive(t *testing.T) {
+	api := newFakeNewRelicAPI(t)
+	resourceName := "newrelic_entity_tags.foo"
+	appName := fmt.Sprintf("tf-test-app-%s", acctest.RandString(5))
+	_, guid := api.seedApplication(appName)
+
+	// Tags added to the entity by another team.
+	api.update(fakeKindEntity, guid, func(entity map[string]interface{}) {
+		entity["tags"] = append(entity["tags"].([]interface{}),
+			map[string]interface{}{"key": "owner", "values": []interface{}{"platform"}},
+			map[string]interface{}{"key": "team", "values": []interface{}{"ops"}},
+		)
+	})
+
+	resource.ParallelTest(t, resource.TestCase{
+		IsUnitTest: true,
+		Providers:  api.providers(),
+		CheckDestroy: resource.ComposeTestCheckFunc(
+			testAccCheckNewRelicEntityUnitTag(api, guid, "owner", "platform"),
+			testAccCheckNewRelicEntityUnitTag(api, guid, "team", "ops"),
+			testAccCheckNewRelicEntityUnitTag(api, guid, "env"),
+		),
+		Steps: []resource.TestStep{
+			// Test: Create
+			{
+				Config: api.config(testAccNewRelicEntityTagsAdditiveUnitConfig(appName, `
+	tag {
+		key    = "team"
+		values = ["checkout"]
+	}
+
+	tag {
+		key    = "env"
+		values = ["production"]
+	}
+`)),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "tag.#", "2"),
+					testAccCheckNewRelicEntityUnitTag(api, guid, "owner", "platform"),
+					testAccCheckNewRelicEntityUnitTag(api, guid, "team", "ops", "checkout"),
+					testAccCheckNewRelicEntityUnitTag(api, guid, "env", "production"),
+				),
+			},
+			// Test: Update
+			{
+				Config: api.config(testAccNewRelicEntityTagsAdditiveUnitConfig(appName, `
+	tag {
+		key    = "team"
+		values = ["payments"]
+	}
+`)),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "tag.#", "1"),
+					testAccCheckNewRelicEntityUnitTag(api, guid, "owner", "platform"),
+					testAccCheckNewRelicEntityUnitTag(api, guid, "team", "ops", "payments"),
+					testAccCheckNewRelicEntityUnitTag(api, guid, "env"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccNewRelicEntityTags_OfflineReadAfterWrite(t *testing.T) {
+	api := newFakeNewRelicAPI(t)
+	resourceName := "newrelic_entity_tags.foo"
+	appName := fmt.Sprintf("tf-test-app-%s", acctest.RandString(5))
+	_, guid := api.seedApplication(appName)
+
+	api.update(fakeKindEntity, guid, func(entity map[string]interface{}) {
+		entity["tags"] = append(entity["tags"].([]interface{}),
+			map[string]interface{}{"key": "team", "values": []interface{}{"ops"}},
+		)
+	})
+
+	tags := func(team string) string {
+		return testAccNewRelicEntityTagsAdditiveUnitConfig(appName, fmt.Sprintf(`
+	tag {
+		key    = "team"
+		values = ["%s"]
+	}
+`, team))
+	}
+
+	resource.ParallelTest(t, resource.TestCase{
+		IsUnitTest:   true,
+		Providers:    api.providers(),
+		CheckDestroy: testAccCheckNewRelicEntityUnitTag(api, guid, "team", "ops"),
+		Steps: []resource.TestStep{
+			// Test: Tags not indexed yet are read again
+			{
+				PreConfig: func() { api.delayTagReads(2) },
+				Config:    api.configWithProvider("max_retries = 3\n\tretry_wait_min = 0\n\tretry_wait_max = 0", tags("checkout")),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "tag.#", "1"),
+					testAccCheckNewRelicEntityUnitTag(api, guid, "team", "ops", "checkout"),
+				),
+			},
+			// Test: The retries are bounded by max_retries
+			{
+				Config:      api.configWithProvider("max_retries = 0", tags("payments")),
+				ExpectError: regexp.MustCompile(`written but could not be read back`),
+			},
+		},
+	})
+}
+
+// testAccCheckNewRelicEntityUnitTag ensures a tag of the entity has exactly
+// the given values, or does not exist when none is given.
+func testAccCheckNewRelicEntityUnitTag(api *fakeNewRelicAPI, guid string, key string, values ...string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		entity := api.get(fakeKindEntity, guid)
+		if entity == nil {
+			return fmt.Errorf("entity %s no longer exists", guid)
+		}
+
+		var actual []string
+		for _, t := range entity["tags"].([]interface{}) {
+			tag := t.(map[string]interface{})
+			if tag["key"] != key {
+				continue
+			}
+
+			for _, v := range tag["values"].([]interface{}) {
+				actual = append(actual, v.(string))
+			}
+		}
+
+		sort.Strings(actual)
+		sort.Strings(values)
+
+		if strings.Join(actual, ",") != strings.Join(values, ",") {
+			return fmt.Errorf("expected tag %s of entity %s to have values %v, got %v", key, guid, values, actual)
+		}
+
+		return nil
+	}
+}
+
+func testAccNewRelicEntityTagsAdditiveUnitConfig(appName string, tags string) string {
+	return fmt.Sprintf(`
+data "newrelic_entity" "foo" {
+	name   = "%[1]s"
+	type   = "APPLICATION"
+	domain = "APM"
+}
+
+resource "newrelic_entity_tags" "foo" {
+	guid = data.newrelic_entity.foo.guid
+	mode = "additive"
+%[2]s}
+`, appName, tags)
+}
@@ -67,7 +67,7 @@ func resourceNewRelicEventsToMetricsRuleCreate(d *schema.ResourceData, meta inte
 		return errors.New("err: NerdGraph support not present, but required for Create")
 	}
 
-	client := providerConfig.NewClient
+	client := accountClient(providerConfig, d)
 
 	createInput := []eventstometrics.EventsToMetricsCreateRuleInput{
 		{
@@ -104,7 +104,7 @@ func resourceNewRelicEventsToMetricsRuleCreate(d *schema.ResourceData, meta inte
 		}
 	}
 
-	return resourceNewRelicEventsToMetricsRuleRead(d, meta)
+	return readAfterWrite(resourceNewRelicEventsToMetricsRuleRead, d, meta)
 }
 
 func resourceNewRelicEventsToMetricsRuleRead(d *schema.ResourceData, meta interface{}) error {
@@ -114,7 +114,7 @@ func resourceNewRelicEventsToMetricsRuleRead(d *schema.ResourceData, meta interf
 		return errors.New("err: NerdGraph support not present, but required for Read")
 	}
 
-	client := providerConfig.NewClient
+	client := accountClient(providerConfig, d)
 
 	log.Printf("[INFO] Reading New Relic entity tags for entity guid %s", d.Id())
 
@@ -171,7 +171,7 @@ func resourceNewRelicEventsToMetricsRuleUpdate(d *schema.ResourceData, meta inte
 		return errors.New("err: NerdGraph support not present, but required for Update")
 	}
 
-	client := providerConfig.NewClient
+	client := accountClient(providerConfig, d)
 
 	log.Printf("[INFO] Updating New Relic events to metric rules")
 
@@ -193,7 +193,7 @@ func resourceNewRelicEventsToMetricsRuleUpdate(d *schema.ResourceData, meta inte
 		return err
 	}
 
-	return resourceNewRelicEventsToMetricsRuleRead(d, meta)
+	return readAfterWrite(resourceNewRelicEventsToMetricsRuleRead, d, meta)
 }
 
 func resourceNewRelicEventsToMetricsRuleDelete(d *schema.ResourceData, meta interface{}) error {
@@ -203,7 +203,7 @@ func resourceNewRelicEventsToMetricsRuleDelete(d *schema.ResourceData, meta inte
 		return errors.New("err: NerdGraph support not present, but required for Delete")
 	}
 
-	client := providerConfig.NewClient
+	client := accountClient(providerConfig, d)
 
 	log.Printf("[INFO] Deleting New Relic entity tags from entity guid %s", d.Id())
 
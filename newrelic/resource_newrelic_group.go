@@ -0,0 +1,167 @@
+package newrelic
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/newrelic/newrelic-client-go/pkg/errors"
+)
+
+func resourceNewRelicGroup() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceNewRelicGroupCreate,
+		Read:   resourceNewRelicGroupRead,
+		Update: resourceNewRelicGroupUpdate,
+		Delete: resourceNewRelicGroupDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+		Schema: map[string]*schema.Schema{
+			"authentication_domain_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The ID of the authentication domain the group belongs to.",
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The name of the group.",
+			},
+			"user_ids": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "The IDs of the users in the group. The members of the group are not managed when omitted or empty, unless manage_members is set.",
+			},
+			"manage_members": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether the members of the group are managed even when user_ids is empty, so that an empty user_ids removes all of them.",
+			},
+		},
+	}
+}
+
+func resourceNewRelicGroupCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*ProviderConfig).NewClient
+
+	input := groupCreateInput{
+		AuthenticationDomainID: d.Get("authentication_domain_id").(string),
+		DisplayName:            d.Get("name").(string),
+	}
+
+	log.Printf("[INFO] Creating New Relic group %s", input.DisplayName)
+
+	created, err := createGroup(client, input)
+	if err != nil {
+		return err
+	}
+
+	if created == nil {
+		return fmt.Errorf("no group was returned after creating group %s", input.DisplayName)
+	}
+
+	d.SetId(created.ID)
+
+	if userIDs := expandStringSet(d.Get("user_ids").(*schema.Set)); groupMembersManaged(d) && len(userIDs) > 0 {
+		if err := addUsersToGroup(client, d.Id(), userIDs); err != nil {
+			return err
+		}
+	}
+
+	return readAfterWrite(resourceNewRelicGroupRead, d, meta)
+}
+
+func resourceNewRelicGroupRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*ProviderConfig).NewClient
+
+	log.Printf("[INFO] Reading New Relic group %s", d.Id())
+
+	g, domainID, err := getGroup(client, d.Id())
+	if err != nil {
+		if _, ok := err.(*errors.NotFound); ok {
+			d.SetId("")
+			return nil
+		}
+
+		return err
+	}
+
+	d.Set("authentication_domain_id", domainID)
+	d.Set("name", g.DisplayName)
+
+	if !groupMembersManaged(d) {
+		return nil
+	}
+
+	userIDs := make([]string, len(g.Users.Users))
+	for i, u := range g.Users.Users {
+		userIDs[i] = u.ID
+	}
+
+	return d.Set("user_ids", userIDs)
+}
+
+func resourceNewRelicGroupUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*ProviderConfig).NewClient
+
+	log.Printf("[INFO] Updating New Relic group %s", d.Id())
+
+	if d.HasChange("name") {
+		input := groupUpdateInput{
+			ID:          d.Id(),
+			DisplayName: d.Get("name").(string),
+		}
+
+		if err := updateGroup(client, input); err != nil {
+			return err
+		}
+	}
+
+	if groupMembersManaged(d) && d.HasChanges("user_ids", "manage_members") {
+		// The members are compared with the group's, since the state has none
+		// while the members were not managed.
+		g, _, err := getGroup(client, d.Id())
+		if err != nil {
+			return err
+		}
+
+		userIDs := d.Get("user_ids").(*schema.Set)
+
+		members := schema.NewSet(userIDs.F, nil)
+		for _, u := range g.Users.Users {
+			members.Add(u.ID)
+		}
+
+		if removed := expandStringSet(members.Difference(userIDs)); len(removed) > 0 {
+			if err := removeUsersFromGroup(client, d.Id(), removed); err != nil {
+				return err
+			}
+		}
+
+		if added := expandStringSet(userIDs.Difference(members)); len(added) > 0 {
+			if err := addUsersToGroup(client, d.Id(), added); err != nil {
+				return err
+			}
+		}
+	}
+
+	return readAfterWrite(resourceNewRelicGroupRead, d, meta)
+}
+
+// groupMembersManaged returns whether the members of the group are managed,
+// which they are when user_ids lists any, or when manage_members is set.
+func groupMembersManaged(d *schema.ResourceData) bool {
+	return d.Get("manage_members").(bool) || d.Get("user_ids").(*schema.Set).Len() > 0
+}
+
+func resourceNewRelicGroupDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*ProviderConfig).NewClient
+
+	log.Printf("[INFO] Deleting New Relic group %s", d.Id())
+
+	return deleteGroup(client, d.Id())
+}
@@ -0,0 +1,132 @@
+package newrelic
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/newrelic/newrelic-client-go/pkg/errors"
+)
+
+func resourceNewRelicGroupRole() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceNewRelicGroupRoleCreate,
+		Read:   resourceNewRelicGroupRoleRead,
+		Delete: resourceNewRelicGroupRoleDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+		Schema: map[string]*schema.Schema{
+			"group_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The ID of the group granted the role.",
+			},
+			"role_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The ID of the role.",
+			},
+			"account_id": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "The ID of the account the role is granted on. Defaults to the account of the provider.",
+			},
+		},
+	}
+}
+
+// Group roles are identified by the group, account and role, in the form
+// <group_id>:<account_id>:<role_id>.
+func parseGroupRoleID(id string) (string, groupRole, error) {
+	parts := strings.Split(id, ":")
+	if len(parts) != 3 {
+		return "", groupRole{}, fmt.Errorf("invalid group role ID %s, expected <group_id>:<account_id>:<role_id>", id)
+	}
+
+	accountID, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return "", groupRole{}, fmt.Errorf("invalid account ID in group role ID %s: %s", id, err)
+	}
+
+	return parts[0], groupRole{AccountID: accountID, RoleID: parts[2]}, nil
+}
+
+func resourceNewRelicGroupRoleCreate(d *schema.ResourceData, meta interface{}) error {
+	providerConfig := meta.(*ProviderConfig)
+	client := providerConfig.NewClient
+
+	groupID := d.Get("group_id").(string)
+	role := groupRole{
+		AccountID: selectAccountID(providerConfig, d),
+		RoleID:    d.Get("role_id").(string),
+	}
+
+	log.Printf("[INFO] Granting New Relic role %s on account %d to group %s", role.RoleID, role.AccountID, groupID)
+
+	if err := grantGroupRole(client, groupID, role); err != nil {
+		return err
+	}
+
+	d.SetId(fmt.Sprintf("%s:%d:%s", groupID, role.AccountID, role.RoleID))
+
+	return readAfterWrite(resourceNewRelicGroupRoleRead, d, meta)
+}
+
+func resourceNewRelicGroupRoleRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*ProviderConfig).NewClient
+
+	log.Printf("[INFO] Reading New Relic group role %s", d.Id())
+
+	groupID, role, err := parseGroupRoleID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	roles, err := getGroupRoles(client, groupID)
+	if err != nil {
+		if _, ok := err.(*errors.NotFound); ok {
+			d.SetId("")
+			return nil
+		}
+
+		return err
+	}
+
+	granted := false
+	for _, r := range roles {
+		if r == role {
+			granted = true
+		}
+	}
+
+	if !granted {
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("group_id", groupID)
+	d.Set("role_id", role.RoleID)
+	d.Set("account_id", role.AccountID)
+
+	return nil
+}
+
+func resourceNewRelicGroupRoleDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*ProviderConfig).NewClient
+
+	groupID, role, err := parseGroupRoleID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[INFO] Revoking New Relic role %s on account %d from group %s", role.RoleID, role.AccountID, groupID)
+
+	return revokeGroupRole(client, groupID, role)
+}
@@ -0,0 +1,76 @@
+// +build unit
+
+package newrelic
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+)
+
+func TestAccNewRelicGroupRole_Offline(t *testing.T) {
+	api := newFakeNewRelicAPI(t)
+	rName := fmt.Sprintf("tf-test-%s", acctest.RandString(5))
+
+	resource.ParallelTest(t, resource.TestCase{
+		IsUnitTest:   true,
+		Providers:    api.providers(),
+		CheckDestroy: api.checkDestroyed(fakeKindGroup),
+		Steps: []resource.TestStep{
+			// Test: Create
+			{
+				Config: api.config(testAccNewRelicGroupRoleUnitConfig(rName)),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("newrelic_group_role.foo", "role_id", "1254"),
+					resource.TestCheckResourceAttr("newrelic_group_role.foo", "account_id", strconv.Itoa(api.AccountID)),
+					resource.TestCheckResourceAttr("newrelic_group_role.sub", "account_id", strconv.Itoa(fakeSubAccountID)),
+					resource.TestMatchResourceAttr("newrelic_group_role.sub", "id", regexp.MustCompile(fmt.Sprintf(`^[\w-]+:%d:1254$`, fakeSubAccountID))),
+					resource.TestCheckResourceAttr("newrelic_group_role.other", "role_id", "1255"),
+				),
+			},
+			// Test: Import
+			{
+				Config:            api.config(testAccNewRelicGroupRoleUnitConfig(rName)),
+				ResourceName:      "newrelic_group_role.sub",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Test: Import a role past the first page of the group's roles
+			{
+				Config:            api.config(testAccNewRelicGroupRoleUnitConfig(rName)),
+				ResourceName:      "newrelic_group_role.other",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccNewRelicGroupRoleUnitConfig(name string) string {
+	return fmt.Sprintf(`
+resource "newrelic_group" "foo" {
+	authentication_domain_id = "fake-domain-1"
+	name                     = "%s"
+}
+
+resource "newrelic_group_role" "foo" {
+	group_id = newrelic_group.foo.id
+	role_id  = "1254"
+}
+
+resource "newrelic_group_role" "sub" {
+	group_id   = newrelic_group.foo.id
+	role_id    = "1254"
+	account_id = %d
+}
+
+resource "newrelic_group_role" "other" {
+	group_id = newrelic_group.foo.id
+	role_id  = "1255"
+}
+`, name, fakeSubAccountID)
+}
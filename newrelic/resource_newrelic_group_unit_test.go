@@ -0,0 +1,106 @@
+// +build unit
+
+package newrelic
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+)
+
+func TestAccNewRelicGroup_Offline(t *testing.T) {
+	api := newFakeNewRelicAPI(t)
+	resourceName := "newrelic_group.foo"
+	rName := fmt.Sprintf("tf-test-%s", acctest.RandString(5))
+
+	resource.ParallelTest(t, resource.TestCase{
+		IsUnitTest:   true,
+		Providers:    api.providers(),
+		CheckDestroy: api.checkDestroyed(fakeKindGroup, fakeKindUser),
+		Steps: []resource.TestStep{
+			// Test: Create
+			{
+				Config: api.config(testAccNewRelicGroupUnitConfig(rName, "user_ids = [newrelic_user.foo[0].id]")),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "name", rName),
+					resource.TestCheckResourceAttr(resourceName, "authentication_domain_id", "fake-domain-1"),
+					resource.TestCheckResourceAttr(resourceName, "user_ids.#", "1"),
+				),
+			},
+			// Test: Update the name and members
+			{
+				Config: api.config(testAccNewRelicGroupUnitConfig(rName+"-updated", "user_ids = [newrelic_user.foo[1].id, newrelic_user.foo[2].id, newrelic_user.foo[3].id]")),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "name", rName+"-updated"),
+					resource.TestCheckResourceAttr(resourceName, "user_ids.#", "3"),
+					testAccCheckNewRelicGroupUnitMembers(api, resourceName, 3),
+				),
+			},
+			// Test: Omit the members, which keeps them
+			{
+				Config: api.config(testAccNewRelicGroupUnitConfig(rName+"-updated", "")),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "user_ids.#", "0"),
+					testAccCheckNewRelicGroupUnitMembers(api, resourceName, 3),
+				),
+			},
+			// Test: Manage the members with an empty list, which removes them
+			{
+				Config: api.config(testAccNewRelicGroupUnitConfig(rName+"-updated", "manage_members = true\n\tuser_ids       = []")),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "user_ids.#", "0"),
+					testAccCheckNewRelicGroupUnitMembers(api, resourceName, 0),
+				),
+			},
+			// Test: Import
+			{
+				Config:                  api.config(testAccNewRelicGroupUnitConfig(rName+"-updated", "manage_members = true\n\tuser_ids       = []")),
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"manage_members"},
+			},
+		},
+	})
+}
+
+func testAccNewRelicGroupUnitConfig(name string, members string) string {
+	return fmt.Sprintf(`
+resource "newrelic_user" "foo" {
+	count = 4
+
+	authentication_domain_id = "fake-domain-1"
+	name                     = "%[1]s-${count.index}"
+	email                    = "%[1]s-${count.index}@example.com"
+}
+
+resource "newrelic_group" "foo" {
+	authentication_domain_id = "fake-domain-1"
+	name                     = "%[1]s"
+	%[2]s
+}
+`, name, members)
+}
+
+func testAccCheckNewRelicGroupUnitMembers(api *fakeNewRelicAPI, resourceName string, count int) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("not found: %s", resourceName)
+		}
+
+		rec := api.get(fakeKindGroup, rs.Primary.ID)
+		if rec == nil {
+			return fmt.Errorf("%s not found in the fake API", resourceName)
+		}
+
+		if members := fakeList(rec["userIds"]); len(members) != count {
+			return fmt.Errorf("expected %d members in %s, got %d", count, resourceName, len(members))
+		}
+
+		return nil
+	}
+}
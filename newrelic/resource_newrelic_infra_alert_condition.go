@@ -64,6 +64,13 @@ func resourceNewRelicInfraAlertCondition() *schema.Resource {
 			State: schema.ImportStatePassthrough,
 		},
 		Schema: map[string]*schema.Schema{
+			"account_id": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "The New Relic account ID to operate on. Accounts other than the one of the provider must be configured in an account block of the provider.",
+			},
 			"policy_id": {
 				Type:        schema.TypeInt,
 				Required:    true,
@@ -177,7 +184,11 @@ func resourceNewRelicInfraAlertCondition() *schema.Resource {
 }
 
 func resourceNewRelicInfraAlertConditionCreate(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*ProviderConfig).NewClient
+	client, err := restAccountClient(meta.(*ProviderConfig), d)
+	if err != nil {
+		return err
+	}
+
 	condition, err := expandInfraAlertCondition(d)
 
 	if err != nil {
@@ -194,12 +205,16 @@ func resourceNewRelicInfraAlertConditionCreate(d *schema.ResourceData, meta inte
 
 	d.SetId(serializeIDs([]int{condition.PolicyID, condition.ID}))
 
-	return resourceNewRelicInfraAlertConditionRead(d, meta)
+	return readAfterWrite(resourceNewRelicInfraAlertConditionRead, d, meta)
 }
 
 func resourceNewRelicInfraAlertConditionRead(d *schema.ResourceData, meta interface{}) error {
 	providerConfig := meta.(*ProviderConfig)
-	client := meta.(*ProviderConfig).NewClient
+	client, err := restAccountClient(providerConfig, d)
+	if err != nil {
+		return err
+	}
+
 	accountID := selectAccountID(providerConfig, d)
 
 	log.Printf("[INFO] Reading New Relic Infra alert condition %s", d.Id())
@@ -231,11 +246,19 @@ func resourceNewRelicInfraAlertConditionRead(d *schema.ResourceData, meta interf
 		return err
 	}
 
+	if err := d.Set("account_id", accountID); err != nil {
+		return err
+	}
+
 	return flattenInfraAlertCondition(condition, d)
 }
 
 func resourceNewRelicInfraAlertConditionUpdate(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*ProviderConfig).NewClient
+	client, err := restAccountClient(meta.(*ProviderConfig), d)
+	if err != nil {
+		return err
+	}
+
 	condition, err := expandInfraAlertCondition(d)
 
 	if err != nil {
@@ -260,11 +283,14 @@ func resourceNewRelicInfraAlertConditionUpdate(d *schema.ResourceData, meta inte
 		return err
 	}
 
-	return resourceNewRelicInfraAlertConditionRead(d, meta)
+	return readAfterWrite(resourceNewRelicInfraAlertConditionRead, d, meta)
 }
 
 func resourceNewRelicInfraAlertConditionDelete(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*ProviderConfig).NewClient
+	client, err := restAccountClient(meta.(*ProviderConfig), d)
+	if err != nil {
+		return err
+	}
 
 	ids, err := parseIDs(d.Id(), 2)
 	if err != nil {
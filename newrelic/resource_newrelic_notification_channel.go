@@ -0,0 +1,175 @@
+package newrelic
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/newrelic/newrelic-client-go/pkg/errors"
+)
+
+var notificationChannelTypes = []string{
+	"EMAIL",
+	"EVENT_BRIDGE",
+	"JIRA_CLASSIC",
+	"JIRA_NEXTGEN",
+	"MOBILE_PUSH",
+	"PAGERDUTY_ACCOUNT_INTEGRATION",
+	"PAGERDUTY_SERVICE_INTEGRATION",
+	"SERVICENOW_EVENTS",
+	"SERVICENOW_INCIDENTS",
+	"SLACK",
+	"SLACK_COLLABORATION",
+	"SLACK_LEGACY",
+	"WEBHOOK",
+}
+
+var notificationChannelProducts = []string{
+	"ALERTS",
+	"DISCUSSIONS",
+	"ERROR_TRACKING",
+	"IINT",
+	"NTFC",
+	"PD",
+	"SHARING",
+}
+
+func resourceNewRelicNotificationChannel() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceNewRelicNotificationChannelCreate,
+		Read:   resourceNewRelicNotificationChannelRead,
+		Update: resourceNewRelicNotificationChannelUpdate,
+		Delete: resourceNewRelicNotificationChannelDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+		Schema: map[string]*schema.Schema{
+			"account_id": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "The New Relic account ID where you want to create the channel.",
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The name of the channel.",
+			},
+			"type": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				Description:  fmt.Sprintf("The type of the channel. One of: (%s).", strings.Join(notificationChannelTypes, ", ")),
+				ValidateFunc: validation.StringInSlice(notificationChannelTypes, false),
+			},
+			"destination_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The ID of the destination the channel sends notifications to.",
+			},
+			"product": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				Description:  fmt.Sprintf("The product the channel belongs to. One of: (%s).", strings.Join(notificationChannelProducts, ", ")),
+				ValidateFunc: validation.StringInSlice(notificationChannelProducts, false),
+			},
+			"active": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Whether the channel is active.",
+			},
+			"property": notificationPropertySchema(),
+			"status": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The status of the channel.",
+			},
+		},
+	}
+}
+
+func resourceNewRelicNotificationChannelCreate(d *schema.ResourceData, meta interface{}) error {
+	providerConfig := meta.(*ProviderConfig)
+	client := accountClient(providerConfig, d)
+	accountID := selectAccountID(providerConfig, d)
+
+	createInput := expandNotificationChannelInput(d)
+
+	log.Printf("[INFO] Creating New Relic notification channel %s", createInput.Name)
+
+	created, err := createNotificationChannel(client, accountID, createInput)
+	if err != nil {
+		return err
+	}
+
+	d.SetId(created.ID)
+
+	// Channels are always created active.
+	if !d.Get("active").(bool) {
+		if _, err := updateNotificationChannel(client, accountID, created.ID, expandNotificationChannelUpdate(d)); err != nil {
+			return err
+		}
+	}
+
+	return readAfterWrite(resourceNewRelicNotificationChannelRead, d, meta)
+}
+
+func resourceNewRelicNotificationChannelRead(d *schema.ResourceData, meta interface{}) error {
+	providerConfig := meta.(*ProviderConfig)
+	client := accountClient(providerConfig, d)
+	accountID := selectAccountID(providerConfig, d)
+
+	log.Printf("[INFO] Reading New Relic notification channel %s", d.Id())
+
+	channel, err := getNotificationChannel(client, accountID, d.Id())
+	if err != nil {
+		if _, ok := err.(*errors.NotFound); ok {
+			d.SetId("")
+			return nil
+		}
+
+		return err
+	}
+
+	d.Set("account_id", accountID)
+
+	return flattenNotificationChannel(channel, d)
+}
+
+func resourceNewRelicNotificationChannelUpdate(d *schema.ResourceData, meta interface{}) error {
+	providerConfig := meta.(*ProviderConfig)
+	client := accountClient(providerConfig, d)
+	accountID := selectAccountID(providerConfig, d)
+
+	log.Printf("[INFO] Updating New Relic notification channel %s", d.Id())
+
+	if _, err := updateNotificationChannel(client, accountID, d.Id(), expandNotificationChannelUpdate(d)); err != nil {
+		return err
+	}
+
+	return readAfterWrite(resourceNewRelicNotificationChannelRead, d, meta)
+}
+
+func resourceNewRelicNotificationChannelDelete(d *schema.ResourceData, meta interface{}) error {
+	providerConfig := meta.(*ProviderConfig)
+	client := accountClient(providerConfig, d)
+	accountID := selectAccountID(providerConfig, d)
+
+	log.Printf("[INFO] Deleting New Relic notification channel %s", d.Id())
+
+	if err := deleteNotificationChannel(client, accountID, d.Id()); err != nil {
+		if _, ok := err.(*errors.NotFound); ok {
+			return nil
+		}
+
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,87 @@
+// +build unit
+
+package newrelic
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+)
+
+func TestAccNewRelicNotificationChannel_Offline(t *testing.T) {
+	api := newFakeNewRelicAPI(t)
+	resourceName := "newrelic_notification_channel.foo"
+	rName := fmt.Sprintf("tf-test-%s", acctest.RandString(5))
+
+	resource.ParallelTest(t, resource.TestCase{
+		IsUnitTest:   true,
+		Providers:    api.providers(),
+		CheckDestroy: api.checkDestroyed(fakeKindNotificationDestination, fakeKindNotificationChannel),
+		Steps: []resource.TestStep{
+			// Test: Create
+			{
+				Config: api.config(testAccNewRelicNotificationChannelUnitConfig(rName, "{{ issueTitle }}")),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "name", rName),
+					resource.TestCheckResourceAttr(resourceName, "type", "WEBHOOK"),
+					resource.TestCheckResourceAttr(resourceName, "product", "IINT"),
+					resource.TestCheckResourceAttr(resourceName, "active", "true"),
+					resource.TestCheckResourceAttrPair(resourceName, "destination_id", "newrelic_notification_destination.foo", "id"),
+				),
+			},
+			// Test: Update
+			{
+				Config: api.config(testAccNewRelicNotificationChannelUnitConfig(rName+"-updated", "{{ issueTitle }} ({{ priority }})")),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "name", rName+"-updated"),
+				),
+			},
+			// Test: Import
+			{
+				Config:            api.config(testAccNewRelicNotificationChannelUnitConfig(rName+"-updated", "{{ issueTitle }} ({{ priority }})")),
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestNewRelicNotificationChannel_DeleteNotFound(t *testing.T) {
+	api := newFakeNewRelicAPI(t)
+	d := resourceNewRelicNotificationChannel().Data(&terraform.InstanceState{ID: "missing"})
+
+	if err := resourceNewRelicNotificationChannelDelete(d, api.providerConfig(t)); err != nil {
+		t.Fatalf("deleting a notification channel which no longer exists should succeed, got %s", err)
+	}
+}
+
+func testAccNewRelicNotificationChannelUnitConfig(name string, payloadTemplate string) string {
+	return fmt.Sprintf(`
+resource "newrelic_notification_destination" "foo" {
+	name = "%[1]s"
+	type = "WEBHOOK"
+
+	property {
+		key   = "url"
+		value = "https://example.com/hook"
+	}
+}
+
+resource "newrelic_notification_channel" "foo" {
+	name           = "%[1]s"
+	type           = "WEBHOOK"
+	destination_id = newrelic_notification_destination.foo.id
+	product        = "IINT"
+
+	property {
+		key   = "payload"
+		value = "{\"title\": \"%[2]s\"}"
+		label = "Payload Template"
+	}
+}
+`, name, payloadTemplate)
+}
@@ -0,0 +1,229 @@
+package newrelic
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/newrelic/newrelic-client-go/pkg/errors"
+)
+
+var notificationDestinationTypes = []string{
+	"EMAIL",
+	"EVENT_BRIDGE",
+	"JIRA",
+	"MOBILE_PUSH",
+	"PAGERDUTY_ACCOUNT_INTEGRATION",
+	"PAGERDUTY_SERVICE_INTEGRATION",
+	"SERVICE_NOW",
+	"SLACK",
+	"SLACK_COLLABORATION",
+	"SLACK_LEGACY",
+	"WEBHOOK",
+}
+
+// notificationPropertySchema describes the key/value properties shared by
+// notification destinations and channels. Values may contain templates which
+// are rendered for each notification.
+func notificationPropertySchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeSet,
+		Optional:    true,
+		Description: "A set of key/value properties.",
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"key": {
+					Type:        schema.TypeString,
+					Required:    true,
+					Description: "The property key.",
+				},
+				"value": {
+					Type:        schema.TypeString,
+					Required:    true,
+					Description: "The property value. May contain a template.",
+				},
+				"label": {
+					Type:        schema.TypeString,
+					Optional:    true,
+					Description: "A human readable label for the property.",
+				},
+				"display_value": {
+					Type:        schema.TypeString,
+					Optional:    true,
+					Description: "The value shown in the New Relic UI.",
+				},
+			},
+		},
+	}
+}
+
+func resourceNewRelicNotificationDestination() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceNewRelicNotificationDestinationCreate,
+		Read:   resourceNewRelicNotificationDestinationRead,
+		Update: resourceNewRelicNotificationDestinationUpdate,
+		Delete: resourceNewRelicNotificationDestinationDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+		Schema: map[string]*schema.Schema{
+			"account_id": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "The New Relic account ID where you want to create the destination.",
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The name of the destination.",
+			},
+			"type": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				Description:  fmt.Sprintf("The type of the destination. One of: (%s).", strings.Join(notificationDestinationTypes, ", ")),
+				ValidateFunc: validation.StringInSlice(notificationDestinationTypes, false),
+			},
+			"active": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Whether the destination is active.",
+			},
+			"property": notificationPropertySchema(),
+			"auth_basic": {
+				Type:          schema.TypeList,
+				Optional:      true,
+				MaxItems:      1,
+				ConflictsWith: []string{"auth_token"},
+				Description:   "Basic username and password authentication credentials.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"user": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "The username.",
+						},
+						"password": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Sensitive:   true,
+							Description: "The password.",
+						},
+					},
+				},
+			},
+			"auth_token": {
+				Type:          schema.TypeList,
+				Optional:      true,
+				MaxItems:      1,
+				ConflictsWith: []string{"auth_basic"},
+				Description:   "Token authentication credentials.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"prefix": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "The prefix sent before the token, e.g. Bearer.",
+						},
+						"token": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Sensitive:   true,
+							Description: "The token.",
+						},
+					},
+				},
+			},
+			"status": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The status of the destination.",
+			},
+		},
+	}
+}
+
+func resourceNewRelicNotificationDestinationCreate(d *schema.ResourceData, meta interface{}) error {
+	providerConfig := meta.(*ProviderConfig)
+	client := accountClient(providerConfig, d)
+	accountID := selectAccountID(providerConfig, d)
+
+	createInput := expandNotificationDestinationInput(d)
+
+	log.Printf("[INFO] Creating New Relic notification destination %s", createInput.Name)
+
+	created, err := createNotificationDestination(client, accountID, createInput)
+	if err != nil {
+		return err
+	}
+
+	d.SetId(created.ID)
+
+	// Destinations are always created active.
+	if !d.Get("active").(bool) {
+		if _, err := updateNotificationDestination(client, accountID, created.ID, expandNotificationDestinationUpdate(d)); err != nil {
+			return err
+		}
+	}
+
+	return readAfterWrite(resourceNewRelicNotificationDestinationRead, d, meta)
+}
+
+func resourceNewRelicNotificationDestinationRead(d *schema.ResourceData, meta interface{}) error {
+	providerConfig := meta.(*ProviderConfig)
+	client := accountClient(providerConfig, d)
+	accountID := selectAccountID(providerConfig, d)
+
+	log.Printf("[INFO] Reading New Relic notification destination %s", d.Id())
+
+	destination, err := getNotificationDestination(client, accountID, d.Id())
+	if err != nil {
+		if _, ok := err.(*errors.NotFound); ok {
+			d.SetId("")
+			return nil
+		}
+
+		return err
+	}
+
+	d.Set("account_id", accountID)
+
+	return flattenNotificationDestination(destination, d)
+}
+
+func resourceNewRelicNotificationDestinationUpdate(d *schema.ResourceData, meta interface{}) error {
+	providerConfig := meta.(*ProviderConfig)
+	client := accountClient(providerConfig, d)
+	accountID := selectAccountID(providerConfig, d)
+
+	log.Printf("[INFO] Updating New Relic notification destination %s", d.Id())
+
+	if _, err := updateNotificationDestination(client, accountID, d.Id(), expandNotificationDestinationUpdate(d)); err != nil {
+		return err
+	}
+
+	return readAfterWrite(resourceNewRelicNotificationDestinationRead, d, meta)
+}
+
+func resourceNewRelicNotificationDestinationDelete(d *schema.ResourceData, meta interface{}) error {
+	providerConfig := meta.(*ProviderConfig)
+	client := accountClient(providerConfig, d)
+	accountID := selectAccountID(providerConfig, d)
+
+	log.Printf("[INFO] Deleting New Relic notification destination %s", d.Id())
+
+	if err := deleteNotificationDestination(client, accountID, d.Id()); err != nil {
+		if _, ok := err.(*errors.NotFound); ok {
+			return nil
+		}
+
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,98 @@
+// +build unit
+
+package newrelic
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+)
+
+func TestAccNewRelicNotificationDestination_Offline(t *testing.T) {
+	api := newFakeNewRelicAPI(t)
+	resourceName := "newrelic_notification_destination.foo"
+	rName := fmt.Sprintf("tf-test-%s", acctest.RandString(5))
+
+	basicAuth := `
+	auth_basic {
+		user     = "username"
+		password = "abc123"
+	}`
+	tokenAuth := `
+	auth_token {
+		prefix = "Bearer"
+		token  = "def456"
+	}`
+
+	resource.ParallelTest(t, resource.TestCase{
+		IsUnitTest:   true,
+		Providers:    api.providers(),
+		CheckDestroy: api.checkDestroyed(fakeKindNotificationDestination),
+		Steps: []resource.TestStep{
+			// Test: Create
+			{
+				Config: api.config(testAccNewRelicNotificationDestinationUnitConfig(rName, true, basicAuth)),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "name", rName),
+					resource.TestCheckResourceAttr(resourceName, "type", "WEBHOOK"),
+					resource.TestCheckResourceAttr(resourceName, "active", "true"),
+					resource.TestCheckResourceAttr(resourceName, "property.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "auth_basic.0.user", "username"),
+					resource.TestCheckResourceAttrSet(resourceName, "status"),
+				),
+			},
+			// Test: Update
+			{
+				Config: api.config(testAccNewRelicNotificationDestinationUnitConfig(rName+"-updated", false, tokenAuth)),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "name", rName+"-updated"),
+					resource.TestCheckResourceAttr(resourceName, "active", "false"),
+					resource.TestCheckResourceAttr(resourceName, "auth_basic.#", "0"),
+					resource.TestCheckResourceAttr(resourceName, "auth_token.0.prefix", "Bearer"),
+				),
+			},
+			// Test: Remove credentials
+			{
+				Config: api.config(testAccNewRelicNotificationDestinationUnitConfig(rName+"-updated", false, "")),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "auth_token.#", "0"),
+				),
+			},
+			// Test: Import
+			{
+				Config:            api.config(testAccNewRelicNotificationDestinationUnitConfig(rName+"-updated", false, "")),
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestNewRelicNotificationDestination_DeleteNotFound(t *testing.T) {
+	api := newFakeNewRelicAPI(t)
+	d := resourceNewRelicNotificationDestination().Data(&terraform.InstanceState{ID: "missing"})
+
+	if err := resourceNewRelicNotificationDestinationDelete(d, api.providerConfig(t)); err != nil {
+		t.Fatalf("deleting a notification destination which no longer exists should succeed, got %s", err)
+	}
+}
+
+func testAccNewRelicNotificationDestinationUnitConfig(name string, active bool, auth string) string {
+	return fmt.Sprintf(`
+resource "newrelic_notification_destination" "foo" {
+	name   = "%[1]s"
+	type   = "WEBHOOK"
+	active = %[2]t
+
+	property {
+		key   = "url"
+		value = "https://example.com/hook"
+	}
+%[3]s
+}
+`, name, active, auth)
+}
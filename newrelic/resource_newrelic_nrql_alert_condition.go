@@ -350,7 +350,7 @@ func resourceNewRelicNrqlAlertCondition() *schema.Resource {
 
 func resourceNewRelicNrqlAlertConditionCreate(d *schema.ResourceData, meta interface{}) error {
 	providerConfig := meta.(*ProviderConfig)
-	client := providerConfig.NewClient
+	client := accountClient(providerConfig, d)
 
 	accountID := selectAccountID(providerConfig, d)
 	policyID := strconv.Itoa(d.Get("policy_id").(int))
@@ -373,6 +373,8 @@ func resourceNewRelicNrqlAlertConditionCreate(d *schema.ResourceData, meta inter
 		condition, err = client.Alerts.CreateNrqlConditionOutlierMutation(accountID, policyID, *conditionInput)
 	}
 
+	providerConfig.nrqlConditions.invalidate()
+
 	if err != nil {
 		return err
 	}
@@ -384,12 +386,23 @@ func resourceNewRelicNrqlAlertConditionCreate(d *schema.ResourceData, meta inter
 
 	d.SetId(serializeIDs([]int{d.Get("policy_id").(int), conditionID}))
 
-	return resourceNewRelicNrqlAlertConditionRead(d, meta)
+	return readAfterWrite(resourceNewRelicNrqlAlertConditionReadAfterWrite, d, meta)
 }
 
 func resourceNewRelicNrqlAlertConditionRead(d *schema.ResourceData, meta interface{}) error {
+	return readNrqlAlertCondition(d, meta, meta.(*ProviderConfig).nrqlConditions)
+}
+
+// resourceNewRelicNrqlAlertConditionReadAfterWrite reads a condition without
+// the cache, which may still hold the conditions of its policy as they were
+// before the write.
+func resourceNewRelicNrqlAlertConditionReadAfterWrite(d *schema.ResourceData, meta interface{}) error {
+	return readNrqlAlertCondition(d, meta, nil)
+}
+
+func readNrqlAlertCondition(d *schema.ResourceData, meta interface{}, cache *nrqlConditionCache) error {
 	providerConfig := meta.(*ProviderConfig)
-	client := providerConfig.NewClient
+	client := accountClient(providerConfig, d)
 	accountID := selectAccountID(providerConfig, d)
 
 	log.Printf("[INFO] Reading New Relic NRQL alert condition %s", d.Id())
@@ -402,16 +415,7 @@ func resourceNewRelicNrqlAlertConditionRead(d *schema.ResourceData, meta interfa
 	policyID := ids[0]
 	conditionID := ids[1]
 
-	_, err = client.Alerts.QueryPolicy(accountID, strconv.Itoa(policyID))
-	if err != nil {
-		if _, ok := err.(*errors.NotFound); ok {
-			d.SetId("")
-			return nil
-		}
-		return err
-	}
-
-	nrqlCondition, err := client.Alerts.GetNrqlConditionQuery(accountID, strconv.Itoa(conditionID))
+	nrqlCondition, err := cache.get(client, accountID, policyID, conditionID)
 	if err != nil {
 		if _, ok := err.(*errors.NotFound); ok {
 			d.SetId("")
@@ -425,7 +429,7 @@ func resourceNewRelicNrqlAlertConditionRead(d *schema.ResourceData, meta interfa
 
 func resourceNewRelicNrqlAlertConditionUpdate(d *schema.ResourceData, meta interface{}) error {
 	providerConfig := meta.(*ProviderConfig)
-	client := providerConfig.NewClient
+	client := accountClient(providerConfig, d)
 	accountID := selectAccountID(providerConfig, d)
 
 	ids, err := parseHashedIDs(d.Id())
@@ -449,16 +453,18 @@ func resourceNewRelicNrqlAlertConditionUpdate(d *schema.ResourceData, meta inter
 		_, err = client.Alerts.UpdateNrqlConditionOutlierMutation(accountID, conditionID, *conditionInput)
 	}
 
+	providerConfig.nrqlConditions.invalidate()
+
 	if err != nil {
 		return err
 	}
 
-	return resourceNewRelicNrqlAlertConditionRead(d, meta)
+	return readAfterWrite(resourceNewRelicNrqlAlertConditionReadAfterWrite, d, meta)
 }
 
 func resourceNewRelicNrqlAlertConditionDelete(d *schema.ResourceData, meta interface{}) error {
 	providerConfig := meta.(*ProviderConfig)
-	client := providerConfig.NewClient
+	client := accountClient(providerConfig, d)
 	accountID := selectAccountID(providerConfig, d)
 
 	ids, err := parseHashedIDs(d.Id())
@@ -471,6 +477,7 @@ func resourceNewRelicNrqlAlertConditionDelete(d *schema.ResourceData, meta inter
 	log.Printf("[INFO] Deleting New Relic NRQL alert condition %v", conditionID)
 
 	_, err = client.Alerts.DeleteNrqlConditionMutation(accountID, conditionID)
+	providerConfig.nrqlConditions.invalidate()
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,343 @@
+// +build unit
+
+package newrelic
+
+import (
+	"fmt"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+)
+
+func TestAccNewRelicNrqlAlertCondition_Offline(t *testing.T) {
+	api := newFakeNewRelicAPI(t)
+	resourceName := "newrelic_nrql_alert_condition.foo"
+	rName := acctest.RandString(5)
+	staticAttrs := `value_function = "single_value"`
+
+	resource.ParallelTest(t, resource.TestCase{
+		IsUnitTest:   true,
+		Providers:    api.providers(),
+		CheckDestroy: api.checkDestroyed(fakeKindPolicy, fakeKindNrqlCondition),
+		Steps: []resource.TestStep{
+			// Test: Create
+			{
+				Config: api.config(testAccNewRelicNrqlAlertConditionUnitConfig(rName, "static", staticAttrs, 120)),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "name", "tf-test-"+rName),
+					resource.TestCheckResourceAttr(resourceName, "type", "static"),
+					resource.TestCheckResourceAttr(resourceName, "critical.0.threshold_duration", "120"),
+				),
+			},
+			// Test: Update
+			{
+				Config: api.config(testAccNewRelicNrqlAlertConditionUnitConfig(rName, "static", staticAttrs, 180)),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "critical.0.threshold_duration", "180"),
+				),
+			},
+			// Test: Import
+			{
+				Config:            api.config(testAccNewRelicNrqlAlertConditionUnitConfig(rName, "static", staticAttrs, 180)),
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+				// Only one of the violation time limit attributes is read back,
+				// depending on which one is already present in state.
+				ImportStateVerifyIgnore: []string{
+					"violation_time_limit",
+					"violation_time_limit_seconds",
+				},
+				ImportStateIdFunc: testAccImportStateIDFunc(resourceName, "static"),
+			},
+		},
+	})
+}
+
+func TestAccNewRelicNrqlAlertCondition_OfflineBaseline(t *testing.T) {
+	api := newFakeNewRelicAPI(t)
+	resourceName := "newrelic_nrql_alert_condition.foo"
+	rName := acctest.RandString(5)
+	baselineAttrs := `baseline_direction = "UPPER_ONLY"`
+
+	resource.ParallelTest(t, resource.TestCase{
+		IsUnitTest:   true,
+		Providers:    api.providers(),
+		CheckDestroy: api.checkDestroyed(fakeKindPolicy, fakeKindNrqlCondition),
+		Steps: []resource.TestStep{
+			// Test: Create
+			{
+				Config: api.config(testAccNewRelicNrqlAlertConditionUnitConfig(rName, "baseline", baselineAttrs, 120)),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "type", "baseline"),
+					resource.TestCheckResourceAttr(resourceName, "baseline_direction", "UPPER_ONLY"),
+				),
+			},
+			// Test: Import
+			{
+				Config:            api.config(testAccNewRelicNrqlAlertConditionUnitConfig(rName, "baseline", baselineAttrs, 120)),
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+				// Only one of the violation time limit attributes is read back,
+				// depending on which one is already present in state.
+				ImportStateVerifyIgnore: []string{
+					"violation_time_limit",
+					"violation_time_limit_seconds",
+				},
+				ImportStateIdFunc: testAccImportStateIDFunc(resourceName, "baseline"),
+			},
+		},
+	})
+}
+
+func testAccNewRelicNrqlAlertConditionUnitConfig(
+	name string,
+	conditionType string,
+	conditionalAttrs string,
+	duration int,
+) string {
+	return fmt.Sprintf(`
+resource "newrelic_alert_policy" "foo" {
+	name = "tf-test-%[1]s"
+}
+
+resource "newrelic_nrql_alert_condition" "foo" {
+	policy_id = newrelic_alert_policy.foo.id
+
+	type                         = "%[2]s"
+	name                         = "tf-test-%[1]s"
+	runbook_url                  = "https://foo.example.com"
+	enabled                      = false
+	description                  = "test description"
+	violation_time_limit_seconds = 3600
+	aggregation_window           = 60
+
+	nrql {
+		query             = "SELECT uniqueCount(hostname) FROM ComputeSample"
+		evaluation_offset = 3
+	}
+
+	critical {
+		operator              = "above"
+		threshold             = 0.75
+		threshold_duration    = %[4]d
+		threshold_occurrences = "ALL"
+	}
+
+	%[3]s
+}
+`, name, conditionType, conditionalAttrs, duration)
+}
+
+func TestAccNewRelicNrqlAlertCondition_OfflineReadCache(t *testing.T) {
+	api := newFakeNewRelicAPI(t)
+	rName := acctest.RandString(5)
+
+	resource.ParallelTest(t, resource.TestCase{
+		IsUnitTest:   true,
+		Providers:    api.providers(),
+		CheckDestroy: api.checkDestroyed(fakeKindPolicy, fakeKindNrqlCondition),
+		Steps: []resource.TestStep{
+			// Test: Create
+			{
+				Config: api.config(testAccNewRelicNrqlAlertConditionUnitCountConfig(rName, 5)),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("newrelic_nrql_alert_condition.foo.4", "name", "tf-test-"+rName+"-4"),
+					testAccCheckNewRelicNrqlAlertConditionUnitReadCache(t, api),
+				),
+			},
+		},
+	})
+}
+
+// testAccCheckNewRelicNrqlAlertConditionUnitReadCache reads every NRQL alert
+// condition in the state at once, and checks they are all served by a single
+// search of their policy.
+func testAccCheckNewRelicNrqlAlertConditionUnitReadCache(t *testing.T, api *fakeNewRelicAPI) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		providerConfig := api.providerConfig(t)
+
+		var conditions []*schema.ResourceData
+		for _, rs := range s.RootModule().Resources {
+			if rs.Type == "newrelic_nrql_alert_condition" {
+				conditions = append(conditions, resourceNewRelicNrqlAlertCondition().Data(rs.Primary))
+			}
+		}
+
+		readAll := func() error {
+			errs := make(chan error, len(conditions))
+
+			var wg sync.WaitGroup
+			for _, d := range conditions {
+				wg.Add(1)
+				go func(d *schema.ResourceData) {
+					defer wg.Done()
+					errs <- resourceNewRelicNrqlAlertConditionRead(d, providerConfig)
+				}(d)
+			}
+			wg.Wait()
+			close(errs)
+
+			for err := range errs {
+				if err != nil {
+					return err
+				}
+			}
+
+			for _, d := range conditions {
+				if d.Id() == "" {
+					return fmt.Errorf("expected the NRQL alert conditions to be found")
+				}
+			}
+
+			return nil
+		}
+
+		pages := (len(conditions) + fakeNrqlConditionsPageSize - 1) / fakeNrqlConditionsPageSize
+		searches := api.graphQLCallCount("nrqlConditionsSearch")
+
+		if err := readAll(); err != nil {
+			return err
+		}
+
+		if n := api.graphQLCallCount("nrqlConditionsSearch") - searches; n != pages {
+			return fmt.Errorf("expected %d NRQL condition search requests, got %d", pages, n)
+		}
+
+		// Cached reads make no request.
+		if err := readAll(); err != nil {
+			return err
+		}
+
+		if n := api.graphQLCallCount("nrqlConditionsSearch") - searches; n != pages {
+			return fmt.Errorf("expected %d NRQL condition search requests after reading again, got %d", pages, n)
+		}
+
+		// Writes clear the cache.
+		providerConfig.nrqlConditions.invalidate()
+
+		if err := readAll(); err != nil {
+			return err
+		}
+
+		if n := api.graphQLCallCount("nrqlConditionsSearch") - searches; n != 2*pages {
+			return fmt.Errorf("expected %d NRQL condition search requests after invalidating, got %d", 2*pages, n)
+		}
+
+		if n := api.graphQLCallCount("nrqlCondition"); n != 0 {
+			return fmt.Errorf("expected no single NRQL condition request, got %d", n)
+		}
+
+		return nil
+	}
+}
+
+func TestAccNewRelicNrqlAlertCondition_OfflineSearchDelay(t *testing.T) {
+	api := newFakeNewRelicAPI(t)
+	rName := acctest.RandString(5)
+
+	resource.ParallelTest(t, resource.TestCase{
+		IsUnitTest:   true,
+		Providers:    api.providers(),
+		CheckDestroy: api.checkDestroyed(fakeKindPolicy, fakeKindNrqlCondition),
+		Steps: []resource.TestStep{
+			// Test: Create
+			{
+				Config: api.config(testAccNewRelicNrqlAlertConditionUnitCountConfig(rName, 1)),
+			},
+			// Test: Create a condition only found by the second search
+			{
+				PreConfig: func() {
+					api.delayNrqlConditionSearches(1)
+				},
+				Config: api.config(testAccNewRelicNrqlAlertConditionUnitCountConfig(rName, 2)),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("newrelic_nrql_alert_condition.foo.1", "name", "tf-test-"+rName+"-1"),
+					testAccCheckNewRelicNrqlAlertConditionUnitCacheMiss(t, api, "newrelic_nrql_alert_condition.foo.0"),
+				),
+			},
+		},
+	})
+}
+
+// testAccCheckNewRelicNrqlAlertConditionUnitCacheMiss caches the conditions
+// of a condition's policy, and checks a condition created afterwards is still
+// found.
+func testAccCheckNewRelicNrqlAlertConditionUnitCacheMiss(t *testing.T, api *fakeNewRelicAPI, resourceName string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		providerConfig := api.providerConfig(t)
+		cache := providerConfig.nrqlConditions
+
+		ids, err := parseHashedIDs(s.RootModule().Resources[resourceName].Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		if _, err = cache.get(providerConfig.NewClient, providerConfig.AccountID, ids[0], ids[1]); err != nil {
+			return err
+		}
+
+		data := api.get(fakeKindNrqlCondition, strconv.Itoa(ids[1]))
+
+		api.mu.Lock()
+		id := api.nextID()
+		data["id"] = strconv.Itoa(id)
+		api.put(fakeKindNrqlCondition, strconv.Itoa(id), strconv.Itoa(ids[0]), data)
+		api.mu.Unlock()
+
+		defer func() {
+			api.mu.Lock()
+			api.remove(fakeKindNrqlCondition, strconv.Itoa(id))
+			api.mu.Unlock()
+		}()
+
+		searches := api.graphQLCallCount("nrqlConditionsSearch")
+
+		if _, err = cache.get(providerConfig.NewClient, providerConfig.AccountID, ids[0], id); err != nil {
+			return err
+		}
+
+		if api.graphQLCallCount("nrqlConditionsSearch") == searches {
+			return fmt.Errorf("expected the policy to be searched again")
+		}
+
+		return nil
+	}
+}
+
+func testAccNewRelicNrqlAlertConditionUnitCountConfig(name string, count int) string {
+	return fmt.Sprintf(`
+resource "newrelic_alert_policy" "foo" {
+	name = "tf-test-%[1]s"
+}
+
+resource "newrelic_nrql_alert_condition" "foo" {
+	count     = %[2]d
+	policy_id = newrelic_alert_policy.foo.id
+
+	type                         = "static"
+	name                         = "tf-test-%[1]s-${count.index}"
+	enabled                      = false
+	violation_time_limit_seconds = 3600
+	value_function               = "single_value"
+
+	nrql {
+		query             = "SELECT count(*) FROM Transaction"
+		evaluation_offset = 3
+	}
+
+	critical {
+		operator              = "above"
+		threshold             = 1
+		threshold_duration    = 120
+		threshold_occurrences = "ALL"
+	}
+}
+`, name, count)
+}
@@ -3,9 +3,16 @@ package newrelic
 import (
 	"fmt"
 	"log"
+	"sort"
+	"strconv"
+	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/structure"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	nr "github.com/newrelic/newrelic-client-go/newrelic"
+	"github.com/newrelic/newrelic-client-go/pkg/accounts"
+	"github.com/newrelic/newrelic-client-go/pkg/dashboards"
 	"github.com/newrelic/newrelic-client-go/pkg/entities"
 	"github.com/newrelic/newrelic-client-go/pkg/errors"
 )
@@ -17,8 +24,9 @@ func resourceNewRelicOneDashboard() *schema.Resource {
 		Update: resourceNewRelicOneDashboardUpdate,
 		Delete: resourceNewRelicOneDashboardDelete,
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			State: resourceNewRelicOneDashboardImport,
 		},
+		CustomizeDiff: resourceNewRelicOneDashboardDiff,
 		Schema: map[string]*schema.Schema{
 			// Required
 			"name": {
@@ -51,6 +59,12 @@ func resourceNewRelicOneDashboard() *schema.Resource {
 				ValidateFunc: validation.StringInSlice([]string{"private", "public_read_only", "public_read_write"}, false),
 				Description:  "Determines who can see or edit the dashboard. Valid values are private, public_read_only, public_read_write. Defaults to public_read_only.",
 			},
+			"variable": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "Dashboard-local variable definitions.",
+				Elem:        dashboardVariableSchemaElem(),
+			},
 			// Computed
 			"guid": {
 				Type:        schema.TypeString,
@@ -66,10 +80,99 @@ func resourceNewRelicOneDashboard() *schema.Resource {
 	}
 }
 
+// dashboardVariableSchemaElem returns the schema for a dashboard variable,
+// which widgets reference as {{name}} in their NRQL queries.
+func dashboardVariableSchemaElem() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The variable identifier.",
+			},
+			"title": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The human-friendly display string for this variable.",
+			},
+			"type": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringInSlice([]string{"enum", "nrql", "string"}, false),
+				Description:  "Specifies the data type of the variable and where its possible values may come from. Valid values are enum, nrql, string.",
+			},
+			"default_values": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Default values for this variable.",
+			},
+			"is_multi_selection": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: "Indicates whether this variable supports multiple selection or not. Only applies to variables of type nrql or enum.",
+			},
+			"item": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "List of possible values for variables of type enum.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"title": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "A human-friendly display string for this value.",
+						},
+						"value": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "A possible variable value.",
+						},
+					},
+				},
+			},
+			"nrql_query": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Description: "Configuration for variables of type nrql.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"account_ids": {
+							Type:        schema.TypeList,
+							Required:    true,
+							MinItems:    1,
+							Elem:        &schema.Schema{Type: schema.TypeInt},
+							Description: "New Relic account ID(s) to issue the query against.",
+						},
+						"query": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "NRQL formatted query.",
+						},
+					},
+				},
+			},
+			"replacement_strategy": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.StringInSlice([]string{"default", "identifier", "number", "string"}, false),
+				Description:  "Indicates the strategy to apply when replacing a variable in a NRQL query. Valid values are default, identifier, number, string.",
+			},
+		},
+	}
+}
+
 // dashboardPageElem returns the schema for a New Relic dashboard Page
 func dashboardPageSchemaElem() *schema.Resource {
 	return &schema.Resource{
 		Schema: map[string]*schema.Schema{
+			"account_id": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Description: "The default account ID for the NRQL queries of the page's widgets.",
+			},
 			"description": {
 				Type:        schema.TypeString,
 				Optional:    true,
@@ -91,7 +194,7 @@ func dashboardPageSchemaElem() *schema.Resource {
 				Type:        schema.TypeList,
 				Optional:    true,
 				Description: "An area widget.",
-				Elem:        dashboardWidgetGraphSchemaElem(),
+				Elem:        dashboardWidgetAreaSchemaElem(),
 			},
 			"widget_bar": {
 				Type:        schema.TypeList,
@@ -105,12 +208,48 @@ func dashboardPageSchemaElem() *schema.Resource {
 				Description: "A billboard widget.",
 				Elem:        dashboardWidgetBillboardSchemaElem(),
 			},
+			"widget_bullet": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "A bullet widget.",
+				Elem:        dashboardWidgetBulletSchemaElem(),
+			},
+			"widget_funnel": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "A funnel widget.",
+				Elem:        dashboardWidgetFacetSchemaElem(),
+			},
+			"widget_heatmap": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "A heatmap widget.",
+				Elem:        dashboardWidgetFacetSchemaElem(),
+			},
+			"widget_histogram": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "A histogram widget.",
+				Elem:        dashboardWidgetFacetSchemaElem(),
+			},
+			"widget_json": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "A JSON widget.",
+				Elem:        dashboardWidgetGraphSchemaElem(),
+			},
 			"widget_line": {
 				Type:        schema.TypeList,
 				Optional:    true,
 				Description: "A line widget.",
 				Elem:        dashboardWidgetLineSchemaElem(),
 			},
+			"widget_log_table": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "A log table widget.",
+				Elem:        dashboardWidgetGraphSchemaElem(),
+			},
 			"widget_markdown": {
 				Type:        schema.TypeList,
 				Optional:    true,
@@ -123,11 +262,23 @@ func dashboardPageSchemaElem() *schema.Resource {
 				Description: "A pie widget.",
 				Elem:        dashboardWidgetPieSchemaElem(),
 			},
+			"widget_stacked_bar": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "A stacked bar widget.",
+				Elem:        dashboardWidgetStackedBarSchemaElem(),
+			},
 			"widget_table": {
 				Type:        schema.TypeList,
 				Optional:    true,
 				Description: "A table widget.",
-				Elem:        dashboardWidgetGraphSchemaElem(),
+				Elem:        dashboardWidgetTableSchemaElem(),
+			},
+			"widget_viz": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "A widget of any visualization, configured with raw JSON.",
+				Elem:        dashboardWidgetVizSchemaElem(),
 			},
 		},
 	}
@@ -175,9 +326,16 @@ func dashboardWidgetNRQLQuerySchemaElem() *schema.Resource {
 	return &schema.Resource{
 		Schema: map[string]*schema.Schema{
 			"account_id": {
-				Type:        schema.TypeInt,
-				Required:    true,
-				Description: "The account id used for the NRQL query.",
+				Type:             schema.TypeInt,
+				Optional:         true,
+				DiffSuppressFunc: suppressDefaultDashboardNRQLQueryAccountID,
+				Description:      "The account id used for the NRQL query. Defaults to the account of the page, or of the dashboard.",
+			},
+			"account_ids": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeInt},
+				Description: "The account ids used for a NRQL query that spans several accounts.",
 			},
 			"query": {
 				Type:        schema.TypeString,
@@ -203,6 +361,92 @@ func dashboardWidgetGraphSchemaElem() *schema.Resource {
 	}
 }
 
+// dashboardWidgetFacetSchemaElem is the schema of the widgets without a typed
+// configuration whose facets can filter the dashboard.
+func dashboardWidgetFacetSchemaElem() *schema.Resource {
+	s := dashboardWidgetGraphSchemaElem().Schema
+
+	s["linked_entity_guids"] = dashboardWidgetLinkedEntityGUIDsSchema()
+	s["filter_current_dashboard"] = dashboardWidgetFilterCurrentDashboardSchema()
+	s["linked_page"] = dashboardWidgetLinkedPageSchema()
+
+	return &schema.Resource{
+		Schema: s,
+	}
+}
+
+func dashboardWidgetAreaSchemaElem() *schema.Resource {
+	s := dashboardWidgetSchemaBase()
+
+	s["nrql_query"] = &schema.Schema{
+		Type:     schema.TypeList,
+		Required: true,
+		Elem:     dashboardWidgetNRQLQuerySchemaElem(),
+	}
+
+	s["linked_entity_guids"] = dashboardWidgetLinkedEntityGUIDsSchema()
+	s["filter_current_dashboard"] = dashboardWidgetFilterCurrentDashboardSchema()
+	s["linked_page"] = dashboardWidgetLinkedPageSchema()
+
+	s["legend_enabled"] = dashboardWidgetLegendEnabledSchema()
+	s["y_axis_left_min"] = dashboardWidgetYAxisLeftSchema("minimum")
+	s["y_axis_left_max"] = dashboardWidgetYAxisLeftSchema("maximum")
+	s["null_values"] = dashboardWidgetNullValuesSchema()
+	s["units"] = dashboardWidgetUnitsSchema()
+	s["colors"] = dashboardWidgetColorsSchema()
+
+	return &schema.Resource{
+		Schema: s,
+	}
+}
+
+func dashboardWidgetStackedBarSchemaElem() *schema.Resource {
+	s := dashboardWidgetSchemaBase()
+
+	s["nrql_query"] = &schema.Schema{
+		Type:     schema.TypeList,
+		Required: true,
+		Elem:     dashboardWidgetNRQLQuerySchemaElem(),
+	}
+
+	s["linked_entity_guids"] = dashboardWidgetLinkedEntityGUIDsSchema()
+	s["filter_current_dashboard"] = dashboardWidgetFilterCurrentDashboardSchema()
+	s["linked_page"] = dashboardWidgetLinkedPageSchema()
+
+	s["legend_enabled"] = dashboardWidgetLegendEnabledSchema()
+	s["y_axis_left_min"] = dashboardWidgetYAxisLeftSchema("minimum")
+	s["y_axis_left_max"] = dashboardWidgetYAxisLeftSchema("maximum")
+	s["null_values"] = dashboardWidgetNullValuesSchema()
+	s["units"] = dashboardWidgetUnitsSchema()
+	s["colors"] = dashboardWidgetColorsSchema()
+
+	return &schema.Resource{
+		Schema: s,
+	}
+}
+
+func dashboardWidgetTableSchemaElem() *schema.Resource {
+	s := dashboardWidgetSchemaBase()
+
+	s["nrql_query"] = &schema.Schema{
+		Type:     schema.TypeList,
+		Required: true,
+		Elem:     dashboardWidgetNRQLQuerySchemaElem(),
+	}
+
+	s["linked_entity_guids"] = dashboardWidgetLinkedEntityGUIDsSchema()
+	s["filter_current_dashboard"] = dashboardWidgetFilterCurrentDashboardSchema()
+	s["linked_page"] = dashboardWidgetLinkedPageSchema()
+
+	s["null_values"] = dashboardWidgetNullValuesSchema()
+	s["units"] = dashboardWidgetUnitsSchema()
+	s["colors"] = dashboardWidgetColorsSchema()
+
+	return &schema.Resource{
+		Schema: s,
+	}
+}
+
 func dashboardWidgetBarSchemaElem() *schema.Resource {
 	s := dashboardWidgetSchemaBase()
 
@@ -213,6 +457,12 @@ func dashboardWidgetBarSchemaElem() *schema.Resource {
 	}
 
 	s["linked_entity_guids"] = dashboardWidgetLinkedEntityGUIDsSchema()
+	s["filter_current_dashboard"] = dashboardWidgetFilterCurrentDashboardSchema()
+	s["linked_page"] = dashboardWidgetLinkedPageSchema()
+
+	s["legend_enabled"] = dashboardWidgetLegendEnabledSchema()
+	s["units"] = dashboardWidgetUnitsSchema()
+	s["colors"] = dashboardWidgetColorsSchema()
 
 	return &schema.Resource{
 		Schema: s,
@@ -229,6 +479,15 @@ func dashboardWidgetLineSchemaElem() *schema.Resource {
 	}
 
 	s["linked_entity_guids"] = dashboardWidgetLinkedEntityGUIDsSchema()
+	s["filter_current_dashboard"] = dashboardWidgetFilterCurrentDashboardSchema()
+	s["linked_page"] = dashboardWidgetLinkedPageSchema()
+
+	s["legend_enabled"] = dashboardWidgetLegendEnabledSchema()
+	s["y_axis_left_min"] = dashboardWidgetYAxisLeftSchema("minimum")
+	s["y_axis_left_max"] = dashboardWidgetYAxisLeftSchema("maximum")
+	s["null_values"] = dashboardWidgetNullValuesSchema()
+	s["units"] = dashboardWidgetUnitsSchema()
+	s["colors"] = dashboardWidgetColorsSchema()
 
 	return &schema.Resource{
 		Schema: s,
@@ -245,6 +504,12 @@ func dashboardWidgetPieSchemaElem() *schema.Resource {
 	}
 
 	s["linked_entity_guids"] = dashboardWidgetLinkedEntityGUIDsSchema()
+	s["filter_current_dashboard"] = dashboardWidgetFilterCurrentDashboardSchema()
+	s["linked_page"] = dashboardWidgetLinkedPageSchema()
+
+	s["legend_enabled"] = dashboardWidgetLegendEnabledSchema()
+	s["units"] = dashboardWidgetUnitsSchema()
+	s["colors"] = dashboardWidgetColorsSchema()
 
 	return &schema.Resource{
 		Schema: s,
@@ -272,11 +537,95 @@ func dashboardWidgetBillboardSchemaElem() *schema.Resource {
 		Description: "The warning threshold value.",
 	}
 
+	s["null_values"] = dashboardWidgetNullValuesSchema()
+	s["units"] = dashboardWidgetUnitsSchema()
+	s["colors"] = dashboardWidgetColorsSchema()
+
+	return &schema.Resource{
+		Schema: s,
+	}
+}
+
+func dashboardWidgetBulletSchemaElem() *schema.Resource {
+	s := dashboardWidgetSchemaBase()
+
+	s["nrql_query"] = &schema.Schema{
+		Type:     schema.TypeList,
+		Required: true,
+		Elem:     dashboardWidgetNRQLQuerySchemaElem(),
+	}
+
+	s["limit"] = &schema.Schema{
+		Type:        schema.TypeFloat,
+		Required:    true,
+		Description: "The maximum value of the bullet's range.",
+	}
+
+	return &schema.Resource{
+		Schema: s,
+	}
+}
+
+// dashboardWidgetVizSchemaElem allows any visualization to be used, including
+// ones the provider has no dedicated widget block for.
+func dashboardWidgetVizSchemaElem() *schema.Resource {
+	s := dashboardWidgetSchemaBase()
+
+	s["visualization_id"] = &schema.Schema{
+		Type:         schema.TypeString,
+		Required:     true,
+		ValidateFunc: validateDashboardWidgetVisualizationID,
+		Description:  "The ID of the visualization, e.g. viz.event-feed or a custom Nerdpack visualization.",
+	}
+
+	s["configuration"] = &schema.Schema{
+		Type:             schema.TypeString,
+		Required:         true,
+		ValidateFunc:     validation.StringIsJSON,
+		DiffSuppressFunc: structure.SuppressJsonDiff,
+		Description:      "The widget's raw JSON configuration.",
+	}
+
 	return &schema.Resource{
 		Schema: s,
 	}
 }
 
+// dashboardTypedWidgetVisualizations lists the visualizations configured through
+// a typed widget block.
+var dashboardTypedWidgetVisualizations = []string{
+	"viz.area",
+	"viz.bar",
+	"viz.billboard",
+	"viz.line",
+	"viz.markdown",
+	"viz.pie",
+	"viz.table",
+}
+
+// validateDashboardWidgetVisualizationID rejects visualizations in widget_viz
+// that have their own widget block, as they would be read back into that block.
+func validateDashboardWidgetVisualizationID(v interface{}, k string) (ws []string, es []error) {
+	ws, es = validation.StringIsNotWhiteSpace(v, k)
+	if len(es) > 0 {
+		return
+	}
+
+	id := v.(string)
+	for _, typed := range dashboardTypedWidgetVisualizations {
+		if id == typed {
+			es = append(es, fmt.Errorf("%s: %s has a dedicated widget block, use widget_%s instead", k, id, strings.TrimPrefix(id, "viz.")))
+			return
+		}
+	}
+
+	if widgetType := dashboardRawWidgetType(id); widgetType != "widget_viz" {
+		es = append(es, fmt.Errorf("%s: %s has a dedicated widget block, use %s instead", k, id, widgetType))
+	}
+
+	return
+}
+
 func dashboardWidgetMarkdownSchemaElem() *schema.Resource {
 	s := dashboardWidgetSchemaBase()
 
@@ -302,6 +651,394 @@ func dashboardWidgetLinkedEntityGUIDsSchema() *schema.Schema {
 	}
 }
 
+func dashboardWidgetFilterCurrentDashboardSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeBool,
+		Optional:    true,
+		Default:     false,
+		Description: "Use this widget's facets to filter the current dashboard page.",
+	}
+}
+
+func dashboardWidgetLinkedPageSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:         schema.TypeString,
+		Optional:     true,
+		ValidateFunc: validation.StringIsNotWhiteSpace,
+		Description:  "The name of another page of the dashboard that this widget's facets filter.",
+	}
+}
+
+// legacyDashboardIDPrefix marks the ID of a newrelic_one_dashboard that was
+// imported from a legacy dashboard and has not been applied yet.
+const legacyDashboardIDPrefix = "legacy:"
+
+// legacyDashboardID returns the ID of the legacy dashboard the resource was
+// imported from, if any.
+func legacyDashboardID(id string) (int, bool) {
+	if !strings.HasPrefix(id, legacyDashboardIDPrefix) {
+		return 0, false
+	}
+
+	dashboardID, err := strconv.Atoi(strings.TrimPrefix(id, legacyDashboardIDPrefix))
+	if err != nil {
+		return 0, false
+	}
+
+	return dashboardID, true
+}
+
+func resourceNewRelicOneDashboardImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	if strings.HasPrefix(d.Id(), legacyDashboardIDPrefix) {
+		if _, ok := legacyDashboardID(d.Id()); !ok {
+			return nil, fmt.Errorf("invalid legacy dashboard ID %q, expected %s<dashboard_id>", d.Id(), legacyDashboardIDPrefix)
+		}
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
+
+// suppressDefaultDashboardNRQLQueryAccountID suppresses the diff of a query
+// that sets no account_id when the account it defaulted to is unchanged.
+func suppressDefaultDashboardNRQLQueryAccountID(k, old, new string, d *schema.ResourceData) bool {
+	if new != "" && new != "0" {
+		return false
+	}
+
+	accountID := d.Get("account_id").(int)
+
+	// k is page.<index>.widget_<type>.<index>.nrql_query.<index>.account_id
+	if parts := strings.Split(k, "."); len(parts) > 1 {
+		if pageAccountID := d.Get(fmt.Sprintf("page.%s.account_id", parts[1])).(int); pageAccountID != 0 {
+			accountID = pageAccountID
+		}
+	}
+
+	return old == strconv.Itoa(accountID)
+}
+
+// A dashboard imported from a legacy dashboard is created in New Relic One on
+// the next apply, so the plan always shows an update for it.
+func resourceNewRelicOneDashboardDiff(d *schema.ResourceDiff, meta interface{}) error {
+	if err := validateDashboardPageLinks(d); err != nil {
+		return err
+	}
+
+	if err := validateDashboardAccountIDs(d, meta); err != nil {
+		return err
+	}
+
+	if _, ok := legacyDashboardID(d.Id()); !ok {
+		return nil
+	}
+
+	if err := d.SetNewComputed("guid"); err != nil {
+		return err
+	}
+
+	return d.SetNewComputed("permalink")
+}
+
+// validateDashboardPageLinks checks that a widget filters at most one target,
+// and that linked_page names another page of the dashboard.
+func validateDashboardPageLinks(d *schema.ResourceDiff) error {
+	pages := d.Get("page").([]interface{})
+	names := map[string]bool{}
+
+	for _, p := range pages {
+		names[p.(map[string]interface{})["name"].(string)] = true
+	}
+
+	for i, p := range pages {
+		for widgetType, widgets := range p.(map[string]interface{}) {
+			if !strings.HasPrefix(widgetType, "widget_") {
+				continue
+			}
+
+			for j, v := range widgets.([]interface{}) {
+				w := v.(map[string]interface{})
+				guids, _ := w["linked_entity_guids"].([]interface{})
+				filter, _ := w["filter_current_dashboard"].(bool)
+				linkedPage, _ := w["linked_page"].(string)
+
+				targets := 0
+				for _, set := range []bool{len(guids) > 0, filter, linkedPage != ""} {
+					if set {
+						targets++
+					}
+				}
+
+				if targets > 1 {
+					return fmt.Errorf("page.%d.%s.%d: only one of linked_entity_guids, filter_current_dashboard or linked_page can be set", i, widgetType, j)
+				}
+
+				if linkedPage != "" && !names[linkedPage] {
+					return fmt.Errorf("page.%d.%s.%d: linked_page %q is not a page of the dashboard", i, widgetType, j, linkedPage)
+				}
+
+				if linkedPage != "" && linkedPage == p.(map[string]interface{})["name"] {
+					return fmt.Errorf("page.%d.%s.%d: linked_page %q is the page of the widget, use filter_current_dashboard instead", i, widgetType, j, linkedPage)
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// validateDashboardAccountIDs checks that the queries of a dashboard set at
+// most one of account_id and account_ids, and that the provider can access the
+// accounts they add. Accounts with their own credentials in an account block
+// are not checked.
+func validateDashboardAccountIDs(d *schema.ResourceDiff, meta interface{}) error {
+	if !d.HasChange("account_id") && !d.HasChange("page") && !d.HasChange("variable") {
+		return nil
+	}
+
+	providerConfig := meta.(*ProviderConfig)
+
+	oldAccountID, newAccountID := d.GetChange("account_id")
+	oldPages, newPages := d.GetChange("page")
+	oldVariables, newVariables := d.GetChange("variable")
+
+	accountIDs, err := dashboardAccountIDs(newAccountID.(int), newPages.([]interface{}), newVariables.([]interface{}))
+	if err != nil {
+		return err
+	}
+
+	// Only the accounts the change adds are checked
+	if oldAccountIDs, err := dashboardAccountIDs(oldAccountID.(int), oldPages.([]interface{}), oldVariables.([]interface{})); err == nil {
+		for id := range oldAccountIDs {
+			delete(accountIDs, id)
+		}
+	}
+
+	delete(accountIDs, 0)
+	delete(accountIDs, providerConfig.AccountID)
+
+	for id := range providerConfig.accountClients {
+		delete(accountIDs, id)
+	}
+
+	listed := map[*nr.NewRelic]bool{}
+	for id := range accountIDs {
+		client := providerConfig.client(id)
+		if listed[client] {
+			continue
+		}
+		listed[client] = true
+
+		var accts []accounts.AccountOutline
+		err := providerRetryPolicy(meta).retry(defaultResourceTimeout, isRetryableError, func() (err error) {
+			accts, err = client.Accounts.ListAccounts(accounts.ListAccountsParams{})
+			return err
+		})
+		if err != nil {
+			return err
+		}
+
+		for _, a := range accts {
+			delete(accountIDs, a.ID)
+		}
+	}
+
+	if len(accountIDs) == 0 {
+		return nil
+	}
+
+	missing := make([]int, 0, len(accountIDs))
+	for id := range accountIDs {
+		missing = append(missing, id)
+	}
+	sort.Ints(missing)
+
+	return fmt.Errorf("the API key of the provider can not access account(s) %s", strings.Trim(fmt.Sprint(missing), "[]"))
+}
+
+// dashboardAccountIDs returns the accounts used by a dashboard, and checks that
+// its queries set at most one of account_id and account_ids.
+func dashboardAccountIDs(accountID int, pages []interface{}, variables []interface{}) (map[int]bool, error) {
+	accountIDs := map[int]bool{accountID: true}
+
+	addAccountIDs := func(ids []interface{}) {
+		for _, id := range ids {
+			accountIDs[id.(int)] = true
+		}
+	}
+
+	pageSchema := dashboardPageSchemaElem().Schema
+	for i, p := range pages {
+		page := p.(map[string]interface{})
+		accountIDs[page["account_id"].(int)] = true
+
+		for widgetType, s := range pageSchema {
+			widgetSchema, ok := s.Elem.(*schema.Resource)
+			if !ok || widgetSchema.Schema["nrql_query"] == nil {
+				continue
+			}
+
+			widgets, _ := page[widgetType].([]interface{})
+			for j, w := range widgets {
+				for k, q := range w.(map[string]interface{})["nrql_query"].([]interface{}) {
+					query := q.(map[string]interface{})
+					ids := query["account_ids"].([]interface{})
+
+					if query["account_id"].(int) != 0 && len(ids) > 0 {
+						return nil, fmt.Errorf("page.%d.%s.%d.nrql_query.%d: only one of account_id or account_ids can be set", i, widgetType, j, k)
+					}
+
+					accountIDs[query["account_id"].(int)] = true
+					addAccountIDs(ids)
+				}
+			}
+		}
+	}
+
+	for _, v := range variables {
+		for _, q := range v.(map[string]interface{})["nrql_query"].([]interface{}) {
+			if q != nil {
+				addAccountIDs(q.(map[string]interface{})["account_ids"].([]interface{}))
+			}
+		}
+	}
+
+	return accountIDs, nil
+}
+
+func dashboardWidgetLegendEnabledSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeBool,
+		Optional:    true,
+		Default:     true,
+		Description: "Whether the legend is shown.",
+	}
+}
+
+// The bounds of the Y axis are strings, as a float nested in a widget block is
+// read as 0 when it is not set, and 0 is a valid bound.
+func dashboardWidgetYAxisLeftSchema(bound string) *schema.Schema {
+	return &schema.Schema{
+		Type:             schema.TypeString,
+		Optional:         true,
+		ValidateFunc:     validateDashboardWidgetYAxisBound,
+		DiffSuppressFunc: suppressEquivalentDashboardWidgetYAxisBound,
+		Description:      fmt.Sprintf("The %s value of the left Y axis.", bound),
+	}
+}
+
+func validateDashboardWidgetYAxisBound(v interface{}, k string) (ws []string, es []error) {
+	if _, err := strconv.ParseFloat(v.(string), 64); err != nil {
+		es = append(es, fmt.Errorf("%s: %q is not a number", k, v))
+	}
+
+	return
+}
+
+func suppressEquivalentDashboardWidgetYAxisBound(k, old, new string, d *schema.ResourceData) bool {
+	oldBound := expandDashboardWidgetYAxisBound(old)
+	newBound := expandDashboardWidgetYAxisBound(new)
+
+	if oldBound == nil || newBound == nil {
+		return oldBound == newBound
+	}
+
+	return *oldBound == *newBound
+}
+
+// dashboardWidgetSeriesOverridesSchema returns the schema for the per-series
+// overrides of a widget option, each of which sets the option's value for the
+// named series.
+func dashboardWidgetSeriesOverridesSchema(option string, valueSchema *schema.Schema) *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Optional:    true,
+		Description: fmt.Sprintf("Overrides the %s of individual series.", option),
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"series_name": {
+					Type:        schema.TypeString,
+					Required:    true,
+					Description: "The name of the series.",
+				},
+				option: valueSchema,
+			},
+		},
+	}
+}
+
+func dashboardWidgetNullValuesSchema() *schema.Schema {
+	nullValue := func(required bool) *schema.Schema {
+		return &schema.Schema{
+			Type:         schema.TypeString,
+			Optional:     !required,
+			Required:     required,
+			ValidateFunc: validation.StringInSlice([]string{"default", "preserve", "remove", "zero"}, false),
+			Description:  "How null values are displayed. Valid values are default, preserve, remove, zero.",
+		}
+	}
+
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Optional:    true,
+		MaxItems:    1,
+		Description: "How null values are displayed.",
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"null_value":       nullValue(false),
+				"series_overrides": dashboardWidgetSeriesOverridesSchema("null_value", nullValue(true)),
+			},
+		},
+	}
+}
+
+func dashboardWidgetUnitsSchema() *schema.Schema {
+	unit := func(required bool) *schema.Schema {
+		return &schema.Schema{
+			Type:        schema.TypeString,
+			Optional:    !required,
+			Required:    required,
+			Description: "The unit values are displayed in, e.g. MS, BYTES or PERCENTAGE.",
+		}
+	}
+
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Optional:    true,
+		MaxItems:    1,
+		Description: "The units values are displayed in.",
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"unit":             unit(false),
+				"series_overrides": dashboardWidgetSeriesOverridesSchema("unit", unit(true)),
+			},
+		},
+	}
+}
+
+func dashboardWidgetColorsSchema() *schema.Schema {
+	color := func(required bool) *schema.Schema {
+		return &schema.Schema{
+			Type:        schema.TypeString,
+			Optional:    !required,
+			Required:    required,
+			Description: "A color, e.g. #722727.",
+		}
+	}
+
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Optional:    true,
+		MaxItems:    1,
+		Description: "The colors series are drawn in.",
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"color":            color(false),
+				"series_overrides": dashboardWidgetSeriesOverridesSchema("color", color(true)),
+			},
+		},
+	}
+}
+
 func resourceNewRelicOneDashboardCreate(d *schema.ResourceData, meta interface{}) error {
 	providerConfig := meta.(*ProviderConfig)
 
@@ -309,24 +1046,28 @@ func resourceNewRelicOneDashboardCreate(d *schema.ResourceData, meta interface{}
 		return fmt.Errorf("err: NerdGraph support not present, but required for Create")
 	}
 
-	client := providerConfig.NewClient
+	client := accountClient(providerConfig, d)
 	accountID := selectAccountID(providerConfig, d)
 
-	dashboard, err := expandDashboardInput(d)
+	dashboard, err := expandDashboardInput(d, accountID)
 	if err != nil {
 		return err
 	}
 
 	log.Printf("[INFO] Creating New Relic One dashboard: %s", dashboard.Name)
 
-	created, err := client.Dashboards.DashboardCreate(accountID, *dashboard)
+	created, err := createDashboard(client, accountID, *dashboard)
 	if err != nil {
 		return err
 	}
 	guid := created.EntityResult.GUID
 	d.SetId(string(guid))
 
-	return resourceNewRelicOneDashboardRead(d, meta)
+	if _, err := resourceNewRelicOneDashboardUpdatePageLinks(d, meta, dashboard, accountID, created.EntityResult.Pages); err != nil {
+		return err
+	}
+
+	return readAfterWrite(resourceNewRelicOneDashboardRead, d, meta)
 }
 
 // resourceNewRelicOneDashboardRead NerdGraph => Terraform reader
@@ -337,11 +1078,38 @@ func resourceNewRelicOneDashboardRead(d *schema.ResourceData, meta interface{})
 		return fmt.Errorf("err: NerdGraph support not present, but required for Read")
 	}
 
-	client := providerConfig.NewClient
+	client := accountClient(providerConfig, d)
 
 	log.Printf("[INFO] Reading New Relic One dashboard %s", d.Id())
 
-	dashboard, err := client.Dashboards.GetDashboardEntity(entities.EntityGUID(d.Id()))
+	if legacyID, ok := legacyDashboardID(d.Id()); ok {
+		return resourceNewRelicOneDashboardReadLegacy(d, meta, legacyID)
+	}
+
+	dashboard, err := getDashboardEntity(client, entities.EntityGUID(d.Id()))
+	if err != nil {
+		if _, ok := err.(*errors.NotFound); ok {
+			d.SetId("")
+			return nil
+		}
+
+		return err
+	}
+
+	return flattenDashboardEntity(dashboard, d)
+}
+
+// resourceNewRelicOneDashboardReadLegacy reads a legacy dashboard into the
+// state as its New Relic One equivalent.
+func resourceNewRelicOneDashboardReadLegacy(d *schema.ResourceData, meta interface{}, legacyID int) error {
+	providerConfig := meta.(*ProviderConfig)
+
+	client, err := restAccountClient(providerConfig, d)
+	if err != nil {
+		return err
+	}
+
+	legacy, err := client.Dashboards.GetDashboard(legacyID)
 	if err != nil {
 		if _, ok := err.(*errors.NotFound); ok {
 			d.SetId("")
@@ -351,6 +1119,11 @@ func resourceNewRelicOneDashboardRead(d *schema.ResourceData, meta interface{})
 		return err
 	}
 
+	dashboard, err := convertLegacyDashboard(legacy, selectAccountID(providerConfig, d))
+	if err != nil {
+		return err
+	}
+
 	return flattenDashboardEntity(dashboard, d)
 }
 
@@ -361,27 +1134,87 @@ func resourceNewRelicOneDashboardUpdate(d *schema.ResourceData, meta interface{}
 		return fmt.Errorf("err: NerdGraph support not present, but required for Update")
 	}
 
-	client := providerConfig.NewClient
+	client := accountClient(providerConfig, d)
+	accountID := selectAccountID(providerConfig, d)
 
-	dashboard, err := expandDashboardInput(d)
+	dashboard, err := expandDashboardInput(d, accountID)
 	if err != nil {
 		return err
 	}
 
+	// The legacy dashboard is left in place, it is up to the user to remove
+	// it once the New Relic One dashboard has been created.
+	if _, ok := legacyDashboardID(d.Id()); ok {
+		log.Printf("[INFO] Creating New Relic One dashboard from %s", d.Id())
+
+		created, err := createDashboard(client, accountID, *dashboard)
+		if err != nil {
+			return err
+		}
+		d.SetId(string(created.EntityResult.GUID))
+
+		if _, err := resourceNewRelicOneDashboardUpdatePageLinks(d, meta, dashboard, accountID, created.EntityResult.Pages); err != nil {
+			return err
+		}
+
+		return readAfterWrite(resourceNewRelicOneDashboardRead, d, meta)
+	}
+
 	log.Printf("[INFO] Updating New Relic One dashboard '%s' (%s)", dashboard.Name, d.Id())
 
-	result, err := client.Dashboards.DashboardUpdate(*dashboard, entities.EntityGUID(d.Id()))
+	result, err := updateDashboard(client, entities.EntityGUID(d.Id()), *dashboard)
+	if err != nil {
+		return err
+	}
+
+	linked, err := resourceNewRelicOneDashboardUpdatePageLinks(d, meta, dashboard, accountID, result.EntityResult.Pages)
 	if err != nil {
 		return err
 	}
+	if linked != nil {
+		result = linked
+	}
 
 	// We have to use the Update Result, not a re-read of the entity as the changes take
 	// some amount of time to be re-indexed
 	return flattenDashboardUpdateResult(result, d)
 }
 
+// resourceNewRelicOneDashboardUpdatePageLinks links the widgets that filter
+// pages of the dashboard which did not exist before the dashboard was saved,
+// as their GUIDs are only known once they have been created.
+func resourceNewRelicOneDashboardUpdatePageLinks(d *schema.ResourceData, meta interface{}, dashboard *dashboardInput, accountID int, saved []entities.DashboardPage) (*dashboards.DashboardUpdateResult, error) {
+	client := entityClient(meta.(*ProviderConfig), d.Id())
+	pages := d.Get("page").([]interface{})
+
+	if !dashboardPageLinksPending(pages) || len(pages) != len(saved) {
+		return nil, nil
+	}
+
+	for i, p := range saved {
+		pages[i].(map[string]interface{})["guid"] = string(p.GUID)
+	}
+
+	var err error
+	dashboard.Pages, err = expandDashboardPageInput(pages, accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Printf("[INFO] Linking the pages of New Relic One dashboard %s", d.Id())
+
+	return updateDashboard(client, entities.EntityGUID(d.Id()), *dashboard)
+}
+
 func resourceNewRelicOneDashboardDelete(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*ProviderConfig).NewClient
+	client := accountClient(meta.(*ProviderConfig), d)
+
+	// The dashboard has not been created in New Relic One yet, and the legacy
+	// dashboard it was imported from is left in place.
+	if _, ok := legacyDashboardID(d.Id()); ok {
+		log.Printf("[WARN] Removing New Relic One dashboard %s from the state, the legacy dashboard is left in place", d.Id())
+		return nil
+	}
 
 	log.Printf("[INFO] Deleting New Relic One dashboard %v", d.Id())
 
@@ -0,0 +1,201 @@
+package newrelic
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/newrelic/newrelic-client-go/pkg/entities"
+	"github.com/newrelic/newrelic-client-go/pkg/errors"
+)
+
+func resourceNewRelicOneDashboardJSON() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceNewRelicOneDashboardJSONCreate,
+		Read:   resourceNewRelicOneDashboardJSONRead,
+		Update: resourceNewRelicOneDashboardJSONUpdate,
+		Delete: resourceNewRelicOneDashboardJSONDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+		Schema: map[string]*schema.Schema{
+			// Required
+			"json": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateFunc:     validation.StringIsJSON,
+				DiffSuppressFunc: suppressEquivalentDashboardJSON,
+				Description:      "The dashboard's JSON, as exported from the New Relic One UI.",
+			},
+			// Optional
+			"account_id": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "The New Relic account ID where you want to create the dashboard.",
+			},
+			// Computed
+			"guid": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The unique entity identifier of the dashboard in New Relic.",
+			},
+			"permalink": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The URL of the dashboard.",
+			},
+		},
+	}
+}
+
+func resourceNewRelicOneDashboardJSONCreate(d *schema.ResourceData, meta interface{}) error {
+	providerConfig := meta.(*ProviderConfig)
+
+	if !providerConfig.hasNerdGraphCredentials() {
+		return fmt.Errorf("err: NerdGraph support not present, but required for Create")
+	}
+
+	client := accountClient(providerConfig, d)
+	accountID := selectAccountID(providerConfig, d)
+
+	dashboard, linksPending, err := expandDashboardJSON(d.Get("json").(string), accountID, nil)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[INFO] Creating New Relic One dashboard from JSON: %v", dashboard["name"])
+
+	created, err := createDashboard(client, accountID, dashboard)
+	if err != nil {
+		return err
+	}
+	d.SetId(string(created.EntityResult.GUID))
+
+	if linksPending {
+		if err := resourceNewRelicOneDashboardJSONUpdatePageLinks(d, meta, created.EntityResult.Pages); err != nil {
+			return err
+		}
+	}
+
+	return readAfterWrite(resourceNewRelicOneDashboardJSONRead, d, meta)
+}
+
+func resourceNewRelicOneDashboardJSONRead(d *schema.ResourceData, meta interface{}) error {
+	providerConfig := meta.(*ProviderConfig)
+
+	if !providerConfig.hasNerdGraphCredentials() {
+		return fmt.Errorf("err: NerdGraph support not present, but required for Read")
+	}
+
+	client := accountClient(providerConfig, d)
+
+	log.Printf("[INFO] Reading New Relic One dashboard %s", d.Id())
+
+	dashboard, err := getDashboardJSON(client, entities.EntityGUID(d.Id()))
+	if err != nil {
+		if _, ok := err.(*errors.NotFound); ok {
+			d.SetId("")
+			return nil
+		}
+
+		return err
+	}
+
+	return flattenDashboardJSONEntity(dashboard, d)
+}
+
+func flattenDashboardJSONEntity(dashboard map[string]interface{}, d *schema.ResourceData) error {
+	d.Set("account_id", dashboard["accountId"])
+	d.Set("guid", dashboard["guid"])
+	d.Set("permalink", dashboard["permalink"])
+
+	dashboardJSON, err := flattenDashboardJSON(dashboard)
+	if err != nil {
+		return err
+	}
+
+	// Keep the JSON as it was written unless the dashboard has changed, so
+	// that the formatting of the configuration is preserved.
+	if suppressEquivalentDashboardJSON("json", d.Get("json").(string), dashboardJSON, d) {
+		return nil
+	}
+
+	return d.Set("json", dashboardJSON)
+}
+
+func resourceNewRelicOneDashboardJSONUpdate(d *schema.ResourceData, meta interface{}) error {
+	providerConfig := meta.(*ProviderConfig)
+
+	if !providerConfig.hasNerdGraphCredentials() {
+		return fmt.Errorf("err: NerdGraph support not present, but required for Update")
+	}
+
+	client := accountClient(providerConfig, d)
+	guid := entities.EntityGUID(d.Id())
+
+	current, err := getDashboardJSON(client, guid)
+	if err != nil {
+		return err
+	}
+
+	dashboard, linksPending, err := expandDashboardJSON(d.Get("json").(string), selectAccountID(providerConfig, d), flattenDashboardJSONPageGUIDs(current))
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[INFO] Updating New Relic One dashboard '%v' (%s)", dashboard["name"], d.Id())
+
+	// As with newrelic_one_dashboard, the dashboard is not read back as the
+	// changes take some time to be re-indexed.
+	updated, err := updateDashboard(client, guid, dashboard)
+	if err != nil {
+		return err
+	}
+
+	if linksPending {
+		return resourceNewRelicOneDashboardJSONUpdatePageLinks(d, meta, updated.EntityResult.Pages)
+	}
+
+	return nil
+}
+
+// resourceNewRelicOneDashboardJSONUpdatePageLinks links the widgets to pages
+// of the dashboard which did not exist before the dashboard was saved, as
+// their GUIDs are only known once they have been created.
+func resourceNewRelicOneDashboardJSONUpdatePageLinks(d *schema.ResourceData, meta interface{}, saved []entities.DashboardPage) error {
+	providerConfig := meta.(*ProviderConfig)
+
+	pageGUIDs := make([]string, len(saved))
+	for i, p := range saved {
+		pageGUIDs[i] = string(p.GUID)
+	}
+
+	dashboard, _, err := expandDashboardJSON(d.Get("json").(string), selectAccountID(providerConfig, d), pageGUIDs)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[INFO] Linking the pages of New Relic One dashboard %s", d.Id())
+
+	_, err = updateDashboard(accountClient(providerConfig, d), entities.EntityGUID(d.Id()), dashboard)
+
+	return err
+}
+
+func resourceNewRelicOneDashboardJSONDelete(d *schema.ResourceData, meta interface{}) error {
+	client := accountClient(meta.(*ProviderConfig), d)
+
+	log.Printf("[INFO] Deleting New Relic One dashboard %v", d.Id())
+
+	if _, err := client.Dashboards.DashboardDelete(entities.EntityGUID(d.Id())); err != nil {
+		if _, ok := err.(*errors.NotFound); ok {
+			return nil
+		}
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,356 @@
+// +build unit
+
+package newrelic
+
+import (
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+)
+
+func TestAccNewRelicOneDashboardJSON_Offline(t *testing.T) {
+	api := newFakeNewRelicAPI(t)
+	resourceName := "newrelic_one_dashboard_json.foo"
+	rName := fmt.Sprintf("tf-test-%s", acctest.RandString(5))
+	var pageGUID string
+
+	resource.ParallelTest(t, resource.TestCase{
+		IsUnitTest:   true,
+		Providers:    api.providers(),
+		CheckDestroy: api.checkDestroyed(fakeKindEntity),
+		Steps: []resource.TestStep{
+			// Test: Create
+			{
+				Config: api.config(testAccNewRelicOneDashboardJSONUnitConfig(testAccNewRelicOneDashboardJSONUnitDashboard(rName, "Throughput", "viz.line", true))),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(resourceName, "guid"),
+					resource.TestCheckResourceAttrSet(resourceName, "permalink"),
+					resource.TestCheckResourceAttr(resourceName, "account_id", strconv.Itoa(api.AccountID)),
+					testAccCheckNewRelicOneDashboardJSONUnitPage(api, resourceName, &pageGUID, "Throughput"),
+				),
+			},
+			// Test: Reordering widgets and keys does not cause a plan
+			{
+				Config:   api.config(testAccNewRelicOneDashboardJSONUnitConfig(testAccNewRelicOneDashboardJSONUnitReordered(rName))),
+				PlanOnly: true,
+			},
+			// Test: Update
+			{
+				Config: api.config(testAccNewRelicOneDashboardJSONUnitConfig(testAccNewRelicOneDashboardJSONUnitDashboard(rName, "Requests per minute", "viz.line", true))),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckNewRelicOneDashboardJSONUnitPage(api, resourceName, &pageGUID, "Requests per minute"),
+				),
+			},
+			// Test: Change the visualization
+			{
+				Config: api.config(testAccNewRelicOneDashboardJSONUnitConfig(testAccNewRelicOneDashboardJSONUnitDashboard(rName, "Requests per minute", "viz.area", true))),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckNewRelicOneDashboardJSONUnitWidget(api, resourceName, "viz.area", true),
+				),
+			},
+			// Test: Disable the legend
+			{
+				Config: api.config(testAccNewRelicOneDashboardJSONUnitConfig(testAccNewRelicOneDashboardJSONUnitDashboard(rName, "Requests per minute", "viz.area", false))),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckNewRelicOneDashboardJSONUnitWidget(api, resourceName, "viz.area", false),
+				),
+			},
+			// Test: Import
+			{
+				Config:                  api.config(testAccNewRelicOneDashboardJSONUnitConfig(testAccNewRelicOneDashboardJSONUnitDashboard(rName, "Requests per minute", "viz.area", false))),
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"json"},
+			},
+		},
+	})
+}
+
+func TestAccNewRelicOneDashboardJSON_OfflineLinks(t *testing.T) {
+	api := newFakeNewRelicAPI(t)
+	resourceName := "newrelic_one_dashboard_json.foo"
+	rName := fmt.Sprintf("tf-test-%s", acctest.RandString(5))
+
+	resource.ParallelTest(t, resource.TestCase{
+		IsUnitTest:   true,
+		Providers:    api.providers(),
+		CheckDestroy: api.checkDestroyed(fakeKindEntity),
+		Steps: []resource.TestStep{
+			// Test: Create
+			{
+				Config: api.config(testAccNewRelicOneDashboardJSONUnitConfig(testAccNewRelicOneDashboardJSONUnitLinked(rName, 1, fakeSubAccountID))),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckNewRelicOneDashboardJSONUnitLinks(api, resourceName, 1, fakeSubAccountID),
+				),
+			},
+			// Test: Link another page and query the provider's account
+			{
+				Config: api.config(testAccNewRelicOneDashboardJSONUnitConfig(testAccNewRelicOneDashboardJSONUnitLinked(rName, 2, api.AccountID))),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckNewRelicOneDashboardJSONUnitLinks(api, resourceName, 2, api.AccountID),
+				),
+			},
+			// Test: Query the other account again
+			{
+				Config: api.config(testAccNewRelicOneDashboardJSONUnitConfig(testAccNewRelicOneDashboardJSONUnitLinked(rName, 2, fakeSubAccountID))),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckNewRelicOneDashboardJSONUnitLinks(api, resourceName, 2, fakeSubAccountID),
+				),
+			},
+		},
+	})
+}
+
+// testAccCheckNewRelicOneDashboardJSONUnitLinks checks that the widget of the
+// first page links to the given page of the dashboard itself, not to the
+// dashboard it was exported from, and the account its query runs against.
+func testAccCheckNewRelicOneDashboardJSONUnitLinks(api *fakeNewRelicAPI, resourceName string, linkedPage int, queryAccountID int) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("not found: %s", resourceName)
+		}
+
+		dashboard := api.get(fakeKindEntity, rs.Primary.ID)
+		if dashboard == nil {
+			return fmt.Errorf("dashboard %s not found", rs.Primary.ID)
+		}
+
+		pages := fakeList(dashboard["pages"])
+		widget := fakeMap(fakeList(fakeMap(pages[0])["widgets"])[0])
+
+		linked := fakeList(widget["linkedEntities"])
+		if len(linked) != 1 {
+			return fmt.Errorf("expected 1 linked entity, got %d", len(linked))
+		}
+
+		if guid, expected := fakeMap(linked[0])["guid"], fakeMap(pages[linkedPage])["guid"]; guid != expected {
+			return fmt.Errorf("expected a link to page %s, got %s", expected, guid)
+		}
+
+		query := fakeMap(fakeList(fakeMap(widget["rawConfiguration"])["nrqlQueries"])[0])
+		if accountID := fakeInt(query["accountId"]); accountID != queryAccountID {
+			return fmt.Errorf("expected query account ID %d, got %d", queryAccountID, accountID)
+		}
+
+		return nil
+	}
+}
+
+// testAccNewRelicOneDashboardJSONUnitLinked returns an exported dashboard of
+// three pages, the first one linking to another page of the exported
+// dashboard.
+func testAccNewRelicOneDashboardJSONUnitLinked(dashboardName string, linkedPage int, queryAccountID int) string {
+	pageGUIDs := []string{"MTIzNDU2N3xWSVp8REFTSEJPQVJEfDEx", "MTIzNDU2N3xWSVp8REFTSEJPQVJEfDEy", "MTIzNDU2N3xWSVp8REFTSEJPQVJEfDEz"}
+
+	return fmt.Sprintf(`{
+  "name": "%[1]s",
+  "permissions": "PUBLIC_READ_WRITE",
+  "pages": [
+    {
+      "guid": "%[2]s",
+      "name": "Overview",
+      "widgets": [
+        {
+          "title": "Transactions",
+          "layout": { "column": 1, "row": 1, "width": 4, "height": 3 },
+          "linkedEntityGuids": ["%[5]s"],
+          "visualization": { "id": "viz.bar" },
+          "rawConfiguration": {
+            "facet": { "showOtherSeries": false },
+            "nrqlQueries": [
+              { "accountId": %[6]d, "query": "FROM Transaction SELECT count(*) FACET name" }
+            ]
+          }
+        }
+      ]
+    },
+    { "guid": "%[3]s", "name": "Errors", "widgets": [] },
+    { "guid": "%[4]s", "name": "Details", "widgets": [] }
+  ]
+}`, dashboardName, pageGUIDs[0], pageGUIDs[1], pageGUIDs[2], pageGUIDs[linkedPage], queryAccountID)
+}
+
+// testAccCheckNewRelicOneDashboardJSONUnitPage checks that the dashboard page
+// keeps its GUID across updates and that NRQL queries without an account run
+// against the provider's account.
+func testAccCheckNewRelicOneDashboardJSONUnitPage(api *fakeNewRelicAPI, resourceName string, pageGUID *string, widgetTitle string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("not found: %s", resourceName)
+		}
+
+		dashboard := api.get(fakeKindEntity, rs.Primary.ID)
+		if dashboard == nil {
+			return fmt.Errorf("dashboard %s not found", rs.Primary.ID)
+		}
+
+		page := fakeMap(fakeList(dashboard["pages"])[0])
+		if *pageGUID == "" {
+			*pageGUID = fakeString(page["guid"])
+		} else if fakeString(page["guid"]) != *pageGUID {
+			return fmt.Errorf("expected page GUID %s, got %s", *pageGUID, page["guid"])
+		}
+
+		widgets := fakeList(page["widgets"])
+		if title := fakeString(fakeMap(widgets[0])["title"]); title != widgetTitle {
+			return fmt.Errorf("expected widget title %q, got %q", widgetTitle, title)
+		}
+
+		for _, w := range widgets {
+			cfg := fakeMap(fakeMap(w)["rawConfiguration"])
+			for _, q := range fakeList(cfg["nrqlQueries"]) {
+				if accountID := fakeInt(fakeMap(q)["accountId"]); accountID != api.AccountID {
+					return fmt.Errorf("expected query account ID %d, got %d", api.AccountID, accountID)
+				}
+			}
+		}
+
+		return nil
+	}
+}
+
+// testAccCheckNewRelicOneDashboardJSONUnitWidget checks the visualization and
+// legend of the first widget of the dashboard.
+func testAccCheckNewRelicOneDashboardJSONUnitWidget(api *fakeNewRelicAPI, resourceName string, visualizationID string, legendEnabled bool) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("not found: %s", resourceName)
+		}
+
+		dashboard := api.get(fakeKindEntity, rs.Primary.ID)
+		if dashboard == nil {
+			return fmt.Errorf("dashboard %s not found", rs.Primary.ID)
+		}
+
+		widget := fakeMap(fakeList(fakeMap(fakeList(dashboard["pages"])[0])["widgets"])[0])
+		if id := fakeString(fakeMap(widget["visualization"])["id"]); id != visualizationID {
+			return fmt.Errorf("expected visualization %s, got %s", visualizationID, id)
+		}
+
+		legend := fakeMap(fakeMap(widget["rawConfiguration"])["legend"])
+		if enabled, _ := legend["enabled"].(bool); enabled != legendEnabled {
+			return fmt.Errorf("expected legend enabled %t, got %v", legendEnabled, legend["enabled"])
+		}
+
+		return nil
+	}
+}
+
+func testAccNewRelicOneDashboardJSONUnitConfig(dashboardJSON string) string {
+	return fmt.Sprintf(`
+resource "newrelic_one_dashboard_json" "foo" {
+	json = <<-EOT
+%s
+	EOT
+}
+`, dashboardJSON)
+}
+
+func testAccNewRelicOneDashboardJSONUnitDashboard(dashboardName string, widgetTitle string, visualizationID string, legendEnabled bool) string {
+	return fmt.Sprintf(`{
+  "name": "%[1]s",
+  "description": null,
+  "permissions": "PUBLIC_READ_WRITE",
+  "pages": [
+    {
+      "guid": "MTIzNDU2N3xWSVp8REFTSEJPQVJEfDk4NzY1",
+      "name": "%[1]s",
+      "description": null,
+      "widgets": [
+        {
+          "title": "%[2]s",
+          "layout": { "column": 1, "row": 1, "width": 4, "height": 3 },
+          "linkedEntityGuids": null,
+          "visualization": { "id": "%[3]s" },
+          "rawConfiguration": {
+            "legend": { "enabled": %[4]t },
+            "nrqlQueries": [
+              { "accountId": 0, "query": "FROM Transaction SELECT rate(count(*), 1 minute) TIMESERIES" }
+            ]
+          }
+        },
+        {
+          "title": "Notes",
+          "layout": { "column": 5, "row": 1, "width": 4, "height": 3 },
+          "linkedEntityGuids": null,
+          "visualization": { "id": "viz.markdown" },
+          "rawConfiguration": { "text": "# Notes" }
+        }
+      ]
+    }
+  ],
+  "variables": []
+}`, dashboardName, widgetTitle, visualizationID, legendEnabled)
+}
+
+func TestSuppressEquivalentDashboardJSON(t *testing.T) {
+	dashboard := testAccNewRelicOneDashboardJSONUnitDashboard("foo", "Throughput", "viz.line", true)
+
+	cases := map[string]struct {
+		old        string
+		new        string
+		equivalent bool
+	}{
+		"reordered":              {dashboard, testAccNewRelicOneDashboardJSONUnitReordered("foo"), true},
+		"other visualization":    {dashboard, testAccNewRelicOneDashboardJSONUnitDashboard("foo", "Throughput", "viz.area", true), false},
+		"legend disabled":        {dashboard, testAccNewRelicOneDashboardJSONUnitDashboard("foo", "Throughput", "viz.line", false), false},
+		"other widget title":     {dashboard, testAccNewRelicOneDashboardJSONUnitDashboard("foo", "Requests", "viz.line", true), false},
+		"other dashboard name":   {dashboard, testAccNewRelicOneDashboardJSONUnitDashboard("bar", "Throughput", "viz.line", true), false},
+		"false and omitted":      {`{"name": "foo", "pages": [{"widgets": [{"rawConfiguration": {"legend": {"enabled": false}}}]}]}`, `{"name": "foo", "pages": [{"widgets": [{}]}]}`, false},
+		"own query account":      {`{"pages": [{"widgets": [{"rawConfiguration": {"nrqlQueries": [{"accountId": 0, "query": "q"}]}}]}]}`, `{"pages": [{"widgets": [{"rawConfiguration": {"nrqlQueries": [{"accountId": 1, "query": "q"}]}}]}]}`, true},
+		"other query account":    {`{"pages": [{"widgets": [{"rawConfiguration": {"nrqlQueries": [{"accountId": 1, "query": "q"}]}}]}]}`, `{"pages": [{"widgets": [{"rawConfiguration": {"nrqlQueries": [{"accountId": 2, "query": "q"}]}}]}]}`, false},
+		"own variable account":   {`{"variables": [{"nrqlQuery": {"query": "q"}}]}`, `{"variables": [{"nrqlQuery": {"accountIds": [1], "query": "q"}}]}`, true},
+		"other variable account": {`{"variables": [{"nrqlQuery": {"accountIds": [1], "query": "q"}}]}`, `{"variables": [{"nrqlQuery": {"accountIds": [2], "query": "q"}}]}`, false},
+		"own page link":          {`{"pages": [{"guid": "a", "widgets": [{"linkedEntityGuids": ["b"]}]}, {"guid": "b"}]}`, `{"pages": [{"guid": "c", "widgets": [{"linkedEntityGuids": ["d"]}]}, {"guid": "d"}]}`, true},
+		"other page link":        {`{"pages": [{"guid": "a", "widgets": [{"linkedEntityGuids": ["b"]}]}, {"guid": "b"}]}`, `{"pages": [{"guid": "c", "widgets": [{"linkedEntityGuids": ["c"]}]}, {"guid": "d"}]}`, false},
+		"other entity link":      {`{"pages": [{"widgets": [{"linkedEntityGuids": ["x"]}]}]}`, `{"pages": [{"widgets": [{"linkedEntityGuids": ["y"]}]}]}`, false},
+	}
+
+	d := schema.TestResourceDataRaw(t, resourceNewRelicOneDashboardJSON().Schema, map[string]interface{}{"account_id": 1})
+
+	for name, c := range cases {
+		if equivalent := suppressEquivalentDashboardJSON("json", c.old, c.new, d); equivalent != c.equivalent {
+			t.Errorf("%s: expected equivalent to be %t, got %t", name, c.equivalent, equivalent)
+		}
+	}
+}
+
+func testAccNewRelicOneDashboardJSONUnitReordered(dashboardName string) string {
+	return fmt.Sprintf(`{
+  "permissions": "PUBLIC_READ_WRITE",
+  "name": "%[1]s",
+  "pages": [
+    {
+      "name": "%[1]s",
+      "widgets": [
+        {
+          "visualization": { "id": "viz.markdown" },
+          "rawConfiguration": { "text": "# Notes" },
+          "layout": { "row": 1, "column": 5, "height": 3, "width": 4 },
+          "title": "Notes"
+        },
+        {
+          "rawConfiguration": {
+            "nrqlQueries": [
+              { "query": "FROM Transaction SELECT rate(count(*), 1 minute) TIMESERIES" }
+            ],
+            "legend": { "enabled": true }
+          },
+          "title": "Throughput",
+          "visualization": { "id": "viz.line" },
+          "layout": { "height": 3, "width": 4, "row": 1, "column": 1 }
+        }
+      ]
+    }
+  ]
+}`, dashboardName)
+}
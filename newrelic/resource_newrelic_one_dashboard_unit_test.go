@@ -0,0 +1,1173 @@
+// +build unit
+
+package newrelic
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+	"github.com/newrelic/newrelic-client-go/pkg/dashboards"
+	"github.com/newrelic/newrelic-client-go/pkg/entities"
+)
+
+func TestAccNewRelicOneDashboard_Offline(t *testing.T) {
+	api := newFakeNewRelicAPI(t)
+	resourceName := "newrelic_one_dashboard.bar"
+	rName := fmt.Sprintf("tf-test-%s", acctest.RandString(5))
+	accountID := strconv.Itoa(api.AccountID)
+
+	resource.ParallelTest(t, resource.TestCase{
+		IsUnitTest:   true,
+		Providers:    api.providers(),
+		CheckDestroy: api.checkDestroyed(fakeKindDashboard),
+		Steps: []resource.TestStep{
+			// Test: Create
+			{
+				Config: api.config(testAccNewRelicOneDashboardUnitConfig(rName, "Page 1", accountID)),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "name", rName),
+					resource.TestCheckResourceAttr(resourceName, "page.#", "1"),
+					resource.TestCheckResourceAttrSet(resourceName, "guid"),
+					resource.TestCheckResourceAttrSet(resourceName, "permalink"),
+				),
+			},
+			// Test: Update
+			{
+				Config: api.config(testAccNewRelicOneDashboardUnitConfig(rName, "Page 1 Renamed", accountID)),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "page.0.name", "Page 1 Renamed"),
+				),
+			},
+			// Test: Import
+			{
+				Config:            api.config(testAccNewRelicOneDashboardUnitConfig(rName, "Page 1 Renamed", accountID)),
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccNewRelicOneDashboardUnitConfig(dashboardName string, pageName string, accountID string) string {
+	return fmt.Sprintf(`
+resource "newrelic_one_dashboard" "bar" {
+	name        = "%[1]s"
+	permissions = "private"
+
+	page {
+		name = "%[2]s"
+
+		widget_area {
+			title  = "area widget"
+			row    = 1
+			column = 1
+			height = 3
+			width  = 12
+
+			nrql_query {
+				account_id = %[3]s
+				query      = "FROM Transaction SELECT 51 TIMESERIES"
+			}
+		}
+
+		widget_bar {
+			title  = "bar widget"
+			row    = 4
+			column = 1
+
+			nrql_query {
+				account_id = %[3]s
+				query      = "FROM Transaction SELECT count(*) FACET name"
+			}
+
+			linked_entity_guids = ["MjUyMDUyOHxWSVp8REFTSEJPQVJEfDE2NDYzMDQ"]
+		}
+
+		widget_billboard {
+			title  = "billboard widget"
+			row    = 4
+			column = 5
+
+			nrql_query {
+				account_id = %[3]s
+				query      = "FROM Transaction SELECT count(*)"
+			}
+
+			warning  = 1
+			critical = 2
+		}
+
+		widget_markdown {
+			title  = "markdown widget"
+			row    = 7
+			column = 1
+			text   = "# Header text"
+		}
+	}
+}
+`, dashboardName, pageName, accountID)
+}
+
+func TestAccNewRelicOneDashboard_OfflineVariables(t *testing.T) {
+	api := newFakeNewRelicAPI(t)
+	resourceName := "newrelic_one_dashboard.bar"
+	rName := fmt.Sprintf("tf-test-%s", acctest.RandString(5))
+	accountID := strconv.Itoa(api.AccountID)
+
+	resource.ParallelTest(t, resource.TestCase{
+		IsUnitTest:   true,
+		Providers:    api.providers(),
+		CheckDestroy: api.checkDestroyed(fakeKindDashboard),
+		Steps: []resource.TestStep{
+			// Test: Create
+			{
+				Config: api.config(testAccNewRelicOneDashboardVariablesUnitConfig(rName, accountID, "production")),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "variable.#", "3"),
+					resource.TestCheckResourceAttr(resourceName, "variable.0.name", "appName"),
+					resource.TestCheckResourceAttr(resourceName, "variable.0.type", "nrql"),
+					resource.TestCheckResourceAttr(resourceName, "variable.0.is_multi_selection", "true"),
+					resource.TestCheckResourceAttr(resourceName, "variable.0.nrql_query.0.account_ids.0", accountID),
+					resource.TestCheckResourceAttr(resourceName, "variable.0.replacement_strategy", "default"),
+					resource.TestCheckResourceAttr(resourceName, "variable.1.item.#", "2"),
+					resource.TestCheckResourceAttr(resourceName, "variable.1.default_values.0", "production"),
+					resource.TestCheckResourceAttr(resourceName, "variable.2.replacement_strategy", "string"),
+				),
+			},
+			// Test: Update
+			{
+				Config: api.config(testAccNewRelicOneDashboardVariablesUnitConfig(rName, accountID, "staging")),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "variable.1.default_values.0", "staging"),
+				),
+			},
+			// Test: Import
+			{
+				Config:            api.config(testAccNewRelicOneDashboardVariablesUnitConfig(rName, accountID, "staging")),
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Test: Removing variables
+			{
+				Config: api.config(testAccNewRelicOneDashboardUnitConfig(rName, "Page 1", accountID)),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "variable.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func testAccNewRelicOneDashboardVariablesUnitConfig(dashboardName string, accountID string, defaultEnvironment string) string {
+	return fmt.Sprintf(`
+resource "newrelic_one_dashboard" "bar" {
+	name        = "%[1]s"
+	permissions = "private"
+
+	variable {
+		name               = "appName"
+		title              = "Application"
+		type               = "nrql"
+		is_multi_selection = true
+
+		nrql_query {
+			account_ids = [%[2]s]
+			query       = "FROM Transaction SELECT uniques(appName)"
+		}
+	}
+
+	variable {
+		name           = "environment"
+		type           = "enum"
+		default_values = ["%[3]s"]
+
+		item {
+			title = "Production"
+			value = "production"
+		}
+
+		item {
+			title = "Staging"
+			value = "staging"
+		}
+	}
+
+	variable {
+		name                 = "host"
+		type                 = "string"
+		replacement_strategy = "string"
+	}
+
+	page {
+		name = "Page 1"
+
+		widget_line {
+			title  = "line widget"
+			row    = 1
+			column = 1
+
+			nrql_query {
+				account_id = %[2]s
+				query      = "FROM Transaction SELECT count(*) WHERE appName IN ({{appName}}) AND environment = {{environment}} TIMESERIES"
+			}
+		}
+	}
+}
+`, dashboardName, accountID, defaultEnvironment)
+}
+
+func TestAccNewRelicOneDashboard_OfflineRawWidgets(t *testing.T) {
+	api := newFakeNewRelicAPI(t)
+	resourceName := "newrelic_one_dashboard.bar"
+	rName := fmt.Sprintf("tf-test-%s", acctest.RandString(5))
+	accountID := strconv.Itoa(api.AccountID)
+
+	resource.ParallelTest(t, resource.TestCase{
+		IsUnitTest:   true,
+		Providers:    api.providers(),
+		CheckDestroy: api.checkDestroyed(fakeKindDashboard),
+		Steps: []resource.TestStep{
+			// Test: Create
+			{
+				Config: api.config(testAccNewRelicOneDashboardRawWidgetsUnitConfig(rName, accountID, 100, "viz.event-feed")),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "page.0.widget_bullet.0.limit", "100"),
+					resource.TestCheckResourceAttr(resourceName, "page.0.widget_bullet.0.nrql_query.0.query", "FROM Transaction SELECT count(*)"),
+					resource.TestCheckResourceAttr(resourceName, "page.0.widget_funnel.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "page.0.widget_heatmap.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "page.0.widget_histogram.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "page.0.widget_json.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "page.0.widget_log_table.0.nrql_query.0.account_id", accountID),
+					resource.TestCheckResourceAttr(resourceName, "page.0.widget_stacked_bar.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "page.0.widget_viz.0.visualization_id", "viz.event-feed"),
+					resource.TestCheckResourceAttr(resourceName, "page.0.widget_viz.0.configuration", `{"limit":5,"nrqlQueries":[]}`),
+				),
+			},
+			// Test: Visualization with a dedicated widget block
+			{
+				Config:      api.config(testAccNewRelicOneDashboardRawWidgetsUnitConfig(rName, accountID, 100, "viz.bullet")),
+				ExpectError: regexp.MustCompile(`viz.bullet has a dedicated widget block, use widget_bullet instead`),
+			},
+			// Test: Update
+			{
+				Config: api.config(testAccNewRelicOneDashboardRawWidgetsUnitConfig(rName, accountID, 250, "viz.event-feed")),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "page.0.widget_bullet.0.limit", "250"),
+				),
+			},
+			// Test: Import
+			{
+				Config:            api.config(testAccNewRelicOneDashboardRawWidgetsUnitConfig(rName, accountID, 250, "viz.event-feed")),
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestFlattenDashboardWidget_InvalidRawConfiguration(t *testing.T) {
+	for _, visualizationID := range []string{"viz.bullet", "viz.event-feed"} {
+		widget := entities.DashboardWidget{
+			ID:               "1",
+			Visualization:    entities.DashboardWidgetVisualization{ID: visualizationID},
+			RawConfiguration: entities.DashboardWidgetRawConfiguration(`{"nrqlQueries": [`),
+		}
+
+		if _, err := flattenDashboardWidget(&widget); err == nil {
+			t.Errorf("expected an error for the invalid raw configuration of %s", visualizationID)
+		}
+	}
+}
+
+func testAccNewRelicOneDashboardRawWidgetsUnitConfig(dashboardName string, accountID string, limit int, visualizationID string) string {
+	return fmt.Sprintf(`
+resource "newrelic_one_dashboard" "bar" {
+	name = "%[1]s"
+
+	page {
+		name = "Page 1"
+
+		widget_bullet {
+			title  = "bullet widget"
+			row    = 1
+			column = 1
+			limit  = %[3]d
+
+			nrql_query {
+				account_id = %[2]s
+				query      = "FROM Transaction SELECT count(*)"
+			}
+		}
+
+		widget_funnel {
+			title  = "funnel widget"
+			row    = 1
+			column = 5
+
+			nrql_query {
+				account_id = %[2]s
+				query      = "FROM PageView SELECT funnel(session, WHERE pageUrl LIKE '%%/' AS 'Home', WHERE pageUrl LIKE '%%/cart' AS 'Cart')"
+			}
+		}
+
+		widget_heatmap {
+			title  = "heatmap widget"
+			row    = 1
+			column = 9
+
+			nrql_query {
+				account_id = %[2]s
+				query      = "FROM Transaction SELECT histogram(duration, buckets: 10) FACET appName"
+			}
+		}
+
+		widget_histogram {
+			title  = "histogram widget"
+			row    = 4
+			column = 1
+
+			nrql_query {
+				account_id = %[2]s
+				query      = "FROM Transaction SELECT histogram(duration, buckets: 10)"
+			}
+		}
+
+		widget_json {
+			title  = "json widget"
+			row    = 4
+			column = 5
+
+			nrql_query {
+				account_id = %[2]s
+				query      = "FROM Transaction SELECT count(*)"
+			}
+		}
+
+		widget_log_table {
+			title  = "log table widget"
+			row    = 4
+			column = 9
+
+			nrql_query {
+				account_id = %[2]s
+				query      = "FROM Log SELECT *"
+			}
+		}
+
+		widget_stacked_bar {
+			title  = "stacked bar widget"
+			row    = 7
+			column = 1
+
+			nrql_query {
+				account_id = %[2]s
+				query      = "FROM Transaction SELECT count(*) FACET appName TIMESERIES"
+			}
+		}
+
+		widget_viz {
+			title            = "event feed widget"
+			row              = 7
+			column           = 5
+			visualization_id = "%[4]s"
+			configuration    = <<-EOT
+				{
+					"nrqlQueries": [],
+					"limit": 5
+				}
+			EOT
+		}
+	}
+}
+`, dashboardName, accountID, limit, visualizationID)
+}
+
+func TestAccNewRelicOneDashboard_OfflineImportLegacy(t *testing.T) {
+	api := newFakeNewRelicAPI(t)
+	resourceName := "newrelic_one_dashboard.bar"
+	rName := fmt.Sprintf("tf-test-%s", acctest.RandString(5))
+	accountID := strconv.Itoa(api.AccountID)
+
+	resource.ParallelTest(t, resource.TestCase{
+		IsUnitTest:   true,
+		Providers:    api.providers(),
+		CheckDestroy: api.checkDestroyed(fakeKindDashboard),
+		Steps: []resource.TestStep{
+			// Test: Create the legacy dashboard
+			{
+				Config: api.config(testAccNewRelicOneDashboardLegacyUnitConfig(rName)),
+			},
+			// Test: Import
+			{
+				Config:       api.config(testAccNewRelicOneDashboardLegacyUnitConfig(rName) + testAccNewRelicOneDashboardLegacyConvertedUnitConfig(rName, accountID)),
+				ResourceName: resourceName,
+				ImportState:  true,
+				ImportStateIdFunc: func(s *terraform.State) (string, error) {
+					rs, ok := s.RootModule().Resources["newrelic_dashboard.foo"]
+					if !ok {
+						return "", fmt.Errorf("resource not found: newrelic_dashboard.foo")
+					}
+
+					return legacyDashboardIDPrefix + rs.Primary.ID, nil
+				},
+				ImportStateCheck: func(s []*terraform.InstanceState) error {
+					if len(s) != 1 {
+						return fmt.Errorf("expected 1 state: %#v", s)
+					}
+
+					expected := map[string]string{
+						"name":                                        rName,
+						"permissions":                                 "public_read_write",
+						"page.#":                                      "1",
+						"page.0.name":                                 rName,
+						"page.0.widget_billboard.0.title":             "Transaction Count",
+						"page.0.widget_billboard.0.critical":          "100",
+						"page.0.widget_billboard.0.warning":           "50",
+						"page.0.widget_billboard.0.column":            "1",
+						"page.0.widget_billboard.0.width":             "4",
+						"page.0.widget_billboard.0.height":            "3",
+						"page.0.widget_bar.0.column":                  "5",
+						"page.0.widget_bar.0.nrql_query.0.account_id": accountID,
+						"page.0.widget_histogram.0.row":               "4",
+						"page.0.widget_markdown.0.text":               "# Notes",
+					}
+
+					for k, v := range expected {
+						if actual := s[0].Attributes[k]; actual != v {
+							return fmt.Errorf("expected %s to be %q, got %q", k, v, actual)
+						}
+					}
+
+					return nil
+				},
+			},
+		},
+	})
+}
+
+func TestNewRelicOneDashboard_MigrateLegacy(t *testing.T) {
+	api := newFakeNewRelicAPI(t)
+	meta := api.providerConfig(t)
+	r := resourceNewRelicOneDashboard()
+
+	legacy, err := meta.NewClient.Dashboards.CreateDashboard(dashboards.Dashboard{
+		Title:           "legacy",
+		Visibility:      dashboards.VisibilityTypes.Owner,
+		Editable:        dashboards.EditableTypes.Owner,
+		GridColumnCount: dashboards.GridColumnCountTypes.Insights,
+		Widgets: []dashboards.DashboardWidget{{
+			Visualization: dashboards.VisualizationTypes.LineChart,
+			Data:          []dashboards.DashboardWidgetData{{NRQL: "FROM Transaction SELECT count(*) TIMESERIES"}},
+			Presentation:  dashboards.DashboardWidgetPresentation{Title: "Throughput"},
+			Layout:        dashboards.DashboardWidgetLayout{Column: 2, Row: 2, Width: 2, Height: 1},
+		}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	d := r.Data(&terraform.InstanceState{ID: fmt.Sprintf("%s%d", legacyDashboardIDPrefix, legacy.ID)})
+
+	if err := resourceNewRelicOneDashboardRead(d, meta); err != nil {
+		t.Fatal(err)
+	}
+
+	if d.Get("permissions") != "private" {
+		t.Errorf("expected private permissions, got %v", d.Get("permissions"))
+	}
+
+	if err := resourceNewRelicOneDashboardUpdate(d, meta); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, ok := legacyDashboardID(d.Id()); ok || d.Id() == "" {
+		t.Fatalf("expected the ID of a New Relic One dashboard, got %q", d.Id())
+	}
+
+	if d.Get("guid") != d.Id() {
+		t.Errorf("expected guid %q, got %v", d.Id(), d.Get("guid"))
+	}
+
+	widget := d.Get("page.0.widget_line.0").(map[string]interface{})
+	if widget["column"] != 5 || widget["row"] != 4 || widget["width"] != 8 {
+		t.Errorf("unexpected layout: %v", widget)
+	}
+
+	if api.get(fakeKindDashboard, strconv.Itoa(legacy.ID)) == nil {
+		t.Error("expected the legacy dashboard to be left in place")
+	}
+
+	if err := resourceNewRelicOneDashboardDelete(d, meta); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestNewRelicOneDashboard_DeleteLegacy(t *testing.T) {
+	api := newFakeNewRelicAPI(t)
+	meta := api.providerConfig(t)
+
+	legacy, err := meta.NewClient.Dashboards.CreateDashboard(dashboards.Dashboard{
+		Title:           "legacy",
+		Visibility:      dashboards.VisibilityTypes.Owner,
+		Editable:        dashboards.EditableTypes.Owner,
+		GridColumnCount: dashboards.GridColumnCountTypes.Insights,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	d := resourceNewRelicOneDashboard().Data(&terraform.InstanceState{ID: fmt.Sprintf("%s%d", legacyDashboardIDPrefix, legacy.ID)})
+
+	if err := resourceNewRelicOneDashboardDelete(d, meta); err != nil {
+		t.Fatal(err)
+	}
+
+	if api.get(fakeKindDashboard, strconv.Itoa(legacy.ID)) == nil {
+		t.Error("expected the legacy dashboard to be left in place")
+	}
+}
+
+func testAccNewRelicOneDashboardLegacyUnitConfig(dashboardName string) string {
+	return fmt.Sprintf(`
+resource "newrelic_dashboard" "foo" {
+	title = "%[1]s"
+
+	widget {
+		title            = "Transaction Count"
+		visualization    = "billboard"
+		nrql             = "SELECT count(*) FROM Transaction"
+		threshold_red    = 100
+		threshold_yellow = 50
+		row              = 1
+		column           = 1
+	}
+
+	widget {
+		title         = "Average Transaction Duration"
+		visualization = "facet_bar_chart"
+		nrql          = "SELECT average(duration) FROM Transaction FACET appName"
+		row           = 1
+		column        = 2
+	}
+
+	widget {
+		title         = "Duration Histogram"
+		visualization = "histogram"
+		nrql          = "SELECT histogram(duration) FROM Transaction"
+		row           = 2
+		column        = 1
+	}
+
+	widget {
+		title         = "Notes"
+		visualization = "markdown"
+		source        = "# Notes"
+		row           = 2
+		column        = 2
+	}
+}
+`, dashboardName)
+}
+
+func testAccNewRelicOneDashboardLegacyConvertedUnitConfig(dashboardName string, accountID string) string {
+	return fmt.Sprintf(`
+resource "newrelic_one_dashboard" "bar" {
+	name        = "%[1]s"
+	permissions = "public_read_write"
+
+	page {
+		name = "%[1]s"
+
+		widget_billboard {
+			title    = "Transaction Count"
+			row      = 1
+			column   = 1
+			critical = 100
+			warning  = 50
+
+			nrql_query {
+				account_id = %[2]s
+				query      = "SELECT count(*) FROM Transaction"
+			}
+		}
+
+		widget_bar {
+			title  = "Average Transaction Duration"
+			row    = 1
+			column = 5
+
+			nrql_query {
+				account_id = %[2]s
+				query      = "SELECT average(duration) FROM Transaction FACET appName"
+			}
+		}
+
+		widget_histogram {
+			title  = "Duration Histogram"
+			row    = 4
+			column = 1
+
+			nrql_query {
+				account_id = %[2]s
+				query      = "SELECT histogram(duration) FROM Transaction"
+			}
+		}
+
+		widget_markdown {
+			title  = "Notes"
+			row    = 4
+			column = 5
+			text   = "# Notes"
+		}
+	}
+}
+`, dashboardName, accountID)
+}
+
+func TestAccNewRelicOneDashboard_OfflineWidgetOptions(t *testing.T) {
+	api := newFakeNewRelicAPI(t)
+	resourceName := "newrelic_one_dashboard.bar"
+	rName := fmt.Sprintf("tf-test-%s", acctest.RandString(5))
+	accountID := strconv.Itoa(api.AccountID)
+
+	resource.ParallelTest(t, resource.TestCase{
+		IsUnitTest:   true,
+		Providers:    api.providers(),
+		CheckDestroy: api.checkDestroyed(fakeKindDashboard),
+		Steps: []resource.TestStep{
+			// Test: Create
+			{
+				Config: api.config(testAccNewRelicOneDashboardWidgetOptionsUnitConfig(rName, accountID, "y_axis_left_max = 500")),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "page.0.widget_line.0.legend_enabled", "false"),
+					resource.TestCheckResourceAttr(resourceName, "page.0.widget_line.0.y_axis_left_min", ""),
+					resource.TestCheckResourceAttr(resourceName, "page.0.widget_line.0.y_axis_left_max", "500"),
+					resource.TestCheckResourceAttr(resourceName, "page.0.widget_line.0.null_values.0.null_value", "zero"),
+					resource.TestCheckResourceAttr(resourceName, "page.0.widget_line.0.null_values.0.series_overrides.0.series_name", "Errors"),
+					resource.TestCheckResourceAttr(resourceName, "page.0.widget_line.0.null_values.0.series_overrides.0.null_value", "remove"),
+					resource.TestCheckResourceAttr(resourceName, "page.0.widget_line.0.units.0.unit", "MS"),
+					resource.TestCheckResourceAttr(resourceName, "page.0.widget_line.0.colors.0.series_overrides.0.color", "#722727"),
+					resource.TestCheckResourceAttr(resourceName, "page.0.widget_line.0.nrql_query.0.account_id", accountID),
+					resource.TestCheckResourceAttr(resourceName, "page.0.widget_billboard.0.critical", "2"),
+					resource.TestCheckResourceAttr(resourceName, "page.0.widget_billboard.0.units.0.unit", "PERCENTAGE"),
+					resource.TestCheckResourceAttr(resourceName, "page.0.widget_stacked_bar.0.legend_enabled", "true"),
+					resource.TestCheckResourceAttr(resourceName, "page.0.widget_stacked_bar.0.colors.0.color", "#1f77b4"),
+					testAccCheckNewRelicOneDashboardUnitRawConfiguration(api, resourceName),
+					testAccCheckNewRelicOneDashboardUnitYAxis(api, resourceName, `{"max":500}`),
+				),
+			},
+			// Test: Update
+			{
+				Config: api.config(testAccNewRelicOneDashboardWidgetOptionsUnitConfig(rName, accountID, "y_axis_left_min = 0\n\t\t\ty_axis_left_max = 1000")),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "page.0.widget_line.0.y_axis_left_min", "0"),
+					resource.TestCheckResourceAttr(resourceName, "page.0.widget_line.0.y_axis_left_max", "1000"),
+					testAccCheckNewRelicOneDashboardUnitYAxis(api, resourceName, `{"max":1000,"min":0}`),
+				),
+			},
+			// Test: Remove the minimum
+			{
+				Config: api.config(testAccNewRelicOneDashboardWidgetOptionsUnitConfig(rName, accountID, "y_axis_left_max = 1000.0")),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "page.0.widget_line.0.y_axis_left_min", ""),
+					resource.TestCheckResourceAttr(resourceName, "page.0.widget_line.0.y_axis_left_max", "1000"),
+					testAccCheckNewRelicOneDashboardUnitYAxis(api, resourceName, `{"max":1000}`),
+				),
+			},
+			// Test: Import
+			{
+				Config:            api.config(testAccNewRelicOneDashboardWidgetOptionsUnitConfig(rName, accountID, "y_axis_left_max = 1000")),
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+// testAccCheckNewRelicOneDashboardUnitRawConfiguration checks that only the
+// widgets that set display options are sent with a rawConfiguration.
+func testAccCheckNewRelicOneDashboardUnitRawConfiguration(api *fakeNewRelicAPI, resourceName string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("not found: %s", resourceName)
+		}
+
+		page := fakeMap(fakeList(api.get(fakeKindEntity, rs.Primary.ID)["pages"])[0])
+		for _, w := range fakeList(page["widgets"]) {
+			widget := fakeMap(w)
+			_, raw := widget["rawConfiguration"]
+
+			if title := fakeString(widget["title"]); raw != (title != "pie widget") {
+				return fmt.Errorf("unexpected rawConfiguration for %s: %v", title, widget["rawConfiguration"])
+			}
+		}
+
+		return nil
+	}
+}
+
+// testAccCheckNewRelicOneDashboardUnitYAxis checks the Y axis sent in the
+// rawConfiguration of the line widget.
+func testAccCheckNewRelicOneDashboardUnitYAxis(api *fakeNewRelicAPI, resourceName string, expected string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("not found: %s", resourceName)
+		}
+
+		page := fakeMap(fakeList(api.get(fakeKindEntity, rs.Primary.ID)["pages"])[0])
+		for _, w := range fakeList(page["widgets"]) {
+			widget := fakeMap(w)
+			if fakeString(widget["title"]) != "line widget" {
+				continue
+			}
+
+			yAxis, err := json.Marshal(fakeMap(widget["rawConfiguration"])["yAxisLeft"])
+			if err != nil {
+				return err
+			}
+
+			if string(yAxis) != expected {
+				return fmt.Errorf("expected yAxisLeft %s, got %s", expected, yAxis)
+			}
+
+			return nil
+		}
+
+		return fmt.Errorf("line widget not found")
+	}
+}
+
+func testAccNewRelicOneDashboardWidgetOptionsUnitConfig(dashboardName string, accountID string, yAxisBounds string) string {
+	return fmt.Sprintf(`
+resource "newrelic_one_dashboard" "bar" {
+	name = "%[1]s"
+
+	page {
+		name = "Page 1"
+
+		widget_line {
+			title           = "line widget"
+			row             = 1
+			column          = 1
+			legend_enabled  = false
+			%[3]s
+
+			nrql_query {
+				account_id = %[2]s
+				query      = "FROM Transaction SELECT average(duration) AS 'Duration', count(*) AS 'Errors' TIMESERIES"
+			}
+
+			null_values {
+				null_value = "zero"
+
+				series_overrides {
+					series_name = "Errors"
+					null_value  = "remove"
+				}
+			}
+
+			units {
+				unit = "MS"
+			}
+
+			colors {
+				series_overrides {
+					series_name = "Duration"
+					color       = "#722727"
+				}
+			}
+		}
+
+		widget_billboard {
+			title    = "billboard widget"
+			row      = 1
+			column   = 5
+			critical = 2
+			warning  = 1
+
+			nrql_query {
+				account_id = %[2]s
+				query      = "FROM Transaction SELECT percentage(count(*), WHERE error IS true)"
+			}
+
+			units {
+				unit = "PERCENTAGE"
+			}
+		}
+
+		widget_pie {
+			title  = "pie widget"
+			row    = 1
+			column = 9
+
+			nrql_query {
+				account_id = %[2]s
+				query      = "FROM Transaction SELECT count(*) FACET appName"
+			}
+		}
+
+		widget_stacked_bar {
+			title  = "stacked bar widget"
+			row    = 4
+			column = 1
+
+			nrql_query {
+				account_id = %[2]s
+				query      = "FROM Transaction SELECT count(*) FACET appName TIMESERIES"
+			}
+
+			colors {
+				color = "#1f77b4"
+			}
+		}
+	}
+}
+`, dashboardName, accountID, yAxisBounds)
+}
+
+func TestAccNewRelicOneDashboard_OfflineAccounts(t *testing.T) {
+	api := newFakeNewRelicAPI(t)
+	resourceName := "newrelic_one_dashboard.bar"
+	rName := fmt.Sprintf("tf-test-%s", acctest.RandString(5))
+	accountID := strconv.Itoa(api.AccountID)
+	subAccountID := strconv.Itoa(fakeSubAccountID)
+
+	resource.ParallelTest(t, resource.TestCase{
+		IsUnitTest:   true,
+		Providers:    api.providers(),
+		CheckDestroy: api.checkDestroyed(fakeKindEntity),
+		Steps: []resource.TestStep{
+			// Test: Inaccessible account
+			{
+				Config:      api.config(testAccNewRelicOneDashboardAccountsUnitConfig(rName, "33333", accountID)),
+				ExpectError: regexp.MustCompile(`can not access account\(s\) 33333`),
+			},
+			// Test: Account configured with its own credentials
+			{
+				Config:             api.configWithProvider(testAccNewRelicUnitAccountBlock(33333), testAccNewRelicOneDashboardAccountsUnitConfig(rName, "33333", accountID)),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			// Test: Create
+			{
+				Config: api.config(testAccNewRelicOneDashboardAccountsUnitConfig(rName, subAccountID, accountID)),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "page.0.account_id", subAccountID),
+					resource.TestCheckResourceAttr(resourceName, "page.0.widget_line.0.nrql_query.0.account_id", subAccountID),
+					resource.TestCheckResourceAttr(resourceName, "page.0.widget_bar.0.nrql_query.0.account_ids.#", "2"),
+					resource.TestCheckResourceAttr(resourceName, "page.0.widget_bar.0.nrql_query.0.account_ids.1", subAccountID),
+					resource.TestCheckResourceAttr(resourceName, "page.0.widget_bar.0.nrql_query.0.account_id", "0"),
+					resource.TestCheckResourceAttr(resourceName, "page.1.widget_table.0.nrql_query.0.account_id", accountID),
+				),
+			},
+			// Test: Update
+			{
+				Config: api.config(testAccNewRelicOneDashboardAccountsUnitConfig(rName, accountID, subAccountID)),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "page.0.widget_line.0.nrql_query.0.account_id", accountID),
+					resource.TestCheckResourceAttr(resourceName, "page.0.widget_bar.0.nrql_query.0.account_ids.1", subAccountID),
+				),
+			},
+			// Test: Import
+			{
+				Config:                  api.config(testAccNewRelicOneDashboardAccountsUnitConfig(rName, accountID, subAccountID)),
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"page.0.account_id"},
+			},
+			// Test: Both account_id and account_ids
+			{
+				Config: api.config(`
+resource "newrelic_one_dashboard" "bar" {
+	name = "` + rName + `"
+
+	page {
+		name = "Page 1"
+
+		widget_bar {
+			title  = "bar widget"
+			row    = 1
+			column = 1
+
+			nrql_query {
+				account_id  = ` + accountID + `
+				account_ids = [` + accountID + `]
+				query       = "FROM Transaction SELECT count(*) FACET appName"
+			}
+		}
+	}
+}
+`),
+				ExpectError: regexp.MustCompile(`only one of account_id or account_ids can be set`),
+			},
+		},
+	})
+}
+
+func testAccNewRelicOneDashboardAccountsUnitConfig(dashboardName string, pageAccountID string, barAccountID string) string {
+	return fmt.Sprintf(`
+resource "newrelic_one_dashboard" "bar" {
+	name = "%[1]s"
+
+	page {
+		name       = "Page 1"
+		account_id = %[2]s
+
+		widget_line {
+			title  = "line widget"
+			row    = 1
+			column = 1
+
+			nrql_query {
+				query = "FROM Transaction SELECT count(*) TIMESERIES"
+			}
+		}
+
+		widget_bar {
+			title  = "bar widget"
+			row    = 1
+			column = 5
+
+			nrql_query {
+				account_ids = [%[3]s, %[4]d]
+				query       = "FROM Transaction SELECT count(*) FACET appName"
+			}
+		}
+	}
+
+	page {
+		name = "Page 2"
+
+		widget_table {
+			title  = "table widget"
+			row    = 1
+			column = 1
+
+			nrql_query {
+				query = "FROM Transaction SELECT count(*) FACET appName"
+			}
+		}
+	}
+}
+`, dashboardName, pageAccountID, barAccountID, fakeSubAccountID)
+}
+
+func TestAccNewRelicOneDashboard_OfflinePageLinks(t *testing.T) {
+	api := newFakeNewRelicAPI(t)
+	resourceName := "newrelic_one_dashboard.bar"
+	rName := fmt.Sprintf("tf-test-%s", acctest.RandString(5))
+
+	resource.ParallelTest(t, resource.TestCase{
+		IsUnitTest:   true,
+		Providers:    api.providers(),
+		CheckDestroy: api.checkDestroyed(fakeKindEntity),
+		Steps: []resource.TestStep{
+			// Test: Unknown page
+			{
+				Config:      api.config(testAccNewRelicOneDashboardPageLinksUnitConfig(rName, `linked_page = "Missing"`)),
+				ExpectError: regexp.MustCompile(`linked_page "Missing" is not a page of the dashboard`),
+			},
+			// Test: Create
+			{
+				Config: api.config(testAccNewRelicOneDashboardPageLinksUnitConfig(rName, `linked_page = "Details"`)),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "page.0.widget_bar.0.filter_current_dashboard", "true"),
+					resource.TestCheckResourceAttr(resourceName, "page.0.widget_bar.0.linked_entity_guids.#", "0"),
+					resource.TestCheckResourceAttr(resourceName, "page.0.widget_pie.0.linked_page", "Details"),
+					resource.TestCheckResourceAttr(resourceName, "page.1.widget_table.0.filter_current_dashboard", "false"),
+					resource.TestCheckResourceAttr(resourceName, "page.1.widget_table.0.linked_page", "Overview"),
+					resource.TestCheckResourceAttr(resourceName, "page.1.widget_heatmap.0.filter_current_dashboard", "true"),
+					testAccCheckNewRelicOneDashboardUnitPageLinks(api, resourceName),
+				),
+			},
+			// Test: Update
+			{
+				Config: api.config(testAccNewRelicOneDashboardPageLinksUnitConfig(rName, "filter_current_dashboard = true")),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "page.0.widget_pie.0.linked_page", ""),
+					resource.TestCheckResourceAttr(resourceName, "page.0.widget_pie.0.filter_current_dashboard", "true"),
+					testAccCheckNewRelicOneDashboardUnitPageLinks(api, resourceName),
+				),
+			},
+			// Test: Import, which reads the links as linked_entity_guids
+			{
+				Config:            api.config(testAccNewRelicOneDashboardPageLinksUnitConfig(rName, "filter_current_dashboard = true")),
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateVerifyIgnore: []string{
+					"page.0.widget_bar.0.filter_current_dashboard",
+					"page.0.widget_bar.0.linked_entity_guids",
+					"page.0.widget_pie.0.filter_current_dashboard",
+					"page.0.widget_pie.0.linked_entity_guids",
+					"page.1.widget_heatmap.0.filter_current_dashboard",
+					"page.1.widget_heatmap.0.linked_entity_guids",
+					"page.1.widget_table.0.linked_entity_guids",
+					"page.1.widget_table.0.linked_page",
+				},
+			},
+			// Test: Several link targets
+			{
+				Config: api.config(`
+resource "newrelic_one_dashboard" "bar" {
+	name = "` + rName + `"
+
+	page {
+		name = "Overview"
+
+		widget_bar {
+			title                    = "bar widget"
+			row                      = 1
+			column                   = 1
+			filter_current_dashboard = true
+			linked_entity_guids      = ["abc123"]
+
+			nrql_query {
+				query = "FROM Transaction SELECT count(*) FACET appName"
+			}
+		}
+	}
+}
+`),
+				ExpectError: regexp.MustCompile(`only one of linked_entity_guids, filter_current_dashboard or linked_page can be set`),
+			},
+		},
+	})
+}
+
+// testAccCheckNewRelicOneDashboardUnitPageLinks checks that every widget that
+// filters a page is linked to the GUID of that page.
+func testAccCheckNewRelicOneDashboardUnitPageLinks(api *fakeNewRelicAPI, resourceName string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("not found: %s", resourceName)
+		}
+
+		pageGUIDs := map[string]string{}
+		pages := fakeList(api.get(fakeKindEntity, rs.Primary.ID)["pages"])
+		for _, p := range pages {
+			pageGUIDs[fakeString(fakeMap(p)["name"])] = fakeString(fakeMap(p)["guid"])
+		}
+
+		for _, p := range pages {
+			for _, w := range fakeList(fakeMap(p)["widgets"]) {
+				widget := fakeMap(w)
+				linked := fakeList(widget["linkedEntities"])
+
+				if len(linked) != 1 {
+					return fmt.Errorf("expected one linked entity for %s, got %v", fakeString(widget["title"]), linked)
+				}
+
+				guid := fakeString(fakeMap(linked[0])["guid"])
+				if guid == "" || (guid != pageGUIDs["Overview"] && guid != pageGUIDs["Details"]) {
+					return fmt.Errorf("unexpected linked entity %q for %s", guid, fakeString(widget["title"]))
+				}
+			}
+		}
+
+		return nil
+	}
+}
+
+func TestFlattenDashboardPage_LinkedEntityGUIDs(t *testing.T) {
+	pages := []entities.DashboardPage{{
+		GUID: "page-guid",
+		Name: "Overview",
+		Widgets: []entities.DashboardWidget{{
+			ID:             "1",
+			Title:          "bar widget",
+			Visualization:  entities.DashboardWidgetVisualization{ID: "viz.bar"},
+			LinkedEntities: []entities.EntityOutlineInterface{&entities.DashboardEntityOutline{GUID: "page-guid"}},
+		}},
+	}}
+
+	d := resourceNewRelicOneDashboard().Data(&terraform.InstanceState{ID: "dashboard-guid"})
+
+	flattened, err := flattenDashboardPage(&pages, d)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	widget := flattened[0].(map[string]interface{})["widget_bar"].([]interface{})[0].(map[string]interface{})
+	if guids, ok := widget["linked_entity_guids"].([]string); !ok || len(guids) != 1 || guids[0] != "page-guid" {
+		t.Errorf("expected linked_entity_guids to be kept, got %v", widget)
+	}
+
+	if _, ok := widget["filter_current_dashboard"]; ok {
+		t.Errorf("expected filter_current_dashboard not to be set, got %v", widget)
+	}
+}
+
+func testAccNewRelicOneDashboardPageLinksUnitConfig(dashboardName string, pieLink string) string {
+	return fmt.Sprintf(`
+resource "newrelic_one_dashboard" "bar" {
+	name = "%[1]s"
+
+	page {
+		name = "Overview"
+
+		widget_bar {
+			title                    = "bar widget"
+			row                      = 1
+			column                   = 1
+			filter_current_dashboard = true
+
+			nrql_query {
+				query = "FROM Transaction SELECT count(*) FACET appName"
+			}
+		}
+
+		widget_pie {
+			title  = "pie widget"
+			row    = 1
+			column = 5
+			%[2]s
+
+			nrql_query {
+				query = "FROM Transaction SELECT count(*) FACET host"
+			}
+		}
+	}
+
+	page {
+		name = "Details"
+
+		widget_table {
+			title       = "table widget"
+			row         = 1
+			column      = 1
+			linked_page = "Overview"
+
+			nrql_query {
+				query = "FROM Transaction SELECT count(*) FACET name"
+			}
+		}
+
+		widget_heatmap {
+			title                    = "heatmap widget"
+			row                      = 1
+			column                   = 5
+			filter_current_dashboard = true
+
+			nrql_query {
+				query = "FROM Transaction SELECT histogram(duration, buckets: 10) FACET appName"
+			}
+		}
+	}
+}
+`, dashboardName, pieLink)
+}
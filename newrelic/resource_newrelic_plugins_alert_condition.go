@@ -122,7 +122,7 @@ func resourceNewRelicPluginsAlertCondition() *schema.Resource {
 }
 
 func resourceNewRelicPluginsAlertConditionCreate(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*ProviderConfig).NewClient
+	client := accountClient(meta.(*ProviderConfig), d)
 	condition := expandPluginsCondition(d)
 	policyID := d.Get("policy_id").(int)
 
@@ -135,12 +135,12 @@ func resourceNewRelicPluginsAlertConditionCreate(d *schema.ResourceData, meta in
 
 	d.SetId(serializeIDs([]int{policyID, condition.ID}))
 
-	return nil
+	return readAfterWrite(resourceNewRelicPluginsAlertConditionRead, d, meta)
 }
 
 func resourceNewRelicPluginsAlertConditionRead(d *schema.ResourceData, meta interface{}) error {
 	providerConfig := meta.(*ProviderConfig)
-	client := meta.(*ProviderConfig).NewClient
+	client := accountClient(meta.(*ProviderConfig), d)
 	accountID := selectAccountID(providerConfig, d)
 
 	log.Printf("[INFO] Reading New Relic alert condition %s", d.Id())
@@ -178,7 +178,7 @@ func resourceNewRelicPluginsAlertConditionRead(d *schema.ResourceData, meta inte
 }
 
 func resourceNewRelicPluginsAlertConditionUpdate(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*ProviderConfig).NewClient
+	client := accountClient(meta.(*ProviderConfig), d)
 	condition := expandPluginsCondition(d)
 
 	ids, err := parseIDs(d.Id(), 2)
@@ -191,16 +191,15 @@ func resourceNewRelicPluginsAlertConditionUpdate(d *schema.ResourceData, meta in
 
 	log.Printf("[INFO] Updating New Relic alert condition %d", id)
 
-	updatedCondition, err := client.Alerts.UpdatePluginsCondition(*condition)
-	if err != nil {
+	if _, err := client.Alerts.UpdatePluginsCondition(*condition); err != nil {
 		return err
 	}
 
-	return flattenPluginsCondition(updatedCondition, d)
+	return readAfterWrite(resourceNewRelicPluginsAlertConditionRead, d, meta)
 }
 
 func resourceNewRelicPluginsAlertConditionDelete(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*ProviderConfig).NewClient
+	client := accountClient(meta.(*ProviderConfig), d)
 
 	ids, err := parseIDs(d.Id(), 2)
 	if err != nil {
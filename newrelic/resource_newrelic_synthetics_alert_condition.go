@@ -19,6 +19,13 @@ func resourceNewRelicSyntheticsAlertCondition() *schema.Resource {
 			State: schema.ImportStatePassthrough,
 		},
 		Schema: map[string]*schema.Schema{
+			"account_id": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "The New Relic account ID to operate on. Accounts other than the one of the provider must be configured in an account block of the provider.",
+			},
 			"policy_id": {
 				Type:        schema.TypeInt,
 				Required:    true,
@@ -83,26 +90,33 @@ func flattenSyntheticsCondition(condition *alerts.SyntheticsCondition, d *schema
 }
 
 func resourceNewRelicSyntheticsAlertConditionCreate(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*ProviderConfig).NewClient
+	client, err := restAccountClient(meta.(*ProviderConfig), d)
+	if err != nil {
+		return err
+	}
 
 	policyID := d.Get("policy_id").(int)
 	condition := expandSyntheticsCondition(d)
 
 	log.Printf("[INFO] Creating New Relic Synthetics alert condition %s", condition.Name)
 
-	condition, err := client.Alerts.CreateSyntheticsCondition(policyID, *condition)
+	condition, err = client.Alerts.CreateSyntheticsCondition(policyID, *condition)
 	if err != nil {
 		return err
 	}
 
 	d.SetId(serializeIDs([]int{policyID, condition.ID}))
 
-	return resourceNewRelicSyntheticsAlertConditionRead(d, meta)
+	return readAfterWrite(resourceNewRelicSyntheticsAlertConditionRead, d, meta)
 }
 
 func resourceNewRelicSyntheticsAlertConditionRead(d *schema.ResourceData, meta interface{}) error {
 	providerConfig := meta.(*ProviderConfig)
-	client := providerConfig.NewClient
+	client, err := restAccountClient(providerConfig, d)
+	if err != nil {
+		return err
+	}
+
 	accountID := selectAccountID(providerConfig, d)
 
 	log.Printf("[INFO] Reading New Relic Synthetics alert condition %s", d.Id())
@@ -134,11 +148,19 @@ func resourceNewRelicSyntheticsAlertConditionRead(d *schema.ResourceData, meta i
 		return err
 	}
 
+	if err := d.Set("account_id", accountID); err != nil {
+		return err
+	}
+
 	return flattenSyntheticsCondition(condition, d)
 }
 
 func resourceNewRelicSyntheticsAlertConditionUpdate(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*ProviderConfig).NewClient
+	client, err := restAccountClient(meta.(*ProviderConfig), d)
+	if err != nil {
+		return err
+	}
+
 	condition := expandSyntheticsCondition(d)
 
 	ids, err := parseIDs(d.Id(), 2)
@@ -157,11 +179,14 @@ func resourceNewRelicSyntheticsAlertConditionUpdate(d *schema.ResourceData, meta
 		return err
 	}
 
-	return resourceNewRelicSyntheticsAlertConditionRead(d, meta)
+	return readAfterWrite(resourceNewRelicSyntheticsAlertConditionRead, d, meta)
 }
 
 func resourceNewRelicSyntheticsAlertConditionDelete(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*ProviderConfig).NewClient
+	client, err := restAccountClient(meta.(*ProviderConfig), d)
+	if err != nil {
+		return err
+	}
 
 	ids, err := parseIDs(d.Id(), 2)
 	if err != nil {
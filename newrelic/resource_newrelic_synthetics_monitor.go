@@ -20,6 +20,13 @@ func resourceNewRelicSyntheticsMonitor() *schema.Resource {
 			State: schema.ImportStatePassthrough,
 		},
 		Schema: map[string]*schema.Schema{
+			"account_id": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "The New Relic account ID to operate on. Accounts other than the one of the provider must be configured in an account block of the provider.",
+			},
 			"type": {
 				Type:        schema.TypeString,
 				Required:    true,
@@ -193,7 +200,11 @@ func readSyntheticsMonitorStruct(monitor *synthetics.Monitor, d *schema.Resource
 }
 
 func resourceNewRelicSyntheticsMonitorCreate(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*ProviderConfig).NewClient
+	client, err := restAccountClient(meta.(*ProviderConfig), d)
+	if err != nil {
+		return err
+	}
+
 	monitorStruct := buildSyntheticsMonitorStruct(d)
 
 	log.Printf("[INFO] Creating New Relic Synthetics monitor %s", monitorStruct.Name)
@@ -204,11 +215,14 @@ func resourceNewRelicSyntheticsMonitorCreate(d *schema.ResourceData, meta interf
 	}
 
 	d.SetId(monitor.ID)
-	return resourceNewRelicSyntheticsMonitorRead(d, meta)
+	return readAfterWrite(resourceNewRelicSyntheticsMonitorRead, d, meta)
 }
 
 func resourceNewRelicSyntheticsMonitorRead(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*ProviderConfig).NewClient
+	client, err := restAccountClient(meta.(*ProviderConfig), d)
+	if err != nil {
+		return err
+	}
 
 	log.Printf("[INFO] Reading New Relic Synthetics monitor %s", d.Id())
 
@@ -222,23 +236,34 @@ func resourceNewRelicSyntheticsMonitorRead(d *schema.ResourceData, meta interfac
 		return err
 	}
 
+	if err := d.Set("account_id", selectAccountID(meta.(*ProviderConfig), d)); err != nil {
+		return err
+	}
+
 	return readSyntheticsMonitorStruct(monitor, d)
 }
 
 func resourceNewRelicSyntheticsMonitorUpdate(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*ProviderConfig).NewClient
+	client, err := restAccountClient(meta.(*ProviderConfig), d)
+	if err != nil {
+		return err
+	}
+
 	log.Printf("[INFO] Updating New Relic Synthetics monitor %s", d.Id())
 
-	_, err := client.Synthetics.UpdateMonitor(*buildSyntheticsUpdateMonitorArgs(d))
+	_, err = client.Synthetics.UpdateMonitor(*buildSyntheticsUpdateMonitorArgs(d))
 	if err != nil {
 		return err
 	}
 
-	return resourceNewRelicSyntheticsMonitorRead(d, meta)
+	return readAfterWrite(resourceNewRelicSyntheticsMonitorRead, d, meta)
 }
 
 func resourceNewRelicSyntheticsMonitorDelete(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*ProviderConfig).NewClient
+	client, err := restAccountClient(meta.(*ProviderConfig), d)
+	if err != nil {
+		return err
+	}
 
 	log.Printf("[INFO] Deleting New Relic Synthetics monitor %s", d.Id())
 
@@ -47,7 +47,7 @@ func buildSyntheticsMonitorScriptStruct(d *schema.ResourceData) *synthetics.Moni
 }
 
 func resourceNewRelicSyntheticsMonitorScriptCreate(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*ProviderConfig).NewClient
+	client := accountClient(meta.(*ProviderConfig), d)
 
 	id := d.Get("monitor_id").(string)
 	log.Printf("[INFO] Creating New Relic Synthetics monitor script %s", id)
@@ -58,11 +58,11 @@ func resourceNewRelicSyntheticsMonitorScriptCreate(d *schema.ResourceData, meta
 	}
 
 	d.SetId(id)
-	return resourceNewRelicSyntheticsMonitorScriptRead(d, meta)
+	return readAfterWrite(resourceNewRelicSyntheticsMonitorScriptRead, d, meta)
 }
 
 func resourceNewRelicSyntheticsMonitorScriptRead(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*ProviderConfig).NewClient
+	client := accountClient(meta.(*ProviderConfig), d)
 
 	log.Printf("[INFO] Reading New Relic Synthetics script %s", d.Id())
 
@@ -81,7 +81,7 @@ func resourceNewRelicSyntheticsMonitorScriptRead(d *schema.ResourceData, meta in
 }
 
 func resourceNewRelicSyntheticsMonitorScriptUpdate(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*ProviderConfig).NewClient
+	client := accountClient(meta.(*ProviderConfig), d)
 
 	log.Printf("[INFO] Creating New Relic Synthetics monitor script %s", d.Id())
 
@@ -91,11 +91,11 @@ func resourceNewRelicSyntheticsMonitorScriptUpdate(d *schema.ResourceData, meta
 	}
 
 	d.SetId(d.Id())
-	return resourceNewRelicSyntheticsMonitorScriptRead(d, meta)
+	return readAfterWrite(resourceNewRelicSyntheticsMonitorScriptRead, d, meta)
 }
 
 func resourceNewRelicSyntheticsMonitorScriptDelete(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*ProviderConfig).NewClient
+	client := accountClient(meta.(*ProviderConfig), d)
 
 	log.Printf("[INFO] Deleting New Relic Synthetics monitor script %s", d.Id())
 
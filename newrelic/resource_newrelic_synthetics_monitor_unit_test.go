@@ -0,0 +1,152 @@
+// +build unit
+
+package newrelic
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+)
+
+func TestAccNewRelicSyntheticsMonitor_Offline(t *testing.T) {
+	api := newFakeNewRelicAPI(t)
+	resourceName := "newrelic_synthetics_monitor.foo"
+	rName := fmt.Sprintf("tf-test-%s", acctest.RandString(5))
+
+	resource.ParallelTest(t, resource.TestCase{
+		IsUnitTest:   true,
+		Providers:    api.providers(),
+		CheckDestroy: api.checkDestroyed(fakeKindMonitor),
+		Steps: []resource.TestStep{
+			// Test: Create
+			{
+				Config: api.config(testAccNewRelicSyntheticsMonitorUnitConfig(rName, 1, "DISABLED", "false")),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "name", rName),
+					resource.TestCheckResourceAttr(resourceName, "frequency", "1"),
+					resource.TestCheckResourceAttr(resourceName, "status", "DISABLED"),
+					resource.TestCheckResourceAttr(resourceName, "verify_ssl", "false"),
+				),
+			},
+			// Test: Update
+			{
+				Config: api.config(testAccNewRelicSyntheticsMonitorUnitConfig(rName, 5, "ENABLED", "true")),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "frequency", "5"),
+					resource.TestCheckResourceAttr(resourceName, "status", "ENABLED"),
+					resource.TestCheckResourceAttr(resourceName, "verify_ssl", "true"),
+				),
+			},
+			// Test: Import
+			{
+				Config:            api.config(testAccNewRelicSyntheticsMonitorUnitConfig(rName, 5, "ENABLED", "true")),
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccNewRelicSyntheticsMonitorUnitConfig(name string, frequency int, status string, verifySSL string) string {
+	return fmt.Sprintf(`
+resource "newrelic_synthetics_monitor" "foo" {
+	name      = "%[1]s"
+	type      = "SIMPLE"
+	frequency = %[2]d
+	status    = "%[3]s"
+	locations = ["AWS_US_EAST_1"]
+
+	uri                       = "https://example.com"
+	validation_string         = "add example validation check here"
+	verify_ssl                = %[4]s
+	bypass_head_request       = %[4]s
+	treat_redirect_as_failure = %[4]s
+}
+`, name, frequency, status, verifySSL)
+}
+
+func TestAccNewRelicSyntheticsMonitor_OfflineAccount(t *testing.T) {
+	api := newFakeNewRelicAPI(t)
+	rName := fmt.Sprintf("tf-test-%s", acctest.RandString(5))
+
+	resource.ParallelTest(t, resource.TestCase{
+		IsUnitTest:   true,
+		Providers:    api.providers(),
+		CheckDestroy: api.checkDestroyed(fakeKindMonitor),
+		Steps: []resource.TestStep{
+			// Test: The provider's account in an account block
+			{
+				Config:      api.configWithProvider(testAccNewRelicUnitAccountBlock(fakeAccountID), testAccNewRelicSyntheticsMonitorUnitAccountConfig(rName)),
+				ExpectError: regexp.MustCompile(fmt.Sprintf("account %d is the provider's account and can not be set in an account block", fakeAccountID)),
+			},
+			// Test: An account configured twice
+			{
+				Config:      api.configWithProvider(testAccNewRelicUnitAccountBlock(fakeSubAccountID)+testAccNewRelicUnitAccountBlock(fakeSubAccountID), testAccNewRelicSyntheticsMonitorUnitAccountConfig(rName)),
+				ExpectError: regexp.MustCompile(fmt.Sprintf("account %d is configured more than once", fakeSubAccountID)),
+			},
+			// Test: An account in another region than the overridden API URLs
+			{
+				Config: api.configWithProvider(`
+	account {
+		account_id = 33333
+		api_key    = "NRAK-FAKE-EU"
+		region     = "EU"
+	}
+`, testAccNewRelicSyntheticsMonitorUnitAccountConfig(rName)),
+				ExpectError: regexp.MustCompile("the region of account 33333 can not differ from the provider's"),
+			},
+			// Test: Create in the provider's account and in a sub-account
+			{
+				Config: api.configWithProvider(testAccNewRelicUnitAccountBlock(fakeSubAccountID), testAccNewRelicSyntheticsMonitorUnitAccountConfig(rName)),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("newrelic_synthetics_monitor.foo", "account_id", strconv.Itoa(fakeAccountID)),
+					resource.TestCheckResourceAttr("newrelic_synthetics_monitor.sub", "account_id", strconv.Itoa(fakeSubAccountID)),
+					func(s *terraform.State) error {
+						if api.requestsWithAPIKey("NRAK-FAKE-SUB") == 0 {
+							return fmt.Errorf("expected the sub-account monitor to be managed with the sub-account API key")
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+func testAccNewRelicUnitAccountBlock(accountID int) string {
+	return fmt.Sprintf(`
+	account {
+		account_id = %d
+		api_key    = "NRAK-FAKE-SUB"
+	}
+`, accountID)
+}
+
+func testAccNewRelicSyntheticsMonitorUnitAccountConfig(name string) string {
+	return fmt.Sprintf(`
+resource "newrelic_synthetics_monitor" "foo" {
+	name      = "%[1]s"
+	type      = "SIMPLE"
+	frequency = 5
+	status    = "DISABLED"
+	locations = ["AWS_US_EAST_1"]
+	uri       = "https://example.com"
+}
+
+resource "newrelic_synthetics_monitor" "sub" {
+	account_id = %[2]d
+	name       = "%[1]s-sub"
+	type       = "SIMPLE"
+	frequency  = 5
+	status     = "DISABLED"
+	locations  = ["AWS_US_EAST_1"]
+	uri        = "https://example.com"
+}
+`, name, fakeSubAccountID)
+}
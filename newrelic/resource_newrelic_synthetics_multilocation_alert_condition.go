@@ -88,7 +88,7 @@ func resourceNewRelicSyntheticsMultiLocationAlertCondition() *schema.Resource {
 }
 
 func resourceNewRelicSyntheticsMultiLocationAlertConditionCreate(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*ProviderConfig).NewClient
+	client := accountClient(meta.(*ProviderConfig), d)
 
 	policyID := d.Get("policy_id").(int)
 	condition, err := expandMultiLocationSyntheticsCondition(d)
@@ -105,12 +105,12 @@ func resourceNewRelicSyntheticsMultiLocationAlertConditionCreate(d *schema.Resou
 
 	d.SetId(serializeIDs([]int{policyID, condition.ID}))
 
-	return resourceNewRelicSyntheticsMultiLocationAlertConditionRead(d, meta)
+	return readAfterWrite(resourceNewRelicSyntheticsMultiLocationAlertConditionRead, d, meta)
 }
 
 func resourceNewRelicSyntheticsMultiLocationAlertConditionRead(d *schema.ResourceData, meta interface{}) error {
 	providerConfig := meta.(*ProviderConfig)
-	client := providerConfig.NewClient
+	client := accountClient(providerConfig, d)
 	accountID := selectAccountID(providerConfig, d)
 
 	log.Printf("[INFO] Reading New Relic Alerts multi-location failure condition %s", d.Id())
@@ -146,7 +146,7 @@ func resourceNewRelicSyntheticsMultiLocationAlertConditionRead(d *schema.Resourc
 }
 
 func resourceNewRelicSyntheticsMultiLocationAlertConditionUpdate(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*ProviderConfig).NewClient
+	client := accountClient(meta.(*ProviderConfig), d)
 	condition, err := expandMultiLocationSyntheticsCondition(d)
 	if err != nil {
 		return err
@@ -168,11 +168,11 @@ func resourceNewRelicSyntheticsMultiLocationAlertConditionUpdate(d *schema.Resou
 		return err
 	}
 
-	return resourceNewRelicSyntheticsMultiLocationAlertConditionRead(d, meta)
+	return readAfterWrite(resourceNewRelicSyntheticsMultiLocationAlertConditionRead, d, meta)
 }
 
 func resourceNewRelicSyntheticsMultiLocationAlertConditionDelete(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*ProviderConfig).NewClient
+	client := accountClient(meta.(*ProviderConfig), d)
 
 	ids, err := parseIDs(d.Id(), 2)
 	if err != nil {
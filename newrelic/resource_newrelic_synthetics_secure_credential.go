@@ -56,7 +56,7 @@ func resourceNewRelicSyntheticsSecureCredential() *schema.Resource {
 }
 
 func resourceNewRelicSyntheticsSecureCredentialCreate(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*ProviderConfig).NewClient
+	client := accountClient(meta.(*ProviderConfig), d)
 	sc := expandSyntheticsSecureCredential(d)
 
 	log.Printf("[INFO] Creating New Relic Synthetics secure credential %s", sc.Key)
@@ -67,11 +67,11 @@ func resourceNewRelicSyntheticsSecureCredentialCreate(d *schema.ResourceData, me
 	}
 
 	d.SetId(sc.Key)
-	return resourceNewRelicSyntheticsSecureCredentialRead(d, meta)
+	return readAfterWrite(resourceNewRelicSyntheticsSecureCredentialRead, d, meta)
 }
 
 func resourceNewRelicSyntheticsSecureCredentialRead(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*ProviderConfig).NewClient
+	client := accountClient(meta.(*ProviderConfig), d)
 
 	log.Printf("[INFO] Reading New Relic Synthetics secure credential %s", d.Id())
 
@@ -89,7 +89,7 @@ func resourceNewRelicSyntheticsSecureCredentialRead(d *schema.ResourceData, meta
 }
 
 func resourceNewRelicSyntheticsSecureCredentialUpdate(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*ProviderConfig).NewClient
+	client := accountClient(meta.(*ProviderConfig), d)
 	log.Printf("[INFO] Updating New Relic Synthetics secure credential %s", d.Id())
 
 	sc := expandSyntheticsSecureCredential(d)
@@ -99,11 +99,11 @@ func resourceNewRelicSyntheticsSecureCredentialUpdate(d *schema.ResourceData, me
 		return err
 	}
 
-	return resourceNewRelicSyntheticsSecureCredentialRead(d, meta)
+	return readAfterWrite(resourceNewRelicSyntheticsSecureCredentialRead, d, meta)
 }
 
 func resourceNewRelicSyntheticsSecureCredentialDelete(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*ProviderConfig).NewClient
+	client := accountClient(meta.(*ProviderConfig), d)
 
 	log.Printf("[INFO] Deleting New Relic Synthetics secure credential %s", d.Id())
 
@@ -0,0 +1,133 @@
+package newrelic
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/newrelic/newrelic-client-go/pkg/errors"
+)
+
+func resourceNewRelicUser() *schema.Resource {
+	validUserTypes := make([]string, 0, len(userTypes))
+	for k := range userTypes {
+		validUserTypes = append(validUserTypes, k)
+	}
+
+	return &schema.Resource{
+		Create: resourceNewRelicUserCreate,
+		Read:   resourceNewRelicUserRead,
+		Update: resourceNewRelicUserUpdate,
+		Delete: resourceNewRelicUserDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+		Schema: map[string]*schema.Schema{
+			"authentication_domain_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The ID of the authentication domain the user belongs to.",
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The name of the user.",
+			},
+			"email": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The email address of the user.",
+			},
+			"user_type": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "BASIC_USER_TIER",
+				ValidateFunc: validation.StringInSlice(validUserTypes, false),
+				Description:  "The type of the user. One of: (BASIC_USER_TIER, CORE_USER_TIER, FULL_USER_TIER).",
+			},
+		},
+	}
+}
+
+func resourceNewRelicUserCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*ProviderConfig).NewClient
+
+	input := userCreateInput{
+		AuthenticationDomainID: d.Get("authentication_domain_id").(string),
+		Name:                   d.Get("name").(string),
+		Email:                  d.Get("email").(string),
+		UserType:               d.Get("user_type").(string),
+	}
+
+	log.Printf("[INFO] Creating New Relic user %s", input.Email)
+
+	created, err := createUser(client, input)
+	if err != nil {
+		return err
+	}
+
+	if created == nil {
+		return fmt.Errorf("no user was returned after creating user %s", input.Email)
+	}
+
+	d.SetId(created.ID)
+
+	return readAfterWrite(resourceNewRelicUserRead, d, meta)
+}
+
+func resourceNewRelicUserRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*ProviderConfig).NewClient
+
+	log.Printf("[INFO] Reading New Relic user %s", d.Id())
+
+	u, domainID, err := getUser(client, d.Id())
+	if err != nil {
+		if _, ok := err.(*errors.NotFound); ok {
+			d.SetId("")
+			return nil
+		}
+
+		return err
+	}
+
+	d.Set("authentication_domain_id", domainID)
+	d.Set("name", u.Name)
+	d.Set("email", u.Email)
+
+	for k, displayName := range userTypes {
+		if u.Type.DisplayName == displayName {
+			d.Set("user_type", k)
+		}
+	}
+
+	return nil
+}
+
+func resourceNewRelicUserUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*ProviderConfig).NewClient
+
+	input := userUpdateInput{
+		ID:       d.Id(),
+		Name:     d.Get("name").(string),
+		Email:    d.Get("email").(string),
+		UserType: d.Get("user_type").(string),
+	}
+
+	log.Printf("[INFO] Updating New Relic user %s", d.Id())
+
+	if err := updateUser(client, input); err != nil {
+		return err
+	}
+
+	return readAfterWrite(resourceNewRelicUserRead, d, meta)
+}
+
+func resourceNewRelicUserDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*ProviderConfig).NewClient
+
+	log.Printf("[INFO] Deleting New Relic user %s", d.Id())
+
+	return deleteUser(client, d.Id())
+}
@@ -0,0 +1,62 @@
+// +build unit
+
+package newrelic
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+)
+
+func TestAccNewRelicUser_Offline(t *testing.T) {
+	api := newFakeNewRelicAPI(t)
+	resourceName := "newrelic_user.foo"
+	rName := fmt.Sprintf("tf-test-%s", acctest.RandString(5))
+
+	resource.ParallelTest(t, resource.TestCase{
+		IsUnitTest:   true,
+		Providers:    api.providers(),
+		CheckDestroy: api.checkDestroyed(fakeKindUser),
+		Steps: []resource.TestStep{
+			// Test: Create
+			{
+				Config: api.config(testAccNewRelicUserUnitConfig(rName, "BASIC_USER_TIER")),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "name", rName),
+					resource.TestCheckResourceAttr(resourceName, "email", rName+"@example.com"),
+					resource.TestCheckResourceAttr(resourceName, "user_type", "BASIC_USER_TIER"),
+				),
+			},
+			// Test: Update
+			{
+				Config: api.config(testAccNewRelicUserUnitConfig(rName+"-updated", "FULL_USER_TIER")),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "name", rName+"-updated"),
+					resource.TestCheckResourceAttr(resourceName, "user_type", "FULL_USER_TIER"),
+				),
+			},
+			// Test: Import
+			{
+				Config:            api.config(testAccNewRelicUserUnitConfig(rName+"-updated", "FULL_USER_TIER")),
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+// The user is created in the second authentication domain of the fake, so
+// reading it follows the pagination of the domains.
+func testAccNewRelicUserUnitConfig(name string, userType string) string {
+	return fmt.Sprintf(`
+resource "newrelic_user" "foo" {
+	authentication_domain_id = "fake-domain-2"
+	name                     = "%[1]s"
+	email                    = "%[1]s@example.com"
+	user_type                = "%[2]s"
+}
+`, name, userType)
+}
@@ -0,0 +1,291 @@
+package newrelic
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/hashcode"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/newrelic/newrelic-client-go/pkg/errors"
+)
+
+var workflowMutingRulesHandlings = []string{
+	"DONT_NOTIFY_FULLY_MUTED_ISSUES",
+	"DONT_NOTIFY_FULLY_OR_PARTIALLY_MUTED_ISSUES",
+	"NOTIFY_ALL_ISSUES",
+}
+
+var workflowPredicateOperators = []string{
+	"CONTAINS",
+	"DOES_NOT_CONTAIN",
+	"DOES_NOT_EQUAL",
+	"DOES_NOT_EXACTLY_MATCH",
+	"ENDS_WITH",
+	"EQUAL",
+	"EXACTLY_MATCHES",
+	"GREATER_OR_EQUAL",
+	"GREATER_THAN",
+	"IS",
+	"IS_NOT",
+	"LESS_OR_EQUAL",
+	"LESS_THAN",
+	"STARTS_WITH",
+}
+
+func resourceNewRelicWorkflow() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceNewRelicWorkflowCreate,
+		Read:   resourceNewRelicWorkflowRead,
+		Update: resourceNewRelicWorkflowUpdate,
+		Delete: resourceNewRelicWorkflowDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+		Schema: map[string]*schema.Schema{
+			"account_id": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "The New Relic account ID where you want to create the workflow.",
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The name of the workflow.",
+			},
+			"enabled": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Whether the workflow is enabled.",
+			},
+			"destinations_enabled": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Whether notifications are sent to the workflow's destinations.",
+			},
+			"enrichments_enabled": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Whether enrichments are added to the workflow's notifications.",
+			},
+			"muting_rules_handling": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "NOTIFY_ALL_ISSUES",
+				Description:  fmt.Sprintf("How muted issues are handled. One of: (%s).", strings.Join(workflowMutingRulesHandlings, ", ")),
+				ValidateFunc: validation.StringInSlice(workflowMutingRulesHandlings, false),
+			},
+			"issues_filter": {
+				Type:        schema.TypeList,
+				Required:    true,
+				MinItems:    1,
+				MaxItems:    1,
+				Description: "The filter that decides which issues are routed through the workflow.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"filter_id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The ID of the filter.",
+						},
+						"name": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "The name of the filter.",
+						},
+						"type": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							Default:      "FILTER",
+							Description:  "The type of the filter. One of: (FILTER, VIEW).",
+							ValidateFunc: validation.StringInSlice([]string{"FILTER", "VIEW"}, false),
+						},
+						"predicate": {
+							Type:        schema.TypeList,
+							Optional:    true,
+							Description: "The conditions an issue must meet, all of which must match.",
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"attribute": {
+										Type:        schema.TypeString,
+										Required:    true,
+										Description: "The issue attribute to compare, e.g. labels.policyIds.",
+									},
+									"operator": {
+										Type:         schema.TypeString,
+										Required:     true,
+										Description:  fmt.Sprintf("The comparison operator. One of: (%s).", strings.Join(workflowPredicateOperators, ", ")),
+										ValidateFunc: validation.StringInSlice(workflowPredicateOperators, false),
+									},
+									"values": {
+										Type:        schema.TypeList,
+										Required:    true,
+										MinItems:    1,
+										Description: "The values to compare the attribute against.",
+										Elem:        &schema.Schema{Type: schema.TypeString},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			"enrichments": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Description: "Additional data queried and attached to each notification.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"nrql": {
+							Type:        schema.TypeList,
+							Required:    true,
+							MinItems:    1,
+							Description: "NRQL query enrichments.",
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"enrichment_id": {
+										Type:        schema.TypeString,
+										Computed:    true,
+										Description: "The ID of the enrichment.",
+									},
+									"name": {
+										Type:        schema.TypeString,
+										Required:    true,
+										Description: "The name of the enrichment.",
+									},
+									"configuration": {
+										Type:        schema.TypeList,
+										Required:    true,
+										MinItems:    1,
+										Description: "The queries run for the enrichment.",
+										Elem: &schema.Resource{
+											Schema: map[string]*schema.Schema{
+												"query": {
+													Type:        schema.TypeString,
+													Required:    true,
+													Description: "The NRQL query.",
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			"destination": {
+				Type:        schema.TypeSet,
+				Required:    true,
+				MinItems:    1,
+				Description: "The notification channels the workflow sends to.",
+				Set:         hashWorkflowDestination,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"channel_id": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "The ID of the notification channel.",
+						},
+						"name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The name of the notification channel.",
+						},
+						"type": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The type of the notification channel.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Destinations are identified by their channel alone, so the computed
+// attributes do not cause spurious diffs.
+func hashWorkflowDestination(v interface{}) int {
+	return hashcode.String(v.(map[string]interface{})["channel_id"].(string))
+}
+
+func resourceNewRelicWorkflowCreate(d *schema.ResourceData, meta interface{}) error {
+	providerConfig := meta.(*ProviderConfig)
+	client := accountClient(providerConfig, d)
+	accountID := selectAccountID(providerConfig, d)
+
+	createInput := expandWorkflowCreateInput(d)
+
+	log.Printf("[INFO] Creating New Relic workflow %s", createInput.Name)
+
+	created, err := createWorkflow(client, accountID, createInput)
+	if err != nil {
+		return err
+	}
+
+	d.SetId(created.ID)
+
+	return readAfterWrite(resourceNewRelicWorkflowRead, d, meta)
+}
+
+func resourceNewRelicWorkflowRead(d *schema.ResourceData, meta interface{}) error {
+	providerConfig := meta.(*ProviderConfig)
+	client := accountClient(providerConfig, d)
+	accountID := selectAccountID(providerConfig, d)
+
+	log.Printf("[INFO] Reading New Relic workflow %s", d.Id())
+
+	w, err := getWorkflow(client, accountID, d.Id())
+	if err != nil {
+		if _, ok := err.(*errors.NotFound); ok {
+			d.SetId("")
+			return nil
+		}
+
+		return err
+	}
+
+	d.Set("account_id", accountID)
+
+	return flattenWorkflow(w, d)
+}
+
+func resourceNewRelicWorkflowUpdate(d *schema.ResourceData, meta interface{}) error {
+	providerConfig := meta.(*ProviderConfig)
+	client := accountClient(providerConfig, d)
+	accountID := selectAccountID(providerConfig, d)
+
+	log.Printf("[INFO] Updating New Relic workflow %s", d.Id())
+
+	if _, err := updateWorkflow(client, accountID, expandWorkflowUpdateInput(d, d.Id())); err != nil {
+		return err
+	}
+
+	return readAfterWrite(resourceNewRelicWorkflowRead, d, meta)
+}
+
+func resourceNewRelicWorkflowDelete(d *schema.ResourceData, meta interface{}) error {
+	providerConfig := meta.(*ProviderConfig)
+	client := accountClient(providerConfig, d)
+	accountID := selectAccountID(providerConfig, d)
+
+	log.Printf("[INFO] Deleting New Relic workflow %s", d.Id())
+
+	if err := deleteWorkflow(client, accountID, d.Id()); err != nil {
+		if _, ok := err.(*errors.NotFound); ok {
+			return nil
+		}
+
+		return err
+	}
+
+	return nil
+}
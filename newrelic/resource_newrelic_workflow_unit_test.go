@@ -0,0 +1,135 @@
+// +build unit
+
+package newrelic
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+)
+
+func TestAccNewRelicWorkflow_Offline(t *testing.T) {
+	api := newFakeNewRelicAPI(t)
+	resourceName := "newrelic_workflow.foo"
+	rName := fmt.Sprintf("tf-test-%s", acctest.RandString(5))
+
+	enrichments := `
+	enrichments {
+		nrql {
+			name = "Log count"
+			configuration {
+				query = "SELECT count(*) FROM Log"
+			}
+		}
+	}`
+
+	resource.ParallelTest(t, resource.TestCase{
+		IsUnitTest: true,
+		Providers:  api.providers(),
+		CheckDestroy: api.checkDestroyed(
+			fakeKindNotificationDestination,
+			fakeKindNotificationChannel,
+			fakeKindWorkflow,
+		),
+		Steps: []resource.TestStep{
+			// Test: Create
+			{
+				Config: api.config(testAccNewRelicWorkflowUnitConfig(rName, "NOTIFY_ALL_ISSUES", "")),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "name", rName),
+					resource.TestCheckResourceAttr(resourceName, "enabled", "true"),
+					resource.TestCheckResourceAttr(resourceName, "issues_filter.0.predicate.#", "1"),
+					resource.TestCheckResourceAttrSet(resourceName, "issues_filter.0.filter_id"),
+					resource.TestCheckResourceAttr(resourceName, "destination.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "enrichments.#", "0"),
+				),
+			},
+			// Test: Update
+			{
+				Config: api.config(testAccNewRelicWorkflowUnitConfig(rName+"-updated", "DONT_NOTIFY_FULLY_MUTED_ISSUES", enrichments)),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "name", rName+"-updated"),
+					resource.TestCheckResourceAttr(resourceName, "muting_rules_handling", "DONT_NOTIFY_FULLY_MUTED_ISSUES"),
+					resource.TestCheckResourceAttr(resourceName, "enrichments.0.nrql.0.name", "Log count"),
+					resource.TestCheckResourceAttrSet(resourceName, "enrichments.0.nrql.0.enrichment_id"),
+				),
+			},
+			// Test: Import
+			{
+				Config:            api.config(testAccNewRelicWorkflowUnitConfig(rName+"-updated", "DONT_NOTIFY_FULLY_MUTED_ISSUES", enrichments)),
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Test: Remove enrichments
+			{
+				Config: api.config(testAccNewRelicWorkflowUnitConfig(rName+"-updated", "DONT_NOTIFY_FULLY_MUTED_ISSUES", "")),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "enrichments.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func TestNewRelicWorkflow_DeleteNotFound(t *testing.T) {
+	api := newFakeNewRelicAPI(t)
+	d := resourceNewRelicWorkflow().Data(&terraform.InstanceState{ID: "missing"})
+
+	if err := resourceNewRelicWorkflowDelete(d, api.providerConfig(t)); err != nil {
+		t.Fatalf("deleting a workflow which no longer exists should succeed, got %s", err)
+	}
+}
+
+func testAccNewRelicWorkflowUnitConfig(name string, mutingRulesHandling string, enrichments string) string {
+	return fmt.Sprintf(`
+resource "newrelic_alert_policy" "foo" {
+	name = "%[1]s"
+}
+
+resource "newrelic_notification_destination" "foo" {
+	name = "%[1]s"
+	type = "EMAIL"
+
+	property {
+		key   = "email"
+		value = "example@example.com"
+	}
+}
+
+resource "newrelic_notification_channel" "foo" {
+	name           = "%[1]s"
+	type           = "EMAIL"
+	destination_id = newrelic_notification_destination.foo.id
+	product        = "IINT"
+
+	property {
+		key   = "subject"
+		value = "{{ issueTitle }}"
+	}
+}
+
+resource "newrelic_workflow" "foo" {
+	name                  = "%[1]s"
+	muting_rules_handling = "%[2]s"
+
+	issues_filter {
+		name = "%[1]s"
+
+		predicate {
+			attribute = "labels.policyIds"
+			operator  = "EXACTLY_MATCHES"
+			values    = [newrelic_alert_policy.foo.id]
+		}
+	}
+%[3]s
+
+	destination {
+		channel_id = newrelic_notification_channel.foo.id
+	}
+}
+`, name, mutingRulesHandling, enrichments)
+}
@@ -7,6 +7,7 @@ import (
 	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
 	"github.com/newrelic/newrelic-client-go/pkg/errors"
 )
 
@@ -53,6 +54,25 @@ func resourceNewRelicWorkload() *schema.Resource {
 					},
 				},
 			},
+			"description": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Relevant information about the workload.",
+			},
+			"status_config_automatic": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Description: "An input object used to represent an automatic status configuration.",
+				Elem:        workloadStatusConfigAutomaticSchemaElem(),
+			},
+			"status_config_static": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Description: "A static status that overrides the status of the workload.",
+				Elem:        workloadStatusConfigStaticSchemaElem(),
+			},
 			"scope_account_ids": {
 				Type:        schema.TypeSet,
 				Optional:    true,
@@ -84,14 +104,139 @@ func resourceNewRelicWorkload() *schema.Resource {
 	}
 }
 
+func workloadStatusConfigAutomaticSchemaElem() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"enabled": {
+				Type:        schema.TypeBool,
+				Required:    true,
+				Description: "Whether the automatic status configuration is enabled or not.",
+			},
+			"remaining_entities_rule": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Description: "The rule that rolls up the status of the entities not matched by any other rule.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"rollup": workloadRollupSchema(true),
+					},
+				},
+			},
+			"rule": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "A rule that rolls up the status of a set of entities.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"entity_guids": {
+							Type:        schema.TypeSet,
+							Optional:    true,
+							Description: "A list of entity GUIDs composing the rule.",
+							Elem:        &schema.Schema{Type: schema.TypeString},
+						},
+						"entity_search_query": {
+							Type:        schema.TypeList,
+							Optional:    true,
+							Description: "A list of search queries that select the entities of the rule.",
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"query": {
+										Type:        schema.TypeString,
+										Required:    true,
+										Description: "The query.",
+									},
+								},
+							},
+						},
+						"rollup": workloadRollupSchema(false),
+					},
+				},
+			},
+		},
+	}
+}
+
+// workloadRollupSchema returns the schema of the rollup of a rule, which for
+// the remaining entities rule also groups the entities.
+func workloadRollupSchema(groupBy bool) *schema.Schema {
+	s := map[string]*schema.Schema{
+		"strategy": {
+			Type:         schema.TypeString,
+			Required:     true,
+			ValidateFunc: validation.StringInSlice([]string{"BEST_STATUS_WINS", "WORST_STATUS_WINS"}, false),
+			Description:  "The rollup strategy that is applied to a group of entities. One of BEST_STATUS_WINS or WORST_STATUS_WINS.",
+		},
+		"threshold_type": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			ValidateFunc: validation.StringInSlice([]string{"FIXED", "PERCENTAGE"}, false),
+			Description:  "Type of threshold defined for the rule. One of FIXED or PERCENTAGE.",
+		},
+		"threshold_value": {
+			Type:         schema.TypeInt,
+			Optional:     true,
+			ValidateFunc: validation.IntAtLeast(0),
+			Description:  "Threshold value defined for the rule.",
+		},
+	}
+
+	if groupBy {
+		s["group_by"] = &schema.Schema{
+			Type:         schema.TypeString,
+			Required:     true,
+			ValidateFunc: validation.StringInSlice([]string{"ENTITY_TYPE"}, false),
+			Description:  "The grouping to be applied to the remaining entities.",
+		}
+	}
+
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Required:    true,
+		MaxItems:    1,
+		Description: "The input object used to represent a rollup strategy.",
+		Elem: &schema.Resource{
+			Schema: s,
+		},
+	}
+}
+
+func workloadStatusConfigStaticSchemaElem() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"description": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "A description that provides additional details about the status of the workload.",
+			},
+			"enabled": {
+				Type:        schema.TypeBool,
+				Required:    true,
+				Description: "Whether the static status configuration is enabled or not.",
+			},
+			"status": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringInSlice([]string{"DEGRADED", "DISRUPTED", "OPERATIONAL", "UNKNOWN"}, false),
+				Description:  "The status of the workload. One of DEGRADED, DISRUPTED, OPERATIONAL or UNKNOWN.",
+			},
+			"summary": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "A short description of the status of the workload.",
+			},
+		},
+	}
+}
+
 func resourceNewRelicWorkloadCreate(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*ProviderConfig).NewClient
+	client := accountClient(meta.(*ProviderConfig), d)
 	createInput := expandWorkloadCreateInput(d)
 	accountID := d.Get("account_id").(int)
 
 	log.Printf("[INFO] Creating New Relic One workload %s", createInput.Name)
 
-	created, err := client.Workloads.CreateWorkload(accountID, createInput)
+	created, err := createWorkload(client, accountID, createInput)
 	if err != nil {
 		return err
 	}
@@ -103,18 +248,18 @@ func resourceNewRelicWorkloadCreate(d *schema.ResourceData, meta interface{}) er
 	}
 
 	d.SetId(ids.String())
-	return resourceNewRelicWorkloadRead(d, meta)
+	return readAfterWrite(resourceNewRelicWorkloadRead, d, meta)
 }
 
 func resourceNewRelicWorkloadRead(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*ProviderConfig).NewClient
+	client := accountClient(meta.(*ProviderConfig), d)
 
 	ids, err := parseWorkloadIDs(d.Id())
 	if err != nil {
 		return err
 	}
 
-	workload, err := client.Workloads.GetWorkload(ids.AccountID, ids.GUID)
+	workload, err := getWorkload(client, ids.AccountID, ids.GUID)
 	if err != nil {
 		if _, ok := err.(*errors.NotFound); ok {
 			d.SetId("")
@@ -128,7 +273,7 @@ func resourceNewRelicWorkloadRead(d *schema.ResourceData, meta interface{}) erro
 }
 
 func resourceNewRelicWorkloadUpdate(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*ProviderConfig).NewClient
+	client := accountClient(meta.(*ProviderConfig), d)
 	updateInput := expandWorkloadUpdateInput(d)
 
 	log.Printf("[INFO] Updating New Relic One workload %s", d.Id())
@@ -138,18 +283,18 @@ func resourceNewRelicWorkloadUpdate(d *schema.ResourceData, meta interface{}) er
 		return err
 	}
 
-	_, err = client.Workloads.UpdateWorkload(ids.GUID, updateInput)
+	_, err = updateWorkload(client, ids.GUID, updateInput)
 	if err != nil {
 		return err
 	}
 
 	d.SetId(ids.String())
 
-	return resourceNewRelicWorkloadRead(d, meta)
+	return readAfterWrite(resourceNewRelicWorkloadRead, d, meta)
 }
 
 func resourceNewRelicWorkloadDelete(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*ProviderConfig).NewClient
+	client := accountClient(meta.(*ProviderConfig), d)
 
 	log.Printf("[INFO] Deleting New Relic One workload %s", d.Id())
 
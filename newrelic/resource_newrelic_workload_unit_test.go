@@ -0,0 +1,286 @@
+// +build unit
+
+package newrelic
+
+import (
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+)
+
+func TestAccNewRelicWorkload_Offline(t *testing.T) {
+	api := newFakeNewRelicAPI(t)
+	resourceName := "newrelic_workload.foo"
+	rName := acctest.RandString(5)
+	appName := fmt.Sprintf("tf-test-app-%s", acctest.RandString(5))
+	api.seedApplication(appName)
+
+	resource.ParallelTest(t, resource.TestCase{
+		IsUnitTest:   true,
+		Providers:    api.providers(),
+		CheckDestroy: api.checkDestroyed(fakeKindWorkload),
+		Steps: []resource.TestStep{
+			// Test: Create
+			{
+				Config: api.config(testAccNewRelicWorkloadUnitConfig(api.AccountID, rName, appName)),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "name", rName),
+					resource.TestCheckResourceAttr(resourceName, "entity_guids.#", "1"),
+					resource.TestCheckResourceAttrSet(resourceName, "guid"),
+					resource.TestCheckResourceAttrSet(resourceName, "workload_id"),
+				),
+			},
+			// Test: Update
+			{
+				Config: api.config(testAccNewRelicWorkloadUnitConfig(api.AccountID, rName+"-updated", appName)),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "name", rName+"-updated"),
+				),
+			},
+			// Test: Import
+			{
+				Config:                  api.config(testAccNewRelicWorkloadUnitConfig(api.AccountID, rName+"-updated", appName)),
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"entity_search_query", "composite_entity_search_query"},
+			},
+		},
+	})
+}
+
+func testAccNewRelicWorkloadUnitConfig(accountID int, name string, appName string) string {
+	return fmt.Sprintf(`
+data "newrelic_entity" "app" {
+	name   = "%[3]s"
+	domain = "APM"
+	type   = "APPLICATION"
+}
+
+resource "newrelic_workload" "foo" {
+	name       = "%[2]s"
+	account_id = %[1]d
+
+	entity_guids = [data.newrelic_entity.app.guid]
+
+	entity_search_query {
+		query = "name like '%[3]s'"
+	}
+
+	scope_account_ids = [%[1]d]
+}
+`, accountID, name, appName)
+}
+
+func TestAccNewRelicWorkload_OfflineStatusConfig(t *testing.T) {
+	api := newFakeNewRelicAPI(t)
+	resourceName := "newrelic_workload.foo"
+	rName := acctest.RandString(5)
+	appName := fmt.Sprintf("tf-test-app-%s", acctest.RandString(5))
+	api.seedApplication(appName)
+
+	resource.ParallelTest(t, resource.TestCase{
+		IsUnitTest:   true,
+		Providers:    api.providers(),
+		CheckDestroy: api.checkDestroyed(fakeKindWorkload),
+		Steps: []resource.TestStep{
+			// Test: Create
+			{
+				Config: api.config(testAccNewRelicWorkloadStatusConfigUnitConfig(api.AccountID, rName, appName, "WORST_STATUS_WINS")),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "description", "Checkout services"),
+					resource.TestCheckResourceAttr(resourceName, "status_config_automatic.0.enabled", "true"),
+					resource.TestCheckResourceAttr(resourceName, "status_config_automatic.0.remaining_entities_rule.0.rollup.0.group_by", "ENTITY_TYPE"),
+					resource.TestCheckResourceAttr(resourceName, "status_config_automatic.0.rule.0.entity_guids.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "status_config_automatic.0.rule.0.rollup.0.strategy", "WORST_STATUS_WINS"),
+					resource.TestCheckResourceAttr(resourceName, "status_config_automatic.0.rule.1.entity_search_query.0.query", "type = 'HOST'"),
+					resource.TestCheckResourceAttr(resourceName, "status_config_automatic.0.rule.1.rollup.0.threshold_value", "25"),
+					resource.TestCheckResourceAttr(resourceName, "status_config_static.0.status", "OPERATIONAL"),
+				),
+			},
+			// Test: Update
+			{
+				Config: api.config(testAccNewRelicWorkloadStatusConfigUnitConfig(api.AccountID, rName, appName, "BEST_STATUS_WINS")),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "status_config_automatic.0.rule.0.rollup.0.strategy", "BEST_STATUS_WINS"),
+				),
+			},
+			// Test: Import
+			{
+				Config:            api.config(testAccNewRelicWorkloadStatusConfigUnitConfig(api.AccountID, rName, appName, "BEST_STATUS_WINS")),
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Test: Remove the status configuration
+			{
+				Config: api.config(testAccNewRelicWorkloadUnitConfig(api.AccountID, rName, appName)),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "description", ""),
+					resource.TestCheckResourceAttr(resourceName, "status_config_automatic.#", "0"),
+					resource.TestCheckResourceAttr(resourceName, "status_config_static.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func testAccNewRelicWorkloadStatusConfigUnitConfig(accountID int, name string, appName string, strategy string) string {
+	return fmt.Sprintf(`
+data "newrelic_entity" "app" {
+	name   = "%[3]s"
+	domain = "APM"
+	type   = "APPLICATION"
+}
+
+resource "newrelic_workload" "foo" {
+	name        = "%[2]s"
+	account_id  = %[1]d
+	description = "Checkout services"
+
+	entity_guids = [data.newrelic_entity.app.guid]
+
+	status_config_automatic {
+		enabled = true
+
+		remaining_entities_rule {
+			rollup {
+				group_by = "ENTITY_TYPE"
+				strategy = "BEST_STATUS_WINS"
+			}
+		}
+
+		rule {
+			entity_guids = [data.newrelic_entity.app.guid]
+
+			rollup {
+				strategy = "%[4]s"
+			}
+		}
+
+		rule {
+			entity_search_query {
+				query = "type = 'HOST'"
+			}
+
+			rollup {
+				strategy        = "WORST_STATUS_WINS"
+				threshold_type  = "PERCENTAGE"
+				threshold_value = 25
+			}
+		}
+	}
+
+	status_config_static {
+		enabled = false
+		status  = "OPERATIONAL"
+		summary = "All checkout services are up"
+	}
+}
+`, accountID, name, appName, strategy)
+}
+
+func TestAccNewRelicWorkload_OfflineDataSource(t *testing.T) {
+	api := newFakeNewRelicAPI(t)
+	rName := acctest.RandString(5)
+	appName := fmt.Sprintf("tf-test-app-%s", acctest.RandString(5))
+	otherAppName := fmt.Sprintf("tf-test-other-%s", acctest.RandString(5))
+	api.seedApplication(appName)
+	api.seedApplication(otherAppName)
+
+	// An application outside the accounts the workload is scoped to
+	_, subAccountGUID := api.seedApplication(otherAppName + "-sub")
+	api.update(fakeKindEntity, subAccountGUID, func(entity map[string]interface{}) {
+		entity["accountId"] = fakeSubAccountID
+	})
+
+	resource.ParallelTest(t, resource.TestCase{
+		IsUnitTest:   true,
+		Providers:    api.providers(),
+		CheckDestroy: api.checkDestroyed(fakeKindWorkload),
+		Steps: []resource.TestStep{
+			// Test: Read
+			{
+				Config: api.config(testAccNewRelicWorkloadDataSourceUnitConfig(api.AccountID, rName, appName, otherAppName)),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair("data.newrelic_workload.by_name", "guid", "newrelic_workload.foo", "guid"),
+					resource.TestCheckResourceAttrPair("data.newrelic_workload.by_guid", "name", "newrelic_workload.foo", "name"),
+					resource.TestCheckResourceAttrPair("data.newrelic_workload.by_name", "permalink", "newrelic_workload.foo", "permalink"),
+					resource.TestCheckResourceAttr("data.newrelic_workload.by_name", "entity_guids.#", "1"),
+					resource.TestCheckResourceAttr("data.newrelic_workload.by_name", "entities.#", "2"),
+					resource.TestCheckResourceAttr("data.newrelic_workload.by_name", "entities.0.type", "APPLICATION"),
+					resource.TestCheckResourceAttr("data.newrelic_workload.by_name", "entities.0.domain", "APM"),
+					resource.TestCheckResourceAttr("data.newrelic_workload.by_guid", "entities.#", "2"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccNewRelicWorkload_OfflineDataSourceAccount(t *testing.T) {
+	api := newFakeNewRelicAPI(t)
+	rName := acctest.RandString(5)
+	appGUID := api.seedSubAccountApplication(fmt.Sprintf("tf-test-app-%s", acctest.RandString(5)))
+	api.seedWorkload(fakeSubAccountID, rName, appGUID)
+
+	resource.ParallelTest(t, resource.TestCase{
+		IsUnitTest: true,
+		Providers:  api.providers(),
+		Steps: []resource.TestStep{
+			// Test: Read a workload of a sub-account with its own key
+			{
+				Config: api.configWithProvider(testAccNewRelicUnitAccountBlock(fakeSubAccountID), fmt.Sprintf(`
+data "newrelic_workload" "foo" {
+	name              = "%s"
+	scope_account_ids = [%d, %d]
+}
+`, rName, api.AccountID, fakeSubAccountID)),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.newrelic_workload.foo", "account_id", strconv.Itoa(fakeSubAccountID)),
+					resource.TestCheckResourceAttr("data.newrelic_workload.foo", "entities.#", "1"),
+					resource.TestCheckResourceAttr("data.newrelic_workload.foo", "entities.0.guid", appGUID),
+					func(s *terraform.State) error {
+						if api.requestsWithAPIKey("NRAK-FAKE-SUB") == 0 {
+							return fmt.Errorf("expected the sub-account workload to be read with the sub-account API key")
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+func testAccNewRelicWorkloadDataSourceUnitConfig(accountID int, name string, appName string, otherAppName string) string {
+	return fmt.Sprintf(`
+data "newrelic_entity" "app" {
+	name   = "%[3]s"
+	domain = "APM"
+	type   = "APPLICATION"
+}
+
+resource "newrelic_workload" "foo" {
+	name       = "%[2]s"
+	account_id = %[1]d
+
+	entity_guids = [data.newrelic_entity.app.guid]
+
+	entity_search_query {
+		query = "name like '%[4]s'"
+	}
+}
+
+data "newrelic_workload" "by_name" {
+	name = newrelic_workload.foo.name
+}
+
+data "newrelic_workload" "by_guid" {
+	guid              = newrelic_workload.foo.guid
+	scope_account_ids = [%[1]d]
+}
+`, accountID, name, appName, otherAppName)
+}
@@ -0,0 +1,175 @@
+package newrelic
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"regexp"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	nrErrors "github.com/newrelic/newrelic-client-go/pkg/errors"
+)
+
+const (
+	defaultMaxRetries      = 3
+	defaultRetryWaitMin    = 1
+	defaultRetryWaitMax    = 30
+	defaultResourceTimeout = 20 * time.Minute
+)
+
+// errNotFoundAfterWrite is returned by a read made right after a write when
+// the written resource can not be found yet.
+var errNotFoundAfterWrite = errors.New("resource not found after write")
+
+// The client gives up on 429 and 5xx responses it already retried with this
+// message, without keeping the status code.
+var retriesExhaustedMessage = regexp.MustCompile(`giving up after \d+ attempt`)
+
+// retryPolicy controls how often and how long the provider retries a request
+// which failed with a transient error, such as a 429 or 5xx response, or a
+// read of a resource the New Relic APIs do not return yet.
+type retryPolicy struct {
+	MaxRetries int
+	WaitMin    time.Duration
+	WaitMax    time.Duration
+}
+
+// backoff returns the time to wait before the given retry, doubling from
+// WaitMin for each retry up to WaitMax.
+func (p retryPolicy) backoff(retry int) time.Duration {
+	wait := p.WaitMin
+
+	for i := 1; i < retry && wait < p.WaitMax; i++ {
+		wait *= 2
+	}
+
+	if wait > p.WaitMax {
+		wait = p.WaitMax
+	}
+
+	return wait
+}
+
+// retry calls fn until it succeeds, fails with an error which is not
+// retryable, the retries of the policy are used up or waiting for the next
+// retry would exceed the timeout. The last error of fn is returned.
+func (p retryPolicy) retry(timeout time.Duration, retryable func(error) bool, fn func() error) error {
+	deadline := time.Now().Add(timeout)
+
+	for retry := 1; ; retry++ {
+		err := fn()
+		if err == nil || !retryable(err) || retry > p.MaxRetries {
+			return err
+		}
+
+		wait := p.backoff(retry)
+		if time.Now().Add(wait).After(deadline) {
+			return err
+		}
+
+		log.Printf("[WARN] Retrying in %s (%d/%d): %s", wait, retry, p.MaxRetries, err)
+		time.Sleep(wait)
+	}
+}
+
+func providerRetryPolicy(meta interface{}) retryPolicy {
+	if providerConfig, ok := meta.(*ProviderConfig); ok {
+		return providerConfig.RetryPolicy
+	}
+
+	return retryPolicy{}
+}
+
+// isRetryableError reports whether err was caused by rate limiting or a
+// server side failure, which are worth retrying. The client retries 429 and 5xx
+// responses itself and only fails with them once its own retries are used up.
+func isRetryableError(err error) bool {
+	if _, ok := err.(*nrErrors.MaxRetriesReached); ok {
+		return true
+	}
+
+	return retriesExhaustedMessage.MatchString(err.Error())
+}
+
+func isNotFoundAfterWriteError(err error) bool {
+	if _, ok := err.(*nrErrors.NotFound); ok {
+		return true
+	}
+
+	return err == errNotFoundAfterWrite
+}
+
+// withRetryPolicy makes the resource retry reads which failed with a transient
+// error, and gives every operation of the resource a configurable timeout
+// bounding those retries. Creates, updates and deletes are not retried, as a
+// failed response does not tell whether the change was made anyway.
+func withRetryPolicy(r *schema.Resource) {
+	if r.Timeouts == nil {
+		r.Timeouts = &schema.ResourceTimeout{}
+	}
+
+	if r.Timeouts.Create == nil {
+		r.Timeouts.Create = schema.DefaultTimeout(defaultResourceTimeout)
+	}
+
+	if r.Timeouts.Read == nil {
+		r.Timeouts.Read = schema.DefaultTimeout(defaultResourceTimeout)
+	}
+
+	if r.Update != nil && r.Timeouts.Update == nil {
+		r.Timeouts.Update = schema.DefaultTimeout(defaultResourceTimeout)
+	}
+
+	if r.Timeouts.Delete == nil {
+		r.Timeouts.Delete = schema.DefaultTimeout(defaultResourceTimeout)
+	}
+
+	if r.Read != nil {
+		r.Read = retryOnTransientError(r.Read, schema.TimeoutRead)
+	}
+}
+
+func retryOnTransientError(fn func(*schema.ResourceData, interface{}) error, timeout string) func(*schema.ResourceData, interface{}) error {
+	return func(d *schema.ResourceData, meta interface{}) error {
+		return providerRetryPolicy(meta).retry(d.Timeout(timeout), isRetryableError, func() error {
+			return fn(d, meta)
+		})
+	}
+}
+
+// readAfterWrite reads a resource right after it was created or updated.
+// Writes take a moment to show up in the New Relic APIs, so a resource which
+// is not found yet is read again instead of being removed from the state. As
+// reading is safe to repeat, transient errors are retried as well.
+func readAfterWrite(read schema.ReadFunc, d *schema.ResourceData, meta interface{}) error {
+	id := d.Id()
+
+	timeout := schema.TimeoutUpdate
+	if d.IsNewResource() {
+		timeout = schema.TimeoutCreate
+	}
+
+	retryable := func(err error) bool {
+		return isNotFoundAfterWriteError(err) || isRetryableError(err)
+	}
+
+	err := providerRetryPolicy(meta).retry(d.Timeout(timeout), retryable, func() error {
+		if err := read(d, meta); err != nil {
+			return err
+		}
+
+		if d.Id() == "" {
+			d.SetId(id)
+			return errNotFoundAfterWrite
+		}
+
+		return nil
+	})
+
+	if isNotFoundAfterWriteError(err) {
+		return fmt.Errorf("%s was written but could not be read back: %s", id, err)
+	}
+
+	return err
+}
@@ -0,0 +1,142 @@
+// +build unit
+
+package newrelic
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	nrErrors "github.com/newrelic/newrelic-client-go/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRetryPolicy_Backoff(t *testing.T) {
+	p := retryPolicy{MaxRetries: 5, WaitMin: time.Second, WaitMax: 5 * time.Second}
+
+	require.Equal(t, time.Second, p.backoff(1))
+	require.Equal(t, 2*time.Second, p.backoff(2))
+	require.Equal(t, 4*time.Second, p.backoff(3))
+	require.Equal(t, 5*time.Second, p.backoff(4))
+	require.Equal(t, 5*time.Second, p.backoff(10))
+}
+
+func TestRetryPolicy_Retry(t *testing.T) {
+	p := retryPolicy{MaxRetries: 2}
+	retryable := errors.New("retryable")
+
+	calls := 0
+	err := p.retry(time.Minute, func(err error) bool { return err == retryable }, func() error {
+		calls++
+		return retryable
+	})
+	require.Equal(t, retryable, err)
+	require.Equal(t, 3, calls)
+
+	calls = 0
+	err = p.retry(time.Minute, func(err error) bool { return err == retryable }, func() error {
+		calls++
+		return errors.New("fatal")
+	})
+	require.EqualError(t, err, "fatal")
+	require.Equal(t, 1, calls)
+}
+
+func TestIsRetryableError(t *testing.T) {
+	require.True(t, isRetryableError(nrErrors.NewMaxRetriesReached("server error")))
+	require.True(t, isRetryableError(errors.New("GET https://api.newrelic.com/v2/applications.json giving up after 4 attempt(s)")))
+
+	require.False(t, isRetryableError(nrErrors.NewUnexpectedStatusCode(422, "invalid")))
+	require.False(t, isRetryableError(nrErrors.NewUnexpectedStatusCode(501, "")))
+	require.False(t, isRetryableError(nrErrors.NewNotFound("resource not found")))
+	require.False(t, isRetryableError(errors.New("invalid condition")))
+}
+
+func TestWithRetryPolicy(t *testing.T) {
+	meta := &ProviderConfig{RetryPolicy: retryPolicy{MaxRetries: 2}}
+
+	reads, updates := 0, 0
+	r := &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"name": {Type: schema.TypeString, Optional: true},
+		},
+		Read: func(d *schema.ResourceData, meta interface{}) error {
+			reads++
+			return nrErrors.NewMaxRetriesReached("server error")
+		},
+		Update: func(d *schema.ResourceData, meta interface{}) error {
+			updates++
+			return nrErrors.NewMaxRetriesReached("server error")
+		},
+	}
+	withRetryPolicy(r)
+
+	// Reads are retried.
+	require.Error(t, r.Read(r.TestResourceData(), meta))
+	require.Equal(t, 3, reads)
+
+	// Updates are not, as they may have been applied anyway.
+	require.Error(t, r.Update(r.TestResourceData(), meta))
+	require.Equal(t, 1, updates)
+}
+
+func TestReadAfterWrite(t *testing.T) {
+	meta := &ProviderConfig{RetryPolicy: retryPolicy{MaxRetries: 2}}
+	d := schema.TestResourceDataRaw(t, resourceNewRelicAlertChannel().Schema, map[string]interface{}{})
+	d.SetId("123")
+
+	// The resource shows up on the second read.
+	reads := 0
+	err := readAfterWrite(func(d *schema.ResourceData, meta interface{}) error {
+		reads++
+		if reads == 1 {
+			d.SetId("")
+		}
+		return nil
+	}, d, meta)
+	require.NoError(t, err)
+	require.Equal(t, 2, reads)
+	require.Equal(t, "123", d.Id())
+
+	// The resource never shows up.
+	reads = 0
+	err = readAfterWrite(func(d *schema.ResourceData, meta interface{}) error {
+		reads++
+		return nrErrors.NewNotFound("resource not found")
+	}, d, meta)
+	require.Error(t, err)
+	require.Equal(t, 3, reads)
+	require.Equal(t, "123", d.Id())
+}
+
+func TestAccNewRelicProvider_OfflineRetriesRateLimitedRequests(t *testing.T) {
+	api := newFakeNewRelicAPI(t)
+	resourceName := "newrelic_alert_channel.foo"
+	rName := fmt.Sprintf("tf-test-%s", acctest.RandString(5))
+
+	resource.ParallelTest(t, resource.TestCase{
+		IsUnitTest:   true,
+		Providers:    api.providers(),
+		CheckDestroy: api.checkDestroyed(fakeKindChannel),
+		Steps: []resource.TestStep{
+			// Test: Create
+			{
+				Config: api.config(testAccNewRelicAlertChannelUnitConfig(rName, "foo", "1")),
+				Check:  resource.TestCheckResourceAttr(resourceName, "name", rName),
+			},
+			// Test: Refresh outlasting the retries of the client
+			{
+				PreConfig: func() {
+					api.failNext(5, http.StatusTooManyRequests)
+				},
+				Config: api.config(testAccNewRelicAlertChannelUnitConfig(rName, "foo", "1")),
+				Check:  resource.TestCheckResourceAttr(resourceName, "name", rName),
+			},
+		},
+	})
+}
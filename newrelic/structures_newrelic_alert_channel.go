@@ -1,9 +1,16 @@
 package newrelic
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
+	"fmt"
+	"log"
+	"net/url"
+	"sort"
 	"strconv"
+	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	"github.com/newrelic/newrelic-client-go/pkg/alerts"
@@ -152,6 +159,162 @@ func expandAlertChannelConfiguration(cfg map[string]interface{}) (*alerts.Channe
 	return &config, nil
 }
 
+// expandAlertChannelUpdate converts a REST channel into the NerdGraph update
+// input for its type. User channels are managed by their user and cannot be
+// updated.
+func expandAlertChannelUpdate(channel *alerts.Channel) (*alertChannelUpdateInput, error) {
+	c := channel.Configuration
+	input := alertChannelUpdateInput{}
+
+	switch channel.Type {
+	case alerts.ChannelTypes.Email:
+		input.Email = &alertChannelEmailUpdate{
+			Name:        channel.Name,
+			Emails:      splitAlertChannelList(c.Recipients),
+			IncludeJSON: c.IncludeJSONAttachment == "1" || c.IncludeJSONAttachment == "true",
+		}
+	case alerts.ChannelTypes.OpsGenie:
+		input.OpsGenie = &alertChannelOpsGenieUpdate{
+			Name:             channel.Name,
+			APIKey:           optionalAlertChannelValue(c.APIKey),
+			DataCenterRegion: optionalAlertChannelValue(c.Region),
+			Recipients:       splitAlertChannelList(c.Recipients),
+			Tags:             splitAlertChannelList(c.Tags),
+			Teams:            splitAlertChannelList(c.Teams),
+		}
+	case alerts.ChannelTypes.PagerDuty:
+		input.PagerDuty = &alertChannelPagerDutyUpdate{
+			Name:   channel.Name,
+			APIKey: c.ServiceKey,
+		}
+	case alerts.ChannelTypes.Slack:
+		input.Slack = &alertChannelSlackUpdate{
+			Name:        channel.Name,
+			TeamChannel: optionalAlertChannelValue(c.Channel),
+			URL:         optionalAlertChannelValue(c.URL),
+		}
+	case alerts.ChannelTypes.VictorOps:
+		input.VictorOps = &alertChannelVictorOpsUpdate{
+			Name:     channel.Name,
+			Key:      optionalAlertChannelValue(c.Key),
+			RouteKey: optionalAlertChannelValue(c.RouteKey),
+		}
+	case alerts.ChannelTypes.Webhook:
+		webhook, err := expandAlertChannelWebhookUpdate(channel)
+		if err != nil {
+			return nil, err
+		}
+
+		input.Webhook = webhook
+	default:
+		return nil, fmt.Errorf("alert channels of type %s cannot be updated", channel.Type)
+	}
+
+	return &input, nil
+}
+
+// optionalAlertChannelValue returns nil for a value missing from the
+// configuration, which clears it on update.
+func optionalAlertChannelValue(v string) *string {
+	if v == "" {
+		return nil
+	}
+
+	return &v
+}
+
+func expandAlertChannelWebhookUpdate(channel *alerts.Channel) (*alertChannelWebhookUpdate, error) {
+	c := channel.Configuration
+	webhook := alertChannelWebhookUpdate{
+		Name:              channel.Name,
+		BaseURL:           c.BaseURL,
+		CustomHTTPHeaders: []alertChannelHTTPHeader{},
+	}
+
+	if c.AuthUsername != "" || c.AuthPassword != "" {
+		webhook.BasicAuth = &alertChannelBasicAuth{
+			Username: c.AuthUsername,
+			Password: c.AuthPassword,
+		}
+	}
+
+	headerNames := make([]string, 0, len(c.Headers))
+	for k := range c.Headers {
+		headerNames = append(headerNames, k)
+	}
+	sort.Strings(headerNames)
+
+	for _, k := range headerNames {
+		value, err := stringifyAlertChannelValue(c.Headers[k])
+		if err != nil {
+			return nil, err
+		}
+
+		webhook.CustomHTTPHeaders = append(webhook.CustomHTTPHeaders, alertChannelHTTPHeader{Name: k, Value: value})
+	}
+
+	if len(c.Payload) == 0 {
+		return &webhook, nil
+	}
+
+	var payloadType, payloadBody string
+
+	switch c.PayloadType {
+	case "application/x-www-form-urlencoded":
+		form := url.Values{}
+		for k, v := range c.Payload {
+			value, err := stringifyAlertChannelValue(v)
+			if err != nil {
+				return nil, err
+			}
+
+			form.Set(k, value)
+		}
+
+		payloadType = "FORM"
+		payloadBody = form.Encode()
+	default:
+		body, err := json.Marshal(c.Payload)
+		if err != nil {
+			return nil, err
+		}
+
+		payloadType = "JSON"
+		payloadBody = string(body)
+	}
+
+	webhook.CustomPayloadType = &payloadType
+	webhook.CustomPayloadBody = &payloadBody
+
+	return &webhook, nil
+}
+
+func stringifyAlertChannelValue(v interface{}) (string, error) {
+	if s, ok := v.(string); ok {
+		return s, nil
+	}
+
+	b, err := json.Marshal(v)
+	if err != nil {
+		return "", err
+	}
+
+	return string(b), nil
+}
+
+// Splits a comma separated channel attribute, e.g. "a@example.com, b@example.com".
+func splitAlertChannelList(s string) []string {
+	out := []string{}
+
+	for _, v := range strings.Split(s, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			out = append(out, v)
+		}
+	}
+
+	return out
+}
+
 func expandAlertChannelIDs(channelIDs []interface{}) []int {
 	ids := make([]int, len(channelIDs))
 
@@ -195,81 +358,150 @@ func flattenAlertChannelConfiguration(c *alerts.ChannelConfiguration, d *schema.
 	configResult["auth_username"] = c.AuthUsername
 	configResult["base_url"] = c.BaseURL
 	configResult["channel"] = c.Channel
-	configResult["key"] = c.Key
 	configResult["include_json_attachment"] = c.IncludeJSONAttachment
 	configResult["payload_type"] = c.PayloadType
 	configResult["recipients"] = c.Recipients
 	configResult["region"] = c.Region
-	configResult["route_key"] = c.RouteKey
 	configResult["tags"] = c.Tags
 	configResult["teams"] = c.Teams
 	configResult["user_id"] = c.UserID
 
-	if attr, ok := d.GetOk("config.0.auth_password"); ok {
-		if c.AuthPassword != "" {
-			configResult["auth_password"] = c.AuthPassword
-		} else {
-			configResult["auth_password"] = attr.(string)
-		}
+	stored, _ := d.Get("config_fingerprints").(map[string]interface{})
+	remote, err := fingerprintAlertChannelConfiguration(c)
+	if err != nil {
+		return nil, err
 	}
 
-	if attr, ok := d.GetOk("config.0.api_key"); ok {
-		if c.APIKey != "" {
-			configResult["api_key"] = c.APIKey
-		} else {
-			configResult["api_key"] = attr.(string)
+	for _, k := range []string{"api_key", "auth_password", "key", "route_key", "service_key", "url"} {
+		if v, ok := flattenSensitiveAlertChannelValue(d, k, stored, remote); ok {
+			configResult[k] = v
 		}
 	}
 
-	if attr, ok := d.GetOk("config.0.url"); ok {
-		if c.URL != "" {
-			configResult["url"] = c.URL
+	if attr, ok := d.GetOk("config.0.headers"); ok {
+		if alertChannelValueDrifted("headers", stored, remote) {
+			configResult["headers"] = map[string]interface{}{}
 		} else {
-			configResult["url"] = attr.(string)
+			configResult["headers"] = attr
 		}
-	}
-
-	if attr, ok := d.GetOk("config.0.key"); ok {
-		if c.Key != "" {
-			configResult["key"] = c.Key
+	} else if attr, ok := d.GetOk("config.0.headers_string"); ok {
+		if alertChannelValueDrifted("headers", stored, remote) {
+			configResult["headers_string"] = ""
 		} else {
-			configResult["key"] = attr.(string)
+			configResult["headers_string"] = attr
 		}
 	}
 
-	if attr, ok := d.GetOk("config.0.service_key"); ok {
-		if c.ServiceKey != "" {
-			configResult["service_key"] = c.ServiceKey
-		} else {
-			configResult["service_key"] = attr.(string)
+	if _, ok := d.GetOk("config.0.payload"); ok {
+		configResult["payload"] = c.Payload
+	} else if _, ok := d.GetOk("config.0.payload_string"); ok {
+		h, err := json.Marshal(c.Payload)
+
+		if err != nil {
+			return nil, err
 		}
+
+		configResult["payload_string"] = string(h)
 	}
 
-	if _, ok := d.GetOk("config.0.headers"); ok {
-		configResult["headers"] = c.Headers
-	} else if _, ok := d.GetOk("config.0.headers_string"); ok {
-		h, err := json.Marshal(c.Headers)
+	return []interface{}{configResult}, nil
+}
 
+// Sensitive config values are write-only or may be returned masked, so state
+// keeps a SHA-256 fingerprint of each value last applied. Comparing it with the
+// fingerprint of what the API returns detects changes made outside of
+// Terraform without the remote plaintext ever being written to state.
+func fingerprintAlertChannelConfiguration(c *alerts.ChannelConfiguration) (map[string]interface{}, error) {
+	values := map[string]string{
+		"api_key":       c.APIKey,
+		"auth_password": c.AuthPassword,
+		"key":           c.Key,
+		"route_key":     c.RouteKey,
+		"service_key":   c.ServiceKey,
+		"url":           c.URL,
+	}
+
+	if len(c.Headers) > 0 {
+		// Map keys are sorted when marshaled, so equal headers always
+		// produce the same fingerprint.
+		h, err := json.Marshal(c.Headers)
 		if err != nil {
 			return nil, err
 		}
 
-		configResult["headers_string"] = string(h)
+		values["headers"] = string(h)
 	}
 
-	if _, ok := d.GetOk("config.0.payload"); ok {
-		configResult["payload"] = c.Payload
-	} else if _, ok := d.GetOk("config.0.payload_string"); ok {
-		h, err := json.Marshal(c.Payload)
+	fingerprints := map[string]interface{}{}
 
-		if err != nil {
-			return nil, err
+	for k, v := range values {
+		if isMaskedAlertChannelValue(v) {
+			continue
 		}
 
-		configResult["payload_string"] = string(h)
+		sum := sha256.Sum256([]byte(v))
+		fingerprints[k] = hex.EncodeToString(sum[:])
 	}
 
-	return []interface{}{configResult}, nil
+	return fingerprints, nil
+}
+
+// Empty values and values masked with asterisks cannot be compared.
+func isMaskedAlertChannelValue(v string) bool {
+	return strings.Trim(v, "*") == ""
+}
+
+func alertChannelValueDrifted(key string, stored map[string]interface{}, remote map[string]interface{}) bool {
+	fingerprint, ok := stored[key]
+	if !ok {
+		return false
+	}
+
+	remoteFingerprint, ok := remote[key]
+	if !ok || fingerprint == remoteFingerprint {
+		return false
+	}
+
+	log.Printf("[WARN] New Relic alert channel config.0.%s has been changed outside of Terraform", key)
+
+	return true
+}
+
+// flattenSensitiveAlertChannelValue returns the value to store for a sensitive
+// config field that is set in state. The configured value is kept, so the
+// plaintext returned by the API never reaches state. Drifted values are
+// cleared, which makes the next plan restore the configured value.
+func flattenSensitiveAlertChannelValue(d *schema.ResourceData, key string, stored map[string]interface{}, remote map[string]interface{}) (string, bool) {
+	attr, ok := d.GetOk("config.0." + key)
+	if !ok {
+		return "", false
+	}
+
+	if alertChannelValueDrifted(key, stored, remote) {
+		return "", true
+	}
+
+	return attr.(string), true
+}
+
+// mergeAlertChannelFingerprints keeps the fingerprints of applied values and
+// adopts the remote fingerprint of any field that has none yet, such as after
+// an import.
+func mergeAlertChannelFingerprints(d *schema.ResourceData, c *alerts.ChannelConfiguration) (map[string]interface{}, error) {
+	remote, err := fingerprintAlertChannelConfiguration(c)
+	if err != nil {
+		return nil, err
+	}
+
+	merged := d.Get("config_fingerprints").(map[string]interface{})
+
+	for k, v := range remote {
+		if _, ok := merged[k]; !ok {
+			merged[k] = v
+		}
+	}
+
+	return merged, nil
 }
 
 func validateChannelConfiguration(config alerts.ChannelConfiguration) error {
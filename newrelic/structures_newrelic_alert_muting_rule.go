@@ -1,11 +1,13 @@
 package newrelic
 
 import (
+	"time"
+
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	"github.com/newrelic/newrelic-client-go/pkg/alerts"
 )
 
-func expandMutingRuleCreateInput(d *schema.ResourceData) alerts.MutingRuleCreateInput {
+func expandMutingRuleCreateInput(d *schema.ResourceData) (alerts.MutingRuleCreateInput, error) {
 	createInput := alerts.MutingRuleCreateInput{
 		Enabled:     d.Get("enabled").(bool),
 		Name:        d.Get("name").(string),
@@ -16,11 +18,28 @@ func expandMutingRuleCreateInput(d *schema.ResourceData) alerts.MutingRuleCreate
 		createInput.Condition = expandMutingRuleConditionGroup(e.([]interface{})[0].(map[string]interface{}))
 	}
 
-	return createInput
+	if e, ok := d.GetOk("schedule"); ok {
+		schedule, err := expandMutingRuleSchedule(e.([]interface{})[0].(map[string]interface{}))
+		if err != nil {
+			return createInput, err
+		}
+
+		createInput.Schedule = &alerts.MutingRuleScheduleCreateInput{
+			StartTime:        schedule.StartTime,
+			EndTime:          schedule.EndTime,
+			TimeZone:         schedule.TimeZone,
+			Repeat:           schedule.Repeat,
+			EndRepeat:        schedule.EndRepeat,
+			RepeatCount:      schedule.RepeatCount,
+			WeeklyRepeatDays: schedule.WeeklyRepeatDays,
+		}
+	}
+
+	return createInput, nil
 }
 
-func expandMutingRuleUpdateInput(d *schema.ResourceData) alerts.MutingRuleUpdateInput {
-	updateInput := alerts.MutingRuleUpdateInput{
+func expandMutingRuleUpdateInput(d *schema.ResourceData) (mutingRuleUpdateInput, error) {
+	updateInput := mutingRuleUpdateInput{
 		Enabled:     d.Get("enabled").(bool),
 		Name:        d.Get("name").(string),
 		Description: d.Get("description").(string),
@@ -32,7 +51,71 @@ func expandMutingRuleUpdateInput(d *schema.ResourceData) alerts.MutingRuleUpdate
 		updateInput.Condition = &x
 	}
 
-	return updateInput
+	if e, ok := d.GetOk("schedule"); ok {
+		schedule, err := expandMutingRuleSchedule(e.([]interface{})[0].(map[string]interface{}))
+		if err != nil {
+			return updateInput, err
+		}
+
+		updateInput.Schedule = schedule
+	}
+
+	return updateInput, nil
+}
+
+func expandMutingRuleSchedule(cfg map[string]interface{}) (*mutingRuleScheduleUpdateInput, error) {
+	schedule := mutingRuleScheduleUpdateInput{
+		TimeZone: cfg["time_zone"].(string),
+	}
+
+	var err error
+
+	if schedule.StartTime, err = expandMutingRuleNaiveDateTime(cfg["start_time"].(string)); err != nil {
+		return nil, err
+	}
+
+	if schedule.EndTime, err = expandMutingRuleNaiveDateTime(cfg["end_time"].(string)); err != nil {
+		return nil, err
+	}
+
+	if schedule.EndRepeat, err = expandMutingRuleNaiveDateTime(cfg["end_repeat"].(string)); err != nil {
+		return nil, err
+	}
+
+	if repeat := cfg["repeat"].(string); repeat != "" {
+		r := alerts.MutingRuleScheduleRepeat(repeat)
+		schedule.Repeat = &r
+	}
+
+	if repeatCount := cfg["repeat_count"].(int); repeatCount > 0 {
+		schedule.RepeatCount = &repeatCount
+	}
+
+	if days := cfg["weekly_repeat_days"].(*schema.Set).List(); len(days) > 0 {
+		weeklyRepeatDays := make([]alerts.DayOfWeek, len(days))
+		for i, day := range days {
+			weeklyRepeatDays[i] = alerts.DayOfWeek(day.(string))
+		}
+
+		schedule.WeeklyRepeatDays = &weeklyRepeatDays
+	}
+
+	return &schedule, nil
+}
+
+// The schedule's times are local to its time zone, so they are sent without
+// an offset.
+func expandMutingRuleNaiveDateTime(v string) (*alerts.NaiveDateTime, error) {
+	if v == "" {
+		return nil, nil
+	}
+
+	t, err := time.Parse(naiveDateTimeFormat, v)
+	if err != nil {
+		return nil, err
+	}
+
+	return &alerts.NaiveDateTime{Time: t}, nil
 }
 
 func expandMutingRuleConditionGroup(cfg map[string]interface{}) alerts.MutingRuleConditionGroup {
@@ -97,7 +180,57 @@ func flattenMutingRule(mutingRule *alerts.MutingRule, d *schema.ResourceData) er
 	d.Set("description", mutingRule.Description)
 	d.Set("name", mutingRule.Name)
 
-	return nil
+	return d.Set("schedule", flattenMutingRuleSchedule(mutingRule.Schedule))
+}
+
+func flattenMutingRuleSchedule(in *alerts.MutingRuleSchedule) []interface{} {
+	if in == nil {
+		return []interface{}{}
+	}
+
+	// The API returns times with an offset; convert them back to the wall
+	// clock of the schedule's time zone so they match the configuration.
+	location, _ := time.LoadLocation(in.TimeZone)
+
+	schedule := map[string]interface{}{
+		"time_zone":    in.TimeZone,
+		"start_time":   flattenMutingRuleNaiveDateTime(in.StartTime, location),
+		"end_time":     flattenMutingRuleNaiveDateTime(in.EndTime, location),
+		"end_repeat":   flattenMutingRuleNaiveDateTime(in.EndRepeat, location),
+		"repeat":       "",
+		"repeat_count": 0,
+	}
+
+	if in.Repeat != nil {
+		schedule["repeat"] = string(*in.Repeat)
+	}
+
+	if in.RepeatCount != nil {
+		schedule["repeat_count"] = *in.RepeatCount
+	}
+
+	if in.WeeklyRepeatDays != nil {
+		days := make([]interface{}, len(*in.WeeklyRepeatDays))
+		for i, day := range *in.WeeklyRepeatDays {
+			days[i] = string(day)
+		}
+
+		schedule["weekly_repeat_days"] = schema.NewSet(schema.HashString, days)
+	}
+
+	return []interface{}{schedule}
+}
+
+func flattenMutingRuleNaiveDateTime(t *time.Time, location *time.Location) string {
+	if t == nil {
+		return ""
+	}
+
+	if location != nil {
+		return t.In(location).Format(naiveDateTimeFormat)
+	}
+
+	return t.Format(naiveDateTimeFormat)
 }
 
 func flattenMutingRuleConditionGroup(in alerts.MutingRuleConditionGroup, configuredCondition []interface{}, ok bool) []map[string]interface{} {
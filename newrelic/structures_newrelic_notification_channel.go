@@ -0,0 +1,34 @@
+package newrelic
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+func expandNotificationChannelInput(d *schema.ResourceData) notificationChannelInput {
+	return notificationChannelInput{
+		Name:          d.Get("name").(string),
+		Type:          d.Get("type").(string),
+		DestinationID: d.Get("destination_id").(string),
+		Product:       d.Get("product").(string),
+		Properties:    expandNotificationProperties(d.Get("property").(*schema.Set).List()),
+	}
+}
+
+func expandNotificationChannelUpdate(d *schema.ResourceData) notificationChannelUpdate {
+	return notificationChannelUpdate{
+		Name:       d.Get("name").(string),
+		Active:     d.Get("active").(bool),
+		Properties: expandNotificationProperties(d.Get("property").(*schema.Set).List()),
+	}
+}
+
+func flattenNotificationChannel(channel *notificationChannel, d *schema.ResourceData) error {
+	d.Set("name", channel.Name)
+	d.Set("type", channel.Type)
+	d.Set("destination_id", channel.DestinationID)
+	d.Set("product", channel.Product)
+	d.Set("active", channel.Active)
+	d.Set("status", channel.Status)
+
+	return d.Set("property", flattenNotificationProperties(channel.Properties))
+}
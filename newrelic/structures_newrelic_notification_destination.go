@@ -0,0 +1,126 @@
+package newrelic
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+func expandNotificationDestinationInput(d *schema.ResourceData) notificationDestinationInput {
+	return notificationDestinationInput{
+		Name:       d.Get("name").(string),
+		Type:       d.Get("type").(string),
+		Properties: expandNotificationProperties(d.Get("property").(*schema.Set).List()),
+		Auth:       expandNotificationAuth(d),
+	}
+}
+
+func expandNotificationDestinationUpdate(d *schema.ResourceData) notificationDestinationUpdate {
+	updateInput := notificationDestinationUpdate{
+		Name:       d.Get("name").(string),
+		Active:     d.Get("active").(bool),
+		Properties: expandNotificationProperties(d.Get("property").(*schema.Set).List()),
+		Auth:       expandNotificationAuth(d),
+	}
+
+	// Credentials are only cleared when explicitly asked to.
+	if updateInput.Auth == nil && (d.HasChange("auth_basic") || d.HasChange("auth_token")) {
+		updateInput.DisableAuth = true
+	}
+
+	return updateInput
+}
+
+func expandNotificationAuth(d *schema.ResourceData) *notificationAuthInput {
+	if v, ok := d.GetOk("auth_basic"); ok {
+		cfg := v.([]interface{})[0].(map[string]interface{})
+
+		return &notificationAuthInput{
+			Type: "BASIC",
+			Basic: &notificationBasicAuthInput{
+				User:     cfg["user"].(string),
+				Password: cfg["password"].(string),
+			},
+		}
+	}
+
+	if v, ok := d.GetOk("auth_token"); ok {
+		cfg := v.([]interface{})[0].(map[string]interface{})
+
+		return &notificationAuthInput{
+			Type: "TOKEN",
+			Token: &notificationTokenAuthInput{
+				Prefix: cfg["prefix"].(string),
+				Token:  cfg["token"].(string),
+			},
+		}
+	}
+
+	return nil
+}
+
+func expandNotificationProperties(cfg []interface{}) []notificationProperty {
+	properties := make([]notificationProperty, len(cfg))
+
+	for i, rawCfg := range cfg {
+		p := rawCfg.(map[string]interface{})
+
+		properties[i] = notificationProperty{
+			Key:          p["key"].(string),
+			Value:        p["value"].(string),
+			Label:        p["label"].(string),
+			DisplayValue: p["display_value"].(string),
+		}
+	}
+
+	return properties
+}
+
+func flattenNotificationDestination(destination *notificationDestination, d *schema.ResourceData) error {
+	d.Set("name", destination.Name)
+	d.Set("type", destination.Type)
+	d.Set("active", destination.Active)
+	d.Set("status", destination.Status)
+
+	if err := d.Set("property", flattenNotificationProperties(destination.Properties)); err != nil {
+		return err
+	}
+
+	// Secrets are never returned by the API, so they are carried over from
+	// the current state.
+	var authBasic, authToken []interface{}
+
+	if destination.Auth != nil {
+		switch destination.Auth.AuthType {
+		case "BASIC":
+			authBasic = []interface{}{map[string]interface{}{
+				"user":     destination.Auth.User,
+				"password": d.Get("auth_basic.0.password").(string),
+			}}
+		case "TOKEN":
+			authToken = []interface{}{map[string]interface{}{
+				"prefix": destination.Auth.Prefix,
+				"token":  d.Get("auth_token.0.token").(string),
+			}}
+		}
+	}
+
+	if err := d.Set("auth_basic", authBasic); err != nil {
+		return err
+	}
+
+	return d.Set("auth_token", authToken)
+}
+
+func flattenNotificationProperties(properties []notificationProperty) []interface{} {
+	out := make([]interface{}, len(properties))
+
+	for i, p := range properties {
+		out[i] = map[string]interface{}{
+			"key":           p.Key,
+			"value":         p.Value,
+			"label":         p.Label,
+			"display_value": p.DisplayValue,
+		}
+	}
+
+	return out
+}
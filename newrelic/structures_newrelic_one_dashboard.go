@@ -1,26 +1,44 @@
 package newrelic
 
 import (
+	"encoding/json"
 	"fmt"
+	"strconv"
 	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/structure"
 
 	"github.com/newrelic/newrelic-client-go/pkg/dashboards"
 	"github.com/newrelic/newrelic-client-go/pkg/entities"
 	"github.com/newrelic/newrelic-client-go/pkg/nrdb"
 )
 
-// Assemble the *dashboards.DashboardInput struct.
+// dashboardRawWidgetVisualizations lists the widget types that are configured
+// through rawConfiguration, along with their visualization ID.
+var dashboardRawWidgetVisualizations = []struct {
+	widgetType      string
+	visualizationID string
+}{
+	{"widget_bullet", "viz.bullet"},
+	{"widget_funnel", "viz.funnel"},
+	{"widget_heatmap", "viz.heatmap"},
+	{"widget_histogram", "viz.histogram"},
+	{"widget_json", "viz.json"},
+	{"widget_log_table", "logger.log-table-widget"},
+	{"widget_stacked_bar", "viz.stacked-bar"},
+}
+
+// Assemble the *dashboardInput struct. Queries that set no account default to
+// the account of their page, or to accountID.
 // Used by the newrelic_one_dashboard Create function.
-func expandDashboardInput(d *schema.ResourceData) (*dashboards.DashboardInput, error) {
+func expandDashboardInput(d *schema.ResourceData, accountID int) (*dashboardInput, error) {
 	var err error
 
-	dash := dashboards.DashboardInput{
-		Name: d.Get("name").(string),
-	}
+	dash := dashboardInput{}
+	dash.Name = d.Get("name").(string)
 
-	dash.Pages, err = expandDashboardPageInput(d.Get("page").([]interface{}))
+	dash.Pages, err = expandDashboardPageInput(d.Get("page").([]interface{}), accountID)
 	if err != nil {
 		return nil, err
 	}
@@ -34,12 +52,159 @@ func expandDashboardInput(d *schema.ResourceData) (*dashboards.DashboardInput, e
 		dash.Description = e.(string)
 	}
 
+	dash.Variables = expandDashboardVariablesInput(d.Get("variable").([]interface{}))
+
 	return &dash, nil
 }
 
+func expandDashboardVariablesInput(variables []interface{}) []dashboardVariable {
+	expanded := make([]dashboardVariable, len(variables))
+
+	for i, v := range variables {
+		cfg := v.(map[string]interface{})
+
+		variable := dashboardVariable{
+			Name:                cfg["name"].(string),
+			Title:               cfg["title"].(string),
+			Type:                strings.ToUpper(cfg["type"].(string)),
+			IsMultiSelection:    cfg["is_multi_selection"].(bool),
+			ReplacementStrategy: strings.ToUpper(cfg["replacement_strategy"].(string)),
+		}
+
+		for _, value := range cfg["default_values"].([]interface{}) {
+			variable.DefaultValues = append(variable.DefaultValues, dashboardVariableDefaultItem{
+				Value: dashboardVariableDefaultValue{String: value.(string)},
+			})
+		}
+
+		for _, item := range cfg["item"].([]interface{}) {
+			itemCfg := item.(map[string]interface{})
+
+			variable.Items = append(variable.Items, dashboardVariableEnumItem{
+				Title: itemCfg["title"].(string),
+				Value: itemCfg["value"].(string),
+			})
+		}
+
+		if q := cfg["nrql_query"].([]interface{}); len(q) > 0 && q[0] != nil {
+			queryCfg := q[0].(map[string]interface{})
+			accountIDs := queryCfg["account_ids"].([]interface{})
+
+			variable.NRQLQuery = &dashboardVariableNRQLQuery{
+				AccountIDs: make([]int, len(accountIDs)),
+				Query:      queryCfg["query"].(string),
+			}
+
+			for j, id := range accountIDs {
+				variable.NRQLQuery.AccountIDs[j] = id.(int)
+			}
+		}
+
+		expanded[i] = variable
+	}
+
+	return expanded
+}
+
+// defaultDashboardPageNRQLQueryAccounts sets the account of the widget queries
+// on a page that set neither account_id nor account_ids, using the account_id
+// of the page when there is one.
+func defaultDashboardPageNRQLQueryAccounts(p map[string]interface{}, accountID int) {
+	if pageAccountID, ok := p["account_id"].(int); ok && pageAccountID != 0 {
+		accountID = pageAccountID
+	}
+
+	for k, widgets := range p {
+		if !strings.HasPrefix(k, "widget_") {
+			continue
+		}
+
+		for _, w := range widgets.([]interface{}) {
+			queries, ok := w.(map[string]interface{})["nrql_query"].([]interface{})
+			if !ok {
+				continue
+			}
+
+			for _, q := range queries {
+				query := q.(map[string]interface{})
+				accountIDs, _ := query["account_ids"].([]interface{})
+
+				if query["account_id"] == 0 && len(accountIDs) == 0 {
+					query["account_id"] = accountID
+				}
+			}
+		}
+	}
+}
+
+// dashboardWidgetPageLink returns the GUID of the page that a widget on page
+// pageIndex filters, which is its own page with filter_current_dashboard or
+// the page named by linked_page. The GUID is empty when the page has not been
+// created yet.
+func dashboardWidgetPageLink(pages []interface{}, pageIndex int, w map[string]interface{}) (string, bool) {
+	if filter, ok := w["filter_current_dashboard"].(bool); ok && filter {
+		guid, _ := pages[pageIndex].(map[string]interface{})["guid"].(string)
+		return guid, true
+	}
+
+	name, _ := w["linked_page"].(string)
+	if name == "" {
+		return "", false
+	}
+
+	for _, p := range pages {
+		page := p.(map[string]interface{})
+
+		if page["name"] == name {
+			guid, _ := page["guid"].(string)
+			return guid, true
+		}
+	}
+
+	return "", true
+}
+
+// expandDashboardPageLinks sets the linked entity of the widgets on a page
+// that filter a page of the dashboard.
+func expandDashboardPageLinks(pages []interface{}, pageIndex int) {
+	for k, widgets := range pages[pageIndex].(map[string]interface{}) {
+		if !strings.HasPrefix(k, "widget_") {
+			continue
+		}
+
+		for _, v := range widgets.([]interface{}) {
+			w := v.(map[string]interface{})
+
+			if guid, ok := dashboardWidgetPageLink(pages, pageIndex, w); ok && guid != "" {
+				w["linked_entity_guids"] = []interface{}{guid}
+			}
+		}
+	}
+}
+
+// dashboardPageLinksPending reports whether any widget filters a page that has
+// not been created yet.
+func dashboardPageLinksPending(pages []interface{}) bool {
+	for i, p := range pages {
+		for k, widgets := range p.(map[string]interface{}) {
+			if !strings.HasPrefix(k, "widget_") {
+				continue
+			}
+
+			for _, w := range widgets.([]interface{}) {
+				if guid, ok := dashboardWidgetPageLink(pages, i, w.(map[string]interface{})); ok && guid == "" {
+					return true
+				}
+			}
+		}
+	}
+
+	return false
+}
+
 // TODO: Reduce the cyclomatic complexity of this func
 // nolint:gocyclo
-func expandDashboardPageInput(pages []interface{}) ([]dashboards.DashboardPageInput, error) {
+func expandDashboardPageInput(pages []interface{}, accountID int) ([]dashboards.DashboardPageInput, error) {
 	if len(pages) < 1 {
 		return []dashboards.DashboardPageInput{}, nil
 	}
@@ -65,6 +230,9 @@ func expandDashboardPageInput(pages []interface{}) ([]dashboards.DashboardPageIn
 			page.GUID = entities.EntityGUID(guid.(string))
 		}
 
+		defaultDashboardPageNRQLQueryAccounts(p, accountID)
+		expandDashboardPageLinks(pages, i)
+
 		// For each of the widget type, we need to expand them as well
 		if widgets, ok := p["widget_area"]; ok {
 			for _, v := range widgets.([]interface{}) {
@@ -79,6 +247,10 @@ func expandDashboardPageInput(pages []interface{}) ([]dashboards.DashboardPageIn
 					return nil, err
 				}
 
+				if err = expandDashboardWidgetRawConfigurationInput(&widget, v.(map[string]interface{}), "viz.area"); err != nil {
+					return nil, err
+				}
+
 				page.Widgets = append(page.Widgets, widget)
 			}
 		}
@@ -95,6 +267,10 @@ func expandDashboardPageInput(pages []interface{}) ([]dashboards.DashboardPageIn
 					return nil, err
 				}
 
+				if err = expandDashboardWidgetRawConfigurationInput(&widget, v.(map[string]interface{}), "viz.bar"); err != nil {
+					return nil, err
+				}
+
 				page.Widgets = append(page.Widgets, widget)
 			}
 		}
@@ -111,6 +287,10 @@ func expandDashboardPageInput(pages []interface{}) ([]dashboards.DashboardPageIn
 					return nil, err
 				}
 
+				if err = expandDashboardWidgetRawConfigurationInput(&widget, v.(map[string]interface{}), "viz.billboard"); err != nil {
+					return nil, err
+				}
+
 				page.Widgets = append(page.Widgets, widget)
 			}
 		}
@@ -127,6 +307,10 @@ func expandDashboardPageInput(pages []interface{}) ([]dashboards.DashboardPageIn
 					return nil, err
 				}
 
+				if err = expandDashboardWidgetRawConfigurationInput(&widget, v.(map[string]interface{}), "viz.line"); err != nil {
+					return nil, err
+				}
+
 				page.Widgets = append(page.Widgets, widget)
 			}
 		}
@@ -159,6 +343,10 @@ func expandDashboardPageInput(pages []interface{}) ([]dashboards.DashboardPageIn
 					return nil, err
 				}
 
+				if err = expandDashboardWidgetRawConfigurationInput(&widget, v.(map[string]interface{}), "viz.pie"); err != nil {
+					return nil, err
+				}
+
 				page.Widgets = append(page.Widgets, widget)
 			}
 		}
@@ -175,6 +363,32 @@ func expandDashboardPageInput(pages []interface{}) ([]dashboards.DashboardPageIn
 					return nil, err
 				}
 
+				if err = expandDashboardWidgetRawConfigurationInput(&widget, v.(map[string]interface{}), "viz.table"); err != nil {
+					return nil, err
+				}
+
+				page.Widgets = append(page.Widgets, widget)
+			}
+		}
+		for _, raw := range dashboardRawWidgetVisualizations {
+			if widgets, ok := p[raw.widgetType]; ok {
+				for _, v := range widgets.([]interface{}) {
+					widget, err := expandDashboardRawWidgetInput(v.(map[string]interface{}), raw.visualizationID)
+					if err != nil {
+						return nil, err
+					}
+
+					page.Widgets = append(page.Widgets, widget)
+				}
+			}
+		}
+		if widgets, ok := p["widget_viz"]; ok {
+			for _, v := range widgets.([]interface{}) {
+				widget, err := expandDashboardVizWidgetInput(v.(map[string]interface{}))
+				if err != nil {
+					return nil, err
+				}
+
 				page.Widgets = append(page.Widgets, widget)
 			}
 		}
@@ -299,6 +513,152 @@ func expandDashboardTableWidgetConfigurationInput(i map[string]interface{}) (*da
 	return nil, nil
 }
 
+// expandDashboardRawWidgetInput expands a widget whose NRQL queries, and limit
+// when there is one, are sent as rawConfiguration.
+func expandDashboardRawWidgetInput(w map[string]interface{}, visualizationID string) (dashboards.DashboardWidgetInput, error) {
+	var cfg dashboardWidgetRawConfiguration
+
+	widget, err := expandDashboardWidgetInput(w)
+	if err != nil {
+		return widget, err
+	}
+
+	if q, ok := w["nrql_query"]; ok {
+		cfg.NRQLQueries = expandDashboardRawWidgetNRQLQueryInput(q.([]interface{}))
+	}
+
+	if l, ok := w["limit"]; ok {
+		cfg.Limit = l.(float64)
+	}
+
+	expandDashboardWidgetRawConfigurationOptions(w, &cfg)
+
+	widget.Visualization.ID = visualizationID
+	widget.RawConfiguration, err = json.Marshal(cfg)
+
+	return widget, err
+}
+
+// expandDashboardWidgetRawConfigurationInput moves the typed configuration of
+// a widget to rawConfiguration when the widget sets options or queries that
+// only rawConfiguration supports.
+func expandDashboardWidgetRawConfigurationInput(widget *dashboards.DashboardWidgetInput, w map[string]interface{}, visualizationID string) error {
+	var cfg dashboardWidgetRawConfiguration
+	var err error
+
+	if q, ok := w["nrql_query"]; ok {
+		cfg.NRQLQueries = expandDashboardRawWidgetNRQLQueryInput(q.([]interface{}))
+	}
+
+	if !expandDashboardWidgetRawConfigurationOptions(w, &cfg) && !dashboardRawWidgetNRQLQueriesMultiAccount(cfg.NRQLQueries) {
+		return nil
+	}
+
+	if widget.Configuration.Billboard != nil {
+		cfg.Thresholds = widget.Configuration.Billboard.Thresholds
+	}
+
+	widget.Configuration = dashboards.DashboardWidgetConfigurationInput{}
+	widget.Visualization.ID = visualizationID
+	widget.RawConfiguration, err = json.Marshal(cfg)
+
+	return err
+}
+
+// expandDashboardWidgetRawConfigurationOptions sets the display options of a
+// widget on cfg, and reports whether any differ from the defaults.
+func expandDashboardWidgetRawConfigurationOptions(w map[string]interface{}, cfg *dashboardWidgetRawConfiguration) bool {
+	if enabled, ok := w["legend_enabled"]; ok && !enabled.(bool) {
+		cfg.Legend = &dashboardWidgetLegend{Enabled: false}
+	}
+
+	min := expandDashboardWidgetYAxisBound(w["y_axis_left_min"])
+	max := expandDashboardWidgetYAxisBound(w["y_axis_left_max"])
+	if min != nil || max != nil {
+		cfg.YAxisLeft = &dashboardWidgetYAxis{Min: min, Max: max}
+	}
+
+	if v, ok := w["null_values"].([]interface{}); ok && len(v) > 0 && v[0] != nil {
+		nullValues := v[0].(map[string]interface{})
+		cfg.NullValues = &dashboardWidgetNullValues{
+			NullValue: strings.ToUpper(nullValues["null_value"].(string)),
+		}
+
+		for _, o := range nullValues["series_overrides"].([]interface{}) {
+			override := o.(map[string]interface{})
+			cfg.NullValues.SeriesOverrides = append(cfg.NullValues.SeriesOverrides, dashboardWidgetNullValueOverride{
+				NullValue:  strings.ToUpper(override["null_value"].(string)),
+				SeriesName: override["series_name"].(string),
+			})
+		}
+	}
+
+	if v, ok := w["units"].([]interface{}); ok && len(v) > 0 && v[0] != nil {
+		units := v[0].(map[string]interface{})
+		cfg.Units = &dashboardWidgetUnits{
+			Unit: units["unit"].(string),
+		}
+
+		for _, o := range units["series_overrides"].([]interface{}) {
+			override := o.(map[string]interface{})
+			cfg.Units.SeriesOverrides = append(cfg.Units.SeriesOverrides, dashboardWidgetUnitOverride{
+				Unit:       override["unit"].(string),
+				SeriesName: override["series_name"].(string),
+			})
+		}
+	}
+
+	if v, ok := w["colors"].([]interface{}); ok && len(v) > 0 && v[0] != nil {
+		colors := v[0].(map[string]interface{})
+		cfg.Colors = &dashboardWidgetColors{
+			Color: colors["color"].(string),
+		}
+
+		for _, o := range colors["series_overrides"].([]interface{}) {
+			override := o.(map[string]interface{})
+			cfg.Colors.SeriesOverrides = append(cfg.Colors.SeriesOverrides, dashboardWidgetColorOverride{
+				Color:      override["color"].(string),
+				SeriesName: override["series_name"].(string),
+			})
+		}
+	}
+
+	return cfg.Legend != nil || cfg.YAxisLeft != nil || cfg.NullValues != nil || cfg.Units != nil || cfg.Colors != nil
+}
+
+// expandDashboardWidgetYAxisBound returns the bound of a Y axis, or nil when
+// it is not set.
+func expandDashboardWidgetYAxisBound(v interface{}) *float64 {
+	s, _ := v.(string)
+	if s == "" {
+		return nil
+	}
+
+	bound, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return nil
+	}
+
+	return &bound
+}
+
+func expandDashboardVizWidgetInput(w map[string]interface{}) (dashboards.DashboardWidgetInput, error) {
+	widget, err := expandDashboardWidgetInput(w)
+	if err != nil {
+		return widget, err
+	}
+
+	widget.Visualization.ID = w["visualization_id"].(string)
+
+	cfg, err := structure.NormalizeJsonString(w["configuration"])
+	if err != nil {
+		return widget, fmt.Errorf("invalid configuration for widget %q: %s", widget.Title, err)
+	}
+	widget.RawConfiguration = entities.DashboardWidgetRawConfiguration(cfg)
+
+	return widget, nil
+}
+
 // expandDashboardWidgetInput expands the common items in WidgetInput, but not the configuration
 // which is specific to the widgets
 func expandDashboardWidgetInput(w map[string]interface{}) (dashboards.DashboardWidgetInput, error) {
@@ -365,10 +725,45 @@ func expandDashboardWidgetNRQLQueryInput(queries []interface{}) ([]dashboards.Da
 	return expanded, nil
 }
 
+// expandDashboardRawWidgetNRQLQueryInput expands the queries of a widget for
+// its rawConfiguration, where a query can set several accounts.
+func expandDashboardRawWidgetNRQLQueryInput(queries []interface{}) []dashboardWidgetNRQLQuery {
+	expanded := make([]dashboardWidgetNRQLQuery, len(queries))
+
+	for i, v := range queries {
+		q := v.(map[string]interface{})
+
+		expanded[i].Query = q["query"].(string)
+
+		if accountIDs, ok := q["account_ids"].([]interface{}); ok && len(accountIDs) > 0 {
+			expanded[i].AccountIDs = make([]int, len(accountIDs))
+			for j, id := range accountIDs {
+				expanded[i].AccountIDs[j] = id.(int)
+			}
+		} else if acct, ok := q["account_id"]; ok {
+			expanded[i].AccountID = acct.(int)
+		}
+	}
+
+	return expanded
+}
+
+// dashboardRawWidgetNRQLQueriesMultiAccount reports whether any of the queries
+// spans several accounts.
+func dashboardRawWidgetNRQLQueriesMultiAccount(queries []dashboardWidgetNRQLQuery) bool {
+	for _, q := range queries {
+		if len(q.AccountIDs) > 0 {
+			return true
+		}
+	}
+
+	return false
+}
+
 // Unpack the *dashboards.Dashboard variable and set resource data.
 //
 // Used by the newrelic_dashboard Read function (resourceNewRelicDashboardRead)
-func flattenDashboardEntity(dashboard *entities.DashboardEntity, d *schema.ResourceData) error {
+func flattenDashboardEntity(dashboard *dashboardEntity, d *schema.ResourceData) error {
 	d.Set("account_id", dashboard.AccountID)
 	d.Set("guid", dashboard.GUID)
 	d.Set("name", dashboard.Name)
@@ -380,13 +775,58 @@ func flattenDashboardEntity(dashboard *entities.DashboardEntity, d *schema.Resou
 	}
 
 	if dashboard.Pages != nil && len(dashboard.Pages) > 0 {
-		pages := flattenDashboardPage(&dashboard.Pages)
+		pages, err := flattenDashboardPage(&dashboard.Pages, d)
+		if err != nil {
+			return err
+		}
+
+		flattenDashboardPageAccountIDs(pages, d)
 		if err := d.Set("page", pages); err != nil {
 			return err
 		}
 	}
 
-	return nil
+	return d.Set("variable", flattenDashboardVariables(dashboard.Variables))
+}
+
+func flattenDashboardVariables(in []dashboardVariable) []interface{} {
+	out := make([]interface{}, len(in))
+
+	for i, v := range in {
+		m := map[string]interface{}{
+			"name":                 v.Name,
+			"title":                v.Title,
+			"type":                 strings.ToLower(v.Type),
+			"is_multi_selection":   v.IsMultiSelection,
+			"replacement_strategy": strings.ToLower(v.ReplacementStrategy),
+		}
+
+		defaultValues := make([]interface{}, len(v.DefaultValues))
+		for j, value := range v.DefaultValues {
+			defaultValues[j] = value.Value.String
+		}
+		m["default_values"] = defaultValues
+
+		items := make([]interface{}, len(v.Items))
+		for j, item := range v.Items {
+			items[j] = map[string]interface{}{
+				"title": item.Title,
+				"value": item.Value,
+			}
+		}
+		m["item"] = items
+
+		if v.NRQLQuery != nil {
+			m["nrql_query"] = []interface{}{map[string]interface{}{
+				"account_ids": v.NRQLQuery.AccountIDs,
+				"query":       v.NRQLQuery.Query,
+			}}
+		}
+
+		out[i] = m
+	}
+
+	return out
 }
 
 // Unpack the *dashboards.Dashboard variable and set resource data.
@@ -410,7 +850,12 @@ func flattenDashboardUpdateResult(result *dashboards.DashboardUpdateResult, d *s
 	}
 
 	if dashboard.Pages != nil && len(dashboard.Pages) > 0 {
-		pages := flattenDashboardPage(&dashboard.Pages)
+		pages, err := flattenDashboardPage(&dashboard.Pages, d)
+		if err != nil {
+			return err
+		}
+
+		flattenDashboardPageAccountIDs(pages, d)
 		if err := d.Set("page", pages); err != nil {
 			return err
 		}
@@ -419,9 +864,20 @@ func flattenDashboardUpdateResult(result *dashboards.DashboardUpdateResult, d *s
 	return nil
 }
 
+// flattenDashboardPageAccountIDs keeps the account_id of each page, which only
+// exists in the configuration as the default account of its queries.
+func flattenDashboardPageAccountIDs(pages []interface{}, d *schema.ResourceData) {
+	for i, p := range pages {
+		if accountID, ok := d.GetOk(fmt.Sprintf("page.%d.account_id", i)); ok {
+			p.(map[string]interface{})["account_id"] = accountID
+		}
+	}
+}
+
 // return []interface{} because Page is a SetList
-func flattenDashboardPage(in *[]entities.DashboardPage) []interface{} {
+func flattenDashboardPage(in *[]entities.DashboardPage, d *schema.ResourceData) ([]interface{}, error) {
 	out := make([]interface{}, len(*in))
+	pageSchema := dashboardPageSchemaElem().Schema
 
 	for i, p := range *in {
 		m := make(map[string]interface{})
@@ -440,10 +896,17 @@ func flattenDashboardPage(in *[]entities.DashboardPage) []interface{} {
 		m["widget_markdown"] = []interface{}{}
 		m["widget_pie"] = []interface{}{}
 		m["widget_table"] = []interface{}{}
+		m["widget_viz"] = []interface{}{}
+		for _, raw := range dashboardRawWidgetVisualizations {
+			m[raw.widgetType] = []interface{}{}
+		}
 
 		for _, widget := range p.Widgets {
 			var widgetType string
-			w := flattenDashboardWidget(&widget)
+			w, err := flattenDashboardWidget(&widget)
+			if err != nil {
+				return nil, err
+			}
 
 			switch widget.Visualization.ID {
 			case "viz.area":
@@ -460,9 +923,23 @@ func flattenDashboardPage(in *[]entities.DashboardPage) []interface{} {
 				widgetType = "widget_pie"
 			case "viz.table":
 				widgetType = "widget_table"
+			default:
+				widgetType = dashboardRawWidgetType(widget.Visualization.ID)
 			}
 
 			if widgetType != "" {
+				if dashboardWidgetUsesPageLink(d, i, widgetType, len(m[widgetType].([]interface{}))) {
+					flattenDashboardWidgetPageLink(*in, i, w)
+				}
+
+				// Only keep the attributes the widget type supports
+				widgetSchema := pageSchema[widgetType].Elem.(*schema.Resource).Schema
+				for k := range w {
+					if _, ok := widgetSchema[k]; !ok {
+						delete(w, k)
+					}
+				}
+
 				m[widgetType] = append(m[widgetType].([]interface{}), w)
 			}
 		}
@@ -470,7 +947,59 @@ func flattenDashboardPage(in *[]entities.DashboardPage) []interface{} {
 		out[i] = m
 	}
 
-	return out
+	return out, nil
+}
+
+// dashboardWidgetUsesPageLink reports whether a widget is configured to filter
+// a page of the dashboard with filter_current_dashboard or linked_page.
+func dashboardWidgetUsesPageLink(d *schema.ResourceData, pageIndex int, widgetType string, widgetIndex int) bool {
+	prefix := fmt.Sprintf("page.%d.%s.%d.", pageIndex, widgetType, widgetIndex)
+
+	filter, _ := d.Get(prefix + "filter_current_dashboard").(bool)
+	linkedPage, _ := d.Get(prefix + "linked_page").(string)
+
+	return filter || linkedPage != ""
+}
+
+// flattenDashboardWidgetPageLink replaces the linked entity of a widget that
+// filters a page of the dashboard with filter_current_dashboard, or with the
+// linked_page it filters.
+func flattenDashboardWidgetPageLink(pages []entities.DashboardPage, pageIndex int, w map[string]interface{}) {
+	guids, ok := w["linked_entity_guids"].([]string)
+	if !ok || len(guids) != 1 {
+		return
+	}
+
+	for i, p := range pages {
+		if string(p.GUID) != guids[0] {
+			continue
+		}
+
+		if i == pageIndex {
+			w["filter_current_dashboard"] = true
+		} else {
+			w["linked_page"] = p.Name
+		}
+		delete(w, "linked_entity_guids")
+
+		return
+	}
+}
+
+// dashboardRawWidgetType returns the widget type for a visualization that has
+// no typed configuration, falling back to widget_viz for unknown ones.
+func dashboardRawWidgetType(visualizationID string) string {
+	if visualizationID == "" {
+		return ""
+	}
+
+	for _, raw := range dashboardRawWidgetVisualizations {
+		if raw.visualizationID == visualizationID {
+			return raw.widgetType
+		}
+	}
+
+	return "widget_viz"
 }
 
 func flattenLinkedEntityGUIDs(linkedEntities []entities.EntityOutlineInterface) []string {
@@ -484,7 +1013,7 @@ func flattenLinkedEntityGUIDs(linkedEntities []entities.EntityOutlineInterface)
 }
 
 // nolint:gocyclo
-func flattenDashboardWidget(in *entities.DashboardWidget) map[string]interface{} {
+func flattenDashboardWidget(in *entities.DashboardWidget) (map[string]interface{}, error) {
 	out := make(map[string]interface{})
 
 	out["id"] = in.ID
@@ -541,9 +1070,137 @@ func flattenDashboardWidget(in *entities.DashboardWidget) map[string]interface{}
 		if len(in.Configuration.Table.NRQLQueries) > 0 {
 			out["nrql_query"] = flattenDashboardWidgetNRQLQuery(&in.Configuration.Table.NRQLQueries)
 		}
+	default:
+		if err := flattenDashboardRawWidgetConfiguration(in, out); err != nil {
+			return nil, err
+		}
 	}
 
-	return out
+	if _, ok := out["visualization_id"]; !ok {
+		if err := flattenDashboardWidgetRawConfigurationOptions(in.RawConfiguration, out); err != nil {
+			return nil, err
+		}
+	}
+
+	return out, nil
+}
+
+// flattenDashboardWidgetRawConfigurationOptions sets the display options of a
+// widget, along with the queries and thresholds of widgets that were created
+// with rawConfiguration rather than a typed configuration.
+func flattenDashboardWidgetRawConfigurationOptions(raw entities.DashboardWidgetRawConfiguration, out map[string]interface{}) error {
+	var cfg dashboardWidgetRawConfiguration
+
+	if len(raw) > 0 {
+		if err := json.Unmarshal(raw, &cfg); err != nil {
+			return fmt.Errorf("invalid raw configuration: %s", err)
+		}
+	}
+
+	if _, ok := out["nrql_query"]; (!ok && len(cfg.NRQLQueries) > 0) || dashboardRawWidgetNRQLQueriesMultiAccount(cfg.NRQLQueries) {
+		out["nrql_query"] = flattenDashboardRawWidgetNRQLQuery(cfg.NRQLQueries)
+	}
+
+	for _, t := range cfg.Thresholds {
+		switch t.AlertSeverity {
+		case entities.DashboardAlertSeverityTypes.CRITICAL:
+			if _, ok := out["critical"]; !ok {
+				out["critical"] = t.Value
+			}
+		case entities.DashboardAlertSeverityTypes.WARNING:
+			if _, ok := out["warning"]; !ok {
+				out["warning"] = t.Value
+			}
+		}
+	}
+
+	out["legend_enabled"] = cfg.Legend == nil || cfg.Legend.Enabled
+
+	if cfg.YAxisLeft != nil && cfg.YAxisLeft.Min != nil {
+		out["y_axis_left_min"] = strconv.FormatFloat(*cfg.YAxisLeft.Min, 'f', -1, 64)
+	}
+
+	if cfg.YAxisLeft != nil && cfg.YAxisLeft.Max != nil {
+		out["y_axis_left_max"] = strconv.FormatFloat(*cfg.YAxisLeft.Max, 'f', -1, 64)
+	}
+
+	if cfg.NullValues != nil {
+		overrides := make([]interface{}, len(cfg.NullValues.SeriesOverrides))
+		for i, o := range cfg.NullValues.SeriesOverrides {
+			overrides[i] = map[string]interface{}{
+				"null_value":  strings.ToLower(o.NullValue),
+				"series_name": o.SeriesName,
+			}
+		}
+
+		out["null_values"] = []interface{}{map[string]interface{}{
+			"null_value":       strings.ToLower(cfg.NullValues.NullValue),
+			"series_overrides": overrides,
+		}}
+	}
+
+	if cfg.Units != nil {
+		overrides := make([]interface{}, len(cfg.Units.SeriesOverrides))
+		for i, o := range cfg.Units.SeriesOverrides {
+			overrides[i] = map[string]interface{}{
+				"unit":        o.Unit,
+				"series_name": o.SeriesName,
+			}
+		}
+
+		out["units"] = []interface{}{map[string]interface{}{
+			"unit":             cfg.Units.Unit,
+			"series_overrides": overrides,
+		}}
+	}
+
+	if cfg.Colors != nil {
+		overrides := make([]interface{}, len(cfg.Colors.SeriesOverrides))
+		for i, o := range cfg.Colors.SeriesOverrides {
+			overrides[i] = map[string]interface{}{
+				"color":       o.Color,
+				"series_name": o.SeriesName,
+			}
+		}
+
+		out["colors"] = []interface{}{map[string]interface{}{
+			"color":            cfg.Colors.Color,
+			"series_overrides": overrides,
+		}}
+	}
+
+	return nil
+}
+
+func flattenDashboardRawWidgetConfiguration(in *entities.DashboardWidget, out map[string]interface{}) error {
+	switch widgetType := dashboardRawWidgetType(in.Visualization.ID); widgetType {
+	case "":
+		return nil
+	case "widget_viz":
+		out["visualization_id"] = in.Visualization.ID
+
+		cfg, err := structure.NormalizeJsonString(string(in.RawConfiguration))
+		if err != nil {
+			return fmt.Errorf("invalid raw configuration for widget %s: %s", in.ID, err)
+		}
+
+		out["configuration"] = cfg
+	default:
+		var cfg dashboardWidgetRawConfiguration
+		if err := json.Unmarshal(in.RawConfiguration, &cfg); err != nil {
+			return fmt.Errorf("invalid raw configuration for widget %s: %s", in.ID, err)
+		}
+
+		if len(cfg.NRQLQueries) > 0 {
+			out["nrql_query"] = flattenDashboardRawWidgetNRQLQuery(cfg.NRQLQueries)
+		}
+
+		if widgetType == "widget_bullet" {
+			out["limit"] = cfg.Limit
+		}
+	}
+
+	return nil
 }
 
 func flattenDashboardWidgetNRQLQuery(in *[]entities.DashboardWidgetNRQLQuery) []interface{} {
@@ -560,3 +1217,192 @@ func flattenDashboardWidgetNRQLQuery(in *[]entities.DashboardWidgetNRQLQuery) []
 
 	return out
 }
+
+func flattenDashboardRawWidgetNRQLQuery(in []dashboardWidgetNRQLQuery) []interface{} {
+	out := make([]interface{}, len(in))
+
+	for i, v := range in {
+		m := make(map[string]interface{})
+
+		if len(v.AccountIDs) > 0 {
+			m["account_ids"] = v.AccountIDs
+		} else {
+			m["account_id"] = v.AccountID
+		}
+		m["query"] = v.Query
+
+		out[i] = m
+	}
+
+	return out
+}
+
+// legacyDashboardWidgetVisualizations maps the visualizations of the legacy
+// dashboard API to their New Relic One equivalent.
+var legacyDashboardWidgetVisualizations = map[dashboards.VisualizationType]string{
+	dashboards.VisualizationTypes.AttributeSheet:      "viz.table",
+	dashboards.VisualizationTypes.Billboard:           "viz.billboard",
+	dashboards.VisualizationTypes.BillboardComparison: "viz.billboard",
+	dashboards.VisualizationTypes.ComparisonLineChart: "viz.line",
+	dashboards.VisualizationTypes.EventFeed:           "viz.event-feed",
+	dashboards.VisualizationTypes.EventTable:          "viz.table",
+	dashboards.VisualizationTypes.FacetBarChart:       "viz.bar",
+	dashboards.VisualizationTypes.FacetPieChart:       "viz.pie",
+	dashboards.VisualizationTypes.FacetTable:          "viz.table",
+	dashboards.VisualizationTypes.FacetedAreaChart:    "viz.area",
+	dashboards.VisualizationTypes.FacetedLineChart:    "viz.line",
+	dashboards.VisualizationTypes.Funnel:              "viz.funnel",
+	dashboards.VisualizationTypes.Gauge:               "viz.billboard",
+	dashboards.VisualizationTypes.Heatmap:             "viz.heatmap",
+	dashboards.VisualizationTypes.Histogram:           "viz.histogram",
+	dashboards.VisualizationTypes.LineChart:           "viz.line",
+	dashboards.VisualizationTypes.Markdown:            "viz.markdown",
+	dashboards.VisualizationTypes.RawJSON:             "viz.json",
+	dashboards.VisualizationTypes.SingleEvent:         "viz.table",
+	dashboards.VisualizationTypes.UniquesList:         "viz.table",
+}
+
+// convertLegacyDashboard converts a dashboard of the legacy dashboard API into
+// a single page New Relic One dashboard, so it can be read into the state of a
+// newrelic_one_dashboard resource.
+func convertLegacyDashboard(legacy *dashboards.Dashboard, accountID int) (*dashboardEntity, error) {
+	dashboard := entities.DashboardEntity{
+		AccountID:   accountID,
+		Name:        legacy.Title,
+		Permalink:   legacy.UIURL,
+		Permissions: convertLegacyDashboardPermissions(legacy),
+	}
+
+	page := entities.DashboardPage{
+		Name: legacy.Title,
+	}
+
+	var unsupported []string
+
+	for _, w := range legacy.Widgets {
+		widget, err := convertLegacyDashboardWidget(w, legacy.GridColumnCount, accountID)
+		if err != nil {
+			unsupported = append(unsupported, err.Error())
+			continue
+		}
+
+		page.Widgets = append(page.Widgets, *widget)
+	}
+
+	if len(unsupported) > 0 {
+		return nil, fmt.Errorf("legacy dashboard %d cannot be converted: %s", legacy.ID, strings.Join(unsupported, "; "))
+	}
+
+	dashboard.Pages = []entities.DashboardPage{page}
+
+	return &dashboardEntity{DashboardEntity: &dashboard}, nil
+}
+
+func convertLegacyDashboardPermissions(legacy *dashboards.Dashboard) entities.DashboardPermissions {
+	switch {
+	case legacy.Visibility == dashboards.VisibilityTypes.Owner:
+		return entities.DashboardPermissionsTypes.PRIVATE
+	case legacy.Editable == dashboards.EditableTypes.All, legacy.Editable == "all":
+		return entities.DashboardPermissionsTypes.PUBLIC_READ_WRITE
+	default:
+		return entities.DashboardPermissionsTypes.PUBLIC_READ_ONLY
+	}
+}
+
+func convertLegacyDashboardWidget(legacy dashboards.DashboardWidget, gridColumnCount dashboards.GridColumnCountType, accountID int) (*entities.DashboardWidget, error) {
+	visualizationID, ok := legacyDashboardWidgetVisualizations[legacy.Visualization]
+	if !ok || len(legacy.Data) == 0 {
+		return nil, fmt.Errorf("widget %d has unsupported visualization %s", legacy.ID, legacy.Visualization)
+	}
+
+	widget := entities.DashboardWidget{
+		Title:         legacy.Presentation.Title,
+		Layout:        convertLegacyDashboardWidgetLayout(legacy.Layout, gridColumnCount),
+		Visualization: entities.DashboardWidgetVisualization{ID: visualizationID},
+	}
+
+	if visualizationID == "viz.markdown" {
+		widget.Configuration.Markdown.Text = legacy.Data[0].Source
+		return &widget, nil
+	}
+
+	if legacy.Data[0].NRQL == "" {
+		return nil, fmt.Errorf("widget %d has no NRQL query", legacy.ID)
+	}
+
+	if legacy.AccountID != 0 {
+		accountID = legacy.AccountID
+	}
+
+	queries := []entities.DashboardWidgetNRQLQuery{{
+		AccountID: accountID,
+		Query:     nrdb.NRQL(legacy.Data[0].NRQL),
+	}}
+
+	switch visualizationID {
+	case "viz.area":
+		widget.Configuration.Area.NRQLQueries = queries
+	case "viz.bar":
+		widget.Configuration.Bar.NRQLQueries = queries
+	case "viz.billboard":
+		widget.Configuration.Billboard.NRQLQueries = queries
+
+		if t := legacy.Presentation.Threshold; t != nil {
+			if t.Red != 0 {
+				widget.Configuration.Billboard.Thresholds = append(widget.Configuration.Billboard.Thresholds, entities.DashboardBillboardWidgetThreshold{
+					AlertSeverity: entities.DashboardAlertSeverityTypes.CRITICAL,
+					Value:         t.Red,
+				})
+			}
+
+			if t.Yellow != 0 {
+				widget.Configuration.Billboard.Thresholds = append(widget.Configuration.Billboard.Thresholds, entities.DashboardBillboardWidgetThreshold{
+					AlertSeverity: entities.DashboardAlertSeverityTypes.WARNING,
+					Value:         t.Yellow,
+				})
+			}
+		}
+	case "viz.line":
+		widget.Configuration.Line.NRQLQueries = queries
+	case "viz.pie":
+		widget.Configuration.Pie.NRQLQueries = queries
+	case "viz.table":
+		widget.Configuration.Table.NRQLQueries = queries
+	default:
+		cfg := dashboardWidgetRawConfiguration{
+			NRQLQueries: []dashboardWidgetNRQLQuery{{
+				AccountID: queries[0].AccountID,
+				Query:     string(queries[0].Query),
+			}},
+		}
+
+		raw, err := json.Marshal(cfg)
+		if err != nil {
+			return nil, err
+		}
+
+		widget.RawConfiguration = raw
+	}
+
+	return &widget, nil
+}
+
+// convertLegacyDashboardWidgetLayout scales the layout of widgets on the
+// 3 column Insights grid to the 12 column grid of New Relic One.
+func convertLegacyDashboardWidgetLayout(legacy dashboards.DashboardWidgetLayout, gridColumnCount dashboards.GridColumnCountType) entities.DashboardWidgetLayout {
+	if gridColumnCount == dashboards.GridColumnCountTypes.One {
+		return entities.DashboardWidgetLayout{
+			Column: legacy.Column,
+			Height: legacy.Height,
+			Row:    legacy.Row,
+			Width:  legacy.Width,
+		}
+	}
+
+	return entities.DashboardWidgetLayout{
+		Column: (legacy.Column-1)*4 + 1,
+		Height: legacy.Height * 3,
+		Row:    (legacy.Row-1)*3 + 1,
+		Width:  legacy.Width * 4,
+	}
+}
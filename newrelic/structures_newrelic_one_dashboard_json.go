@@ -0,0 +1,372 @@
+package newrelic
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+// expandDashboardJSON decodes the dashboard JSON into the DashboardInput sent
+// to NerdGraph. Identifiers copied from an exported dashboard are dropped, the
+// given page GUIDs are kept so that existing pages are updated in place, and
+// NRQL queries without an account run against accountID.
+//
+// Widgets linked to pages of the exported dashboard are linked to the same
+// pages of this one instead. Links to pages without a GUID yet are left out,
+// which is reported so that they can be added once the pages exist.
+func expandDashboardJSON(raw string, accountID int, pageGUIDs []string) (map[string]interface{}, bool, error) {
+	var dashboard map[string]interface{}
+	if err := json.Unmarshal([]byte(raw), &dashboard); err != nil {
+		return nil, false, fmt.Errorf("invalid dashboard JSON: %s", err)
+	}
+
+	delete(dashboard, "guid")
+	delete(dashboard, "accountId")
+	delete(dashboard, "permalink")
+
+	ownPages := dashboardJSONPageIndexes(dashboard)
+	linksPending := false
+
+	pages, _ := dashboard["pages"].([]interface{})
+	for i, p := range pages {
+		page, ok := p.(map[string]interface{})
+		if !ok {
+			return nil, false, fmt.Errorf("invalid dashboard JSON: page %d is not an object", i)
+		}
+
+		delete(page, "guid")
+		if i < len(pageGUIDs) {
+			page["guid"] = pageGUIDs[i]
+		}
+
+		widgets, _ := page["widgets"].([]interface{})
+		for _, w := range widgets {
+			widget, ok := w.(map[string]interface{})
+			if !ok {
+				continue
+			}
+
+			delete(widget, "id")
+
+			if linked, ok := widget["linkedEntityGuids"].([]interface{}); ok {
+				guids := []interface{}{}
+				for _, g := range linked {
+					guid, _ := g.(string)
+					index, ok := ownPages[guid]
+					switch {
+					case !ok:
+						guids = append(guids, g)
+					case index < len(pageGUIDs):
+						guids = append(guids, pageGUIDs[index])
+					default:
+						linksPending = true
+					}
+				}
+				widget["linkedEntityGuids"] = guids
+			}
+
+			cfg, _ := widget["rawConfiguration"].(map[string]interface{})
+			queries, _ := cfg["nrqlQueries"].([]interface{})
+			for _, q := range queries {
+				if query, ok := q.(map[string]interface{}); ok {
+					if id, _ := query["accountId"].(float64); id == 0 {
+						query["accountId"] = accountID
+					}
+				}
+			}
+		}
+	}
+
+	variables, _ := dashboard["variables"].([]interface{})
+	for _, v := range variables {
+		variable, ok := v.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		if query, ok := variable["nrqlQuery"].(map[string]interface{}); ok {
+			if ids, _ := query["accountIds"].([]interface{}); len(ids) == 0 {
+				query["accountIds"] = []int{accountID}
+			}
+		}
+	}
+
+	return dashboard, linksPending, nil
+}
+
+// dashboardJSONPageIndexes returns the position of each page of the dashboard
+// JSON by its GUID.
+func dashboardJSONPageIndexes(dashboard map[string]interface{}) map[string]int {
+	indexes := map[string]int{}
+
+	pages, _ := dashboard["pages"].([]interface{})
+	for i, p := range pages {
+		page, _ := p.(map[string]interface{})
+		if guid, _ := page["guid"].(string); guid != "" {
+			indexes[guid] = i
+		}
+	}
+
+	return indexes
+}
+
+// flattenDashboardJSON converts a dashboard read from NerdGraph to the JSON
+// the New Relic One UI exports.
+func flattenDashboardJSON(entity map[string]interface{}) (string, error) {
+	dashboard := map[string]interface{}{
+		"name":        entity["name"],
+		"description": entity["description"],
+		"permissions": entity["permissions"],
+		"variables":   entity["variables"],
+	}
+
+	pages := []interface{}{}
+	entityPages, _ := entity["pages"].([]interface{})
+	for _, p := range entityPages {
+		entityPage, _ := p.(map[string]interface{})
+
+		widgets := []interface{}{}
+		entityWidgets, _ := entityPage["widgets"].([]interface{})
+		for _, w := range entityWidgets {
+			entityWidget, _ := w.(map[string]interface{})
+
+			var linkedEntityGUIDs []interface{}
+			linkedEntities, _ := entityWidget["linkedEntities"].([]interface{})
+			for _, e := range linkedEntities {
+				if entity, ok := e.(map[string]interface{}); ok {
+					linkedEntityGUIDs = append(linkedEntityGUIDs, entity["guid"])
+				}
+			}
+
+			widgets = append(widgets, map[string]interface{}{
+				"title":             entityWidget["title"],
+				"layout":            entityWidget["layout"],
+				"visualization":     entityWidget["visualization"],
+				"rawConfiguration":  entityWidget["rawConfiguration"],
+				"linkedEntityGuids": linkedEntityGUIDs,
+			})
+		}
+
+		pages = append(pages, map[string]interface{}{
+			"guid":        entityPage["guid"],
+			"name":        entityPage["name"],
+			"description": entityPage["description"],
+			"widgets":     widgets,
+		})
+	}
+	dashboard["pages"] = pages
+
+	out, err := json.MarshalIndent(dashboard, "", "  ")
+	if err != nil {
+		return "", err
+	}
+
+	return string(out), nil
+}
+
+// flattenDashboardJSONPageGUIDs returns the GUIDs of a dashboard's pages, in
+// page order.
+func flattenDashboardJSONPageGUIDs(entity map[string]interface{}) []string {
+	var guids []string
+
+	pages, _ := entity["pages"].([]interface{})
+	for _, p := range pages {
+		page, _ := p.(map[string]interface{})
+		guid, _ := page["guid"].(string)
+		guids = append(guids, guid)
+	}
+
+	return guids
+}
+
+// normalizeDashboardJSON returns a canonical form of the dashboard JSON: keys
+// are sorted, identifiers and empty values are dropped, and the widgets of each
+// page are ordered by their position on the page.
+func normalizeDashboardJSON(raw string, accountID int) (string, error) {
+	var dashboard interface{}
+	if err := json.Unmarshal([]byte(raw), &dashboard); err != nil {
+		return "", err
+	}
+
+	removeDashboardJSONIdentifiers(dashboard, accountID)
+	dashboard = normalizeDashboardJSONValue(dashboard)
+
+	if d, ok := dashboard.(map[string]interface{}); ok {
+		pages, _ := d["pages"].([]interface{})
+		for _, p := range pages {
+			if page, ok := p.(map[string]interface{}); ok {
+				if widgets, ok := page["widgets"].([]interface{}); ok {
+					sortDashboardJSONWidgets(widgets)
+				}
+			}
+		}
+	}
+
+	out, err := json.Marshal(dashboard)
+	if err != nil {
+		return "", err
+	}
+
+	return string(out), nil
+}
+
+// removeDashboardJSONIdentifiers drops the identifiers that are assigned by New
+// Relic, or differ between the dashboard it was exported from and the one it
+// is managed as. NRQL queries only lose their account when it is accountID,
+// which queries without one run against, and links to pages of the dashboard
+// itself are replaced by the position of the page.
+func removeDashboardJSONIdentifiers(v interface{}, accountID int) {
+	dashboard, ok := v.(map[string]interface{})
+	if !ok {
+		return
+	}
+
+	delete(dashboard, "accountId")
+	delete(dashboard, "guid")
+	delete(dashboard, "id")
+
+	ownPages := dashboardJSONPageIndexes(dashboard)
+
+	pages, _ := dashboard["pages"].([]interface{})
+	for _, p := range pages {
+		page, ok := p.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		delete(page, "guid")
+
+		widgets, _ := page["widgets"].([]interface{})
+		for _, w := range widgets {
+			widget, ok := w.(map[string]interface{})
+			if !ok {
+				continue
+			}
+
+			delete(widget, "id")
+
+			linked, _ := widget["linkedEntityGuids"].([]interface{})
+			for i, g := range linked {
+				if index, ok := ownPages[fmt.Sprint(g)]; ok {
+					linked[i] = fmt.Sprintf("page %d", index)
+				}
+			}
+
+			cfg, _ := widget["rawConfiguration"].(map[string]interface{})
+			queries, _ := cfg["nrqlQueries"].([]interface{})
+			for _, q := range queries {
+				if query, ok := q.(map[string]interface{}); ok {
+					removeDashboardJSONQueryAccount(query, accountID)
+				}
+			}
+		}
+	}
+
+	variables, _ := dashboard["variables"].([]interface{})
+	for _, v := range variables {
+		variable, _ := v.(map[string]interface{})
+		if query, ok := variable["nrqlQuery"].(map[string]interface{}); ok {
+			removeDashboardJSONQueryAccount(query, accountID)
+		}
+	}
+}
+
+// removeDashboardJSONQueryAccount drops the account of a NRQL query when it is
+// accountID or not set, keeping queries of other accounts apart.
+func removeDashboardJSONQueryAccount(query map[string]interface{}, accountID int) {
+	if id, _ := query["accountId"].(float64); id == 0 || int(id) == accountID {
+		delete(query, "accountId")
+	}
+
+	ids, _ := query["accountIds"].([]interface{})
+	if len(ids) == 0 || (len(ids) == 1 && ids[0] == float64(accountID)) {
+		delete(query, "accountIds")
+	}
+}
+
+func normalizeDashboardJSONValue(v interface{}) interface{} {
+	switch value := v.(type) {
+	case map[string]interface{}:
+		for k, item := range value {
+			item = normalizeDashboardJSONValue(item)
+			if item == nil {
+				delete(value, k)
+				continue
+			}
+			value[k] = item
+		}
+
+		if len(value) == 0 {
+			return nil
+		}
+
+		return value
+	case []interface{}:
+		items := []interface{}{}
+		for _, item := range value {
+			if item = normalizeDashboardJSONValue(item); item != nil {
+				items = append(items, item)
+			}
+		}
+
+		if len(items) == 0 {
+			return nil
+		}
+
+		return items
+	case string:
+		if value == "" {
+			return nil
+		}
+
+		return value
+	default:
+		return value
+	}
+}
+
+func sortDashboardJSONWidgets(widgets []interface{}) {
+	position := func(widget interface{}) (float64, float64, string) {
+		w, _ := widget.(map[string]interface{})
+		layout, _ := w["layout"].(map[string]interface{})
+		row, _ := layout["row"].(float64)
+		column, _ := layout["column"].(float64)
+		b, _ := json.Marshal(w)
+
+		return row, column, string(b)
+	}
+
+	sort.SliceStable(widgets, func(i, j int) bool {
+		rowI, columnI, jsonI := position(widgets[i])
+		rowJ, columnJ, jsonJ := position(widgets[j])
+
+		if rowI != rowJ {
+			return rowI < rowJ
+		}
+		if columnI != columnJ {
+			return columnI < columnJ
+		}
+
+		return jsonI < jsonJ
+	})
+}
+
+// suppressEquivalentDashboardJSON ignores differences between dashboard JSON
+// documents that do not change the dashboard.
+func suppressEquivalentDashboardJSON(k, old, new string, d *schema.ResourceData) bool {
+	accountID, _ := d.Get("account_id").(int)
+
+	oldJSON, err := normalizeDashboardJSON(old, accountID)
+	if err != nil {
+		return false
+	}
+
+	newJSON, err := normalizeDashboardJSON(new, accountID)
+	if err != nil {
+		return false
+	}
+
+	return oldJSON == newJSON
+}
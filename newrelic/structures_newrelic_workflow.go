@@ -0,0 +1,187 @@
+package newrelic
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+func expandWorkflowCreateInput(d *schema.ResourceData) workflowCreateInput {
+	return workflowCreateInput{
+		Name:                      d.Get("name").(string),
+		WorkflowEnabled:           d.Get("enabled").(bool),
+		DestinationsEnabled:       d.Get("destinations_enabled").(bool),
+		EnrichmentsEnabled:        d.Get("enrichments_enabled").(bool),
+		MutingRulesHandling:       d.Get("muting_rules_handling").(string),
+		IssuesFilter:              expandWorkflowFilterInput(d.Get("issues_filter.0").(map[string]interface{})),
+		Enrichments:               expandWorkflowEnrichments(d.Get("enrichments").([]interface{})),
+		DestinationConfigurations: expandWorkflowDestinations(d.Get("destination").(*schema.Set).List()),
+	}
+}
+
+func expandWorkflowUpdateInput(d *schema.ResourceData, workflowID string) workflowUpdateInput {
+	filterCfg := d.Get("issues_filter.0").(map[string]interface{})
+
+	input := workflowUpdateInput{
+		ID:                  workflowID,
+		Name:                d.Get("name").(string),
+		WorkflowEnabled:     d.Get("enabled").(bool),
+		DestinationsEnabled: d.Get("destinations_enabled").(bool),
+		EnrichmentsEnabled:  d.Get("enrichments_enabled").(bool),
+		MutingRulesHandling: d.Get("muting_rules_handling").(string),
+		IssuesFilter: workflowUpdatedFilterInput{
+			ID:          filterCfg["filter_id"].(string),
+			FilterInput: expandWorkflowFilterInput(filterCfg),
+		},
+		Enrichments:               expandWorkflowEnrichments(d.Get("enrichments").([]interface{})),
+		DestinationConfigurations: expandWorkflowDestinations(d.Get("destination").(*schema.Set).List()),
+	}
+
+	// The API leaves the enrichments of a workflow untouched when they are
+	// omitted, so removing the block has to be sent as an empty list.
+	if input.Enrichments == nil && d.HasChange("enrichments") {
+		input.Enrichments = &workflowEnrichmentsInput{NRQL: []workflowNrqlEnrichmentInput{}}
+	}
+
+	return input
+}
+
+func expandWorkflowFilterInput(cfg map[string]interface{}) workflowFilterInput {
+	filter := workflowFilterInput{
+		Name:       cfg["name"].(string),
+		Type:       cfg["type"].(string),
+		Predicates: []workflowPredicate{},
+	}
+
+	for _, p := range cfg["predicate"].([]interface{}) {
+		predicateCfg := p.(map[string]interface{})
+
+		filter.Predicates = append(filter.Predicates, workflowPredicate{
+			Attribute: predicateCfg["attribute"].(string),
+			Operator:  predicateCfg["operator"].(string),
+			Values:    expandStringList(predicateCfg["values"].([]interface{})),
+		})
+	}
+
+	return filter
+}
+
+func expandWorkflowEnrichments(cfg []interface{}) *workflowEnrichmentsInput {
+	if len(cfg) == 0 || cfg[0] == nil {
+		return nil
+	}
+
+	enrichments := &workflowEnrichmentsInput{NRQL: []workflowNrqlEnrichmentInput{}}
+
+	for _, e := range cfg[0].(map[string]interface{})["nrql"].([]interface{}) {
+		enrichmentCfg := e.(map[string]interface{})
+
+		enrichment := workflowNrqlEnrichmentInput{
+			ID:   enrichmentCfg["enrichment_id"].(string),
+			Name: enrichmentCfg["name"].(string),
+		}
+
+		for _, c := range enrichmentCfg["configuration"].([]interface{}) {
+			enrichment.Configuration = append(enrichment.Configuration, workflowNrqlConfiguration{
+				Query: c.(map[string]interface{})["query"].(string),
+			})
+		}
+
+		enrichments.NRQL = append(enrichments.NRQL, enrichment)
+	}
+
+	return enrichments
+}
+
+func expandWorkflowDestinations(cfg []interface{}) []workflowDestinationConfigurationInput {
+	destinations := make([]workflowDestinationConfigurationInput, len(cfg))
+
+	for i, d := range cfg {
+		destinations[i] = workflowDestinationConfigurationInput{
+			ChannelID: d.(map[string]interface{})["channel_id"].(string),
+		}
+	}
+
+	return destinations
+}
+
+func flattenWorkflow(w *workflow, d *schema.ResourceData) error {
+	d.Set("name", w.Name)
+	d.Set("enabled", w.WorkflowEnabled)
+	d.Set("destinations_enabled", w.DestinationsEnabled)
+	d.Set("enrichments_enabled", w.EnrichmentsEnabled)
+	d.Set("muting_rules_handling", w.MutingRulesHandling)
+
+	if err := d.Set("issues_filter", flattenWorkflowIssuesFilter(w.IssuesFilter)); err != nil {
+		return err
+	}
+
+	if err := d.Set("enrichments", flattenWorkflowEnrichments(w.Enrichments)); err != nil {
+		return err
+	}
+
+	return d.Set("destination", flattenWorkflowDestinations(w.DestinationConfigurations))
+}
+
+func flattenWorkflowIssuesFilter(filter *workflowIssuesFilter) []interface{} {
+	if filter == nil {
+		return []interface{}{}
+	}
+
+	predicates := make([]interface{}, len(filter.Predicates))
+
+	for i, p := range filter.Predicates {
+		predicates[i] = map[string]interface{}{
+			"attribute": p.Attribute,
+			"operator":  p.Operator,
+			"values":    p.Values,
+		}
+	}
+
+	return []interface{}{map[string]interface{}{
+		"filter_id": filter.ID,
+		"name":      filter.Name,
+		"type":      filter.Type,
+		"predicate": predicates,
+	}}
+}
+
+func flattenWorkflowEnrichments(enrichments []workflowEnrichment) []interface{} {
+	if len(enrichments) == 0 {
+		return []interface{}{}
+	}
+
+	nrql := make([]interface{}, len(enrichments))
+
+	for i, e := range enrichments {
+		configurations := make([]interface{}, len(e.Configurations))
+
+		for j, c := range e.Configurations {
+			configurations[j] = map[string]interface{}{
+				"query": c.Query,
+			}
+		}
+
+		nrql[i] = map[string]interface{}{
+			"enrichment_id": e.ID,
+			"name":          e.Name,
+			"configuration": configurations,
+		}
+	}
+
+	return []interface{}{map[string]interface{}{
+		"nrql": nrql,
+	}}
+}
+
+func flattenWorkflowDestinations(destinations []workflowDestinationConfiguration) []interface{} {
+	out := make([]interface{}, len(destinations))
+
+	for i, d := range destinations {
+		out[i] = map[string]interface{}{
+			"channel_id": d.ChannelID,
+			"name":       d.Name,
+			"type":       d.Type,
+		}
+	}
+
+	return out
+}
@@ -5,10 +5,12 @@ import (
 	"github.com/newrelic/newrelic-client-go/pkg/workloads"
 )
 
-func expandWorkloadCreateInput(d *schema.ResourceData) workloads.CreateInput {
-	createInput := workloads.CreateInput{
-		Name: d.Get("name").(string),
+func expandWorkloadCreateInput(d *schema.ResourceData) workloadInput {
+	createInput := workloadInput{
+		Description:  d.Get("description").(string),
+		StatusConfig: expandWorkloadStatusConfig(d),
 	}
+	createInput.Name = d.Get("name").(string)
 
 	if e, ok := d.GetOk("entity_guids"); ok {
 		createInput.EntityGUIDs = expandWorkloadEntityGUIDs(e.(*schema.Set).List())
@@ -25,10 +27,16 @@ func expandWorkloadCreateInput(d *schema.ResourceData) workloads.CreateInput {
 	return createInput
 }
 
-func expandWorkloadUpdateInput(d *schema.ResourceData) workloads.UpdateInput {
-	name := d.Get("name").(string)
-	updateInput := workloads.UpdateInput{
-		Name: name,
+func expandWorkloadUpdateInput(d *schema.ResourceData) workloadInput {
+	updateInput := workloadInput{
+		Description:  d.Get("description").(string),
+		StatusConfig: expandWorkloadStatusConfig(d),
+	}
+	updateInput.Name = d.Get("name").(string)
+
+	// An empty status configuration removes the one the workload has.
+	if updateInput.StatusConfig == nil {
+		updateInput.StatusConfig = &workloadStatusConfig{}
 	}
 
 	if e, ok := d.GetOk("entity_guids"); ok {
@@ -97,7 +105,77 @@ func expandWorkloadScopeAccountsInput(cfg []interface{}) *workloads.ScopeAccount
 	return &scopeAccounts
 }
 
-func flattenWorkload(workload *workloads.Workload, d *schema.ResourceData) error {
+func expandWorkloadStatusConfig(d *schema.ResourceData) *workloadStatusConfig {
+	var statusConfig workloadStatusConfig
+
+	if v := d.Get("status_config_automatic").([]interface{}); len(v) > 0 && v[0] != nil {
+		statusConfig.Automatic = expandWorkloadAutomaticStatus(v[0].(map[string]interface{}))
+	}
+
+	if v := d.Get("status_config_static").([]interface{}); len(v) > 0 && v[0] != nil {
+		cfg := v[0].(map[string]interface{})
+
+		statusConfig.Static = []workloadStaticStatus{{
+			Description: cfg["description"].(string),
+			Enabled:     cfg["enabled"].(bool),
+			Status:      cfg["status"].(string),
+			Summary:     cfg["summary"].(string),
+		}}
+	}
+
+	if statusConfig.Automatic == nil && statusConfig.Static == nil {
+		return nil
+	}
+
+	return &statusConfig
+}
+
+func expandWorkloadAutomaticStatus(cfg map[string]interface{}) *workloadAutomaticStatus {
+	automatic := workloadAutomaticStatus{
+		Enabled: cfg["enabled"].(bool),
+	}
+
+	if v := cfg["remaining_entities_rule"].([]interface{}); len(v) > 0 && v[0] != nil {
+		rule := v[0].(map[string]interface{})
+
+		automatic.RemainingEntitiesRule = &workloadRemainingEntitiesRule{
+			Rollup: expandWorkloadRollup(rule["rollup"].([]interface{})),
+		}
+	}
+
+	for _, r := range cfg["rule"].([]interface{}) {
+		rule := r.(map[string]interface{})
+
+		automatic.Rules = append(automatic.Rules, workloadRule{
+			EntityGUIDs:         expandWorkloadEntityGUIDs(rule["entity_guids"].(*schema.Set).List()),
+			EntitySearchQueries: expandWorkloadEntitySearchQueryInputs(rule["entity_search_query"].([]interface{})),
+			Rollup:              expandWorkloadRollup(rule["rollup"].([]interface{})),
+		})
+	}
+
+	return &automatic
+}
+
+func expandWorkloadRollup(cfg []interface{}) workloadRollup {
+	var rollup workloadRollup
+
+	if len(cfg) == 0 || cfg[0] == nil {
+		return rollup
+	}
+
+	r := cfg[0].(map[string]interface{})
+	rollup.Strategy = r["strategy"].(string)
+	rollup.ThresholdType = r["threshold_type"].(string)
+	rollup.ThresholdValue = r["threshold_value"].(int)
+
+	if groupBy, ok := r["group_by"]; ok {
+		rollup.GroupBy = groupBy.(string)
+	}
+
+	return rollup
+}
+
+func flattenWorkload(workload *workload, d *schema.ResourceData) error {
 	d.Set("account_id", workload.Account.ID)
 	d.Set("guid", workload.GUID)
 	d.Set("workload_id", workload.ID)
@@ -108,8 +186,80 @@ func flattenWorkload(workload *workloads.Workload, d *schema.ResourceData) error
 	d.Set("entity_guids", flattenWorkloadEntityGUIDs(workload.Entities))
 	d.Set("entity_search_query", flattenWorkloadEntitySearchQueries(workload.EntitySearchQueries))
 	d.Set("scope_account_ids", workload.ScopeAccounts.AccountIDs)
+	d.Set("description", workload.Description)
 
-	return nil
+	automatic, static := flattenWorkloadStatusConfig(workload.StatusConfig)
+
+	if err := d.Set("status_config_automatic", automatic); err != nil {
+		return err
+	}
+
+	return d.Set("status_config_static", static)
+}
+
+func flattenWorkloadStatusConfig(in *workloadStatusConfig) (automatic []interface{}, static []interface{}) {
+	if in == nil {
+		return nil, nil
+	}
+
+	if in.Automatic != nil {
+		rules := make([]interface{}, len(in.Automatic.Rules))
+		for i, r := range in.Automatic.Rules {
+			guids := make([]interface{}, len(r.Entities))
+			for j, e := range r.Entities {
+				guids[j] = e.GUID
+			}
+
+			queries := make([]interface{}, len(r.EntitySearchQueries))
+			for j, q := range r.EntitySearchQueries {
+				queries[j] = map[string]interface{}{"query": q.Query}
+			}
+
+			rules[i] = map[string]interface{}{
+				"entity_guids":        guids,
+				"entity_search_query": queries,
+				"rollup":              flattenWorkloadRollup(r.Rollup, false),
+			}
+		}
+
+		m := map[string]interface{}{
+			"enabled": in.Automatic.Enabled,
+			"rule":    rules,
+		}
+
+		if in.Automatic.RemainingEntitiesRule != nil {
+			m["remaining_entities_rule"] = []interface{}{map[string]interface{}{
+				"rollup": flattenWorkloadRollup(in.Automatic.RemainingEntitiesRule.Rollup, true),
+			}}
+		}
+
+		automatic = []interface{}{m}
+	}
+
+	for _, s := range in.Static {
+		static = append(static, map[string]interface{}{
+			"description": s.Description,
+			"enabled":     s.Enabled,
+			"status":      s.Status,
+			"summary":     s.Summary,
+		})
+	}
+
+	return automatic, static
+}
+
+func flattenWorkloadRollup(in workloadRollup, groupBy bool) []interface{} {
+	m := map[string]interface{}{
+		"strategy":        in.Strategy,
+		"threshold_type":  in.ThresholdType,
+		"threshold_value": in.ThresholdValue,
+	}
+
+	if groupBy {
+		m["group_by"] = in.GroupBy
+	}
+
+	return []interface{}{m}
 }
 
 func flattenWorkloadEntityGUIDs(in []workloads.EntityRef) interface{} {
@@ -134,3 +284,31 @@ func flattenWorkloadEntitySearchQueries(in []workloads.EntitySearchQuery) interf
 
 	return out
 }
+
+func flattenWorkloadData(workload *workload, members []entityOutline, d *schema.ResourceData) error {
+	d.SetId(workload.GUID)
+	d.Set("account_id", workload.Account.ID)
+	d.Set("guid", workload.GUID)
+	d.Set("workload_id", workload.ID)
+	d.Set("name", workload.Name)
+	d.Set("description", workload.Description)
+	d.Set("permalink", workload.Permalink)
+	d.Set("composite_entity_search_query", workload.EntitySearchQuery)
+
+	if err := d.Set("entity_guids", flattenWorkloadEntityGUIDs(workload.Entities)); err != nil {
+		return err
+	}
+
+	entities := make([]interface{}, len(members))
+	for i, e := range members {
+		entities[i] = map[string]interface{}{
+			"guid":       e.GUID,
+			"name":       e.Name,
+			"type":       e.Type,
+			"domain":     e.Domain,
+			"account_id": e.AccountID,
+		}
+	}
+
+	return d.Set("entities", entities)
+}
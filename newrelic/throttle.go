@@ -0,0 +1,246 @@
+package newrelic
+
+import (
+	"crypto/tls"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// The New Relic APIs whose requests can be throttled.
+const (
+	apiREST           = "rest"
+	apiInfrastructure = "infrastructure"
+	apiNerdGraph      = "nerdgraph"
+	apiSynthetics     = "synthetics"
+)
+
+var throttledAPIs = []string{apiREST, apiInfrastructure, apiNerdGraph, apiSynthetics}
+
+// RateLimit throttles the requests made to one of the New Relic APIs. Zero
+// values leave the requests unlimited.
+type RateLimit struct {
+	RequestsPerSecond float64
+	MaxInFlight       int
+}
+
+// throttledTransport spaces out and caps the concurrent requests made to each
+// New Relic API, and holds back all requests to an API for as long as it asked
+// to with a Retry-After header.
+type throttledTransport struct {
+	next      http.RoundTripper
+	throttles []*apiThrottle
+}
+
+// newThrottledTransport returns a transport sending requests through next,
+// throttled per API according to the limits. baseURLs maps each API to the
+// base URLs its requests start with.
+//
+// The New Relic client only accepts an *http.Transport, so the throttling
+// round tripper is registered as the handler of the http and https schemes
+// of an otherwise unused transport.
+func newThrottledTransport(next http.RoundTripper, baseURLs map[string][]string, limits map[string]RateLimit) *http.Transport {
+	t := &throttledTransport{next: next}
+
+	for api, urls := range baseURLs {
+		t.throttles = append(t.throttles, newAPIThrottle(api, urls, limits[api]))
+	}
+
+	// A non-nil TLSNextProto keeps the transport from registering its own
+	// HTTP/2 handler for the https scheme.
+	outer := &http.Transport{
+		TLSNextProto: map[string]func(string, *tls.Conn) http.RoundTripper{},
+	}
+	outer.RegisterProtocol("http", t)
+	outer.RegisterProtocol("https", t)
+
+	return outer
+}
+
+func (t *throttledTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	a := t.throttle(req)
+	if a == nil {
+		return t.next.RoundTrip(req)
+	}
+
+	if err := a.acquire(req); err != nil {
+		return nil, err
+	}
+
+	resp, err := t.next.RoundTrip(req)
+	if err != nil {
+		a.release()
+		return nil, err
+	}
+
+	if wait, ok := retryAfter(resp); ok {
+		log.Printf("[WARN] The New Relic %s API asked to retry after %s, holding back its requests", a.api, wait)
+		a.pause(wait)
+	}
+
+	// The request counts as in flight until its response is read.
+	resp.Body = &releasingBody{ReadCloser: resp.Body, release: a.release}
+
+	return resp, nil
+}
+
+// throttle returns the throttle of the API the request is sent to.
+func (t *throttledTransport) throttle(req *http.Request) *apiThrottle {
+	var match *apiThrottle
+	var matchURL string
+
+	u := req.URL.String()
+	for _, a := range t.throttles {
+		for _, baseURL := range a.baseURLs {
+			if baseURL == "" || !strings.HasPrefix(u, baseURL) {
+				continue
+			}
+
+			if match == nil || len(baseURL) > len(matchURL) {
+				match, matchURL = a, baseURL
+			}
+		}
+	}
+
+	return match
+}
+
+// retryAfter returns how long a rate limited or unavailable API asked to be
+// left alone.
+func retryAfter(resp *http.Response) (time.Duration, bool) {
+	if resp.StatusCode != http.StatusTooManyRequests && resp.StatusCode != http.StatusServiceUnavailable {
+		return 0, false
+	}
+
+	v := resp.Header.Get("Retry-After")
+	if v == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(v); err == nil {
+		return time.Duration(seconds) * time.Second, seconds > 0
+	}
+
+	if at, err := http.ParseTime(v); err == nil {
+		wait := time.Until(at)
+		return wait, wait > 0
+	}
+
+	return 0, false
+}
+
+type apiThrottle struct {
+	api      string
+	baseURLs []string
+
+	// interval is the minimum time between two requests.
+	interval time.Duration
+	// inFlight holds a token per request in flight, or is nil when the
+	// number of requests in flight is not limited.
+	inFlight chan struct{}
+
+	mu sync.Mutex
+	// next is the earliest time the next request may be sent.
+	next time.Time
+}
+
+func newAPIThrottle(api string, baseURLs []string, limit RateLimit) *apiThrottle {
+	a := &apiThrottle{
+		api:      api,
+		baseURLs: baseURLs,
+	}
+
+	if limit.RequestsPerSecond > 0 {
+		a.interval = time.Duration(float64(time.Second) / limit.RequestsPerSecond)
+	}
+
+	if limit.MaxInFlight > 0 {
+		a.inFlight = make(chan struct{}, limit.MaxInFlight)
+	}
+
+	return a
+}
+
+// acquire blocks until the request may be sent, or its context is done. The
+// in-flight token is taken before the time slot, so that a request waiting
+// for a token doesn't hold a slot, and the slot of a request cancelled while
+// waiting goes to the next one.
+func (a *apiThrottle) acquire(req *http.Request) error {
+	ctx := req.Context()
+
+	if a.inFlight != nil {
+		select {
+		case a.inFlight <- struct{}{}:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	for {
+		wait := a.take()
+		if wait <= 0 {
+			return nil
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			a.release()
+			return ctx.Err()
+		}
+	}
+}
+
+// take takes the next time slot if it has come, or returns how long to wait
+// for it.
+func (a *apiThrottle) take() time.Duration {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	now := time.Now()
+	if wait := a.next.Sub(now); wait > 0 {
+		return wait
+	}
+
+	a.next = now.Add(a.interval)
+
+	return 0
+}
+
+func (a *apiThrottle) release() {
+	if a.inFlight != nil {
+		<-a.inFlight
+	}
+}
+
+// pause holds back the requests which are not sent yet for the given time.
+func (a *apiThrottle) pause(wait time.Duration) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if until := time.Now().Add(wait); until.After(a.next) {
+		a.next = until
+	}
+}
+
+// releasingBody releases the throttle of a request once its response body is
+// closed.
+type releasingBody struct {
+	io.ReadCloser
+
+	once    sync.Once
+	release func()
+}
+
+func (b *releasingBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.once.Do(b.release)
+
+	return err
+}
@@ -0,0 +1,209 @@
+// +build unit
+
+package newrelic
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func testThrottledClient(t *testing.T, handler http.HandlerFunc, limits map[string]RateLimit) (*http.Client, string) {
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	transport := newThrottledTransport(http.DefaultTransport, map[string][]string{
+		apiNerdGraph: {server.URL + "/graphql", server.URL + "/eu/graphql"},
+		apiREST:      {server.URL + "/v2"},
+	}, limits)
+
+	return &http.Client{Transport: transport}, server.URL
+}
+
+func testThrottledGet(t *testing.T, client *http.Client, url string) {
+	resp, err := client.Get(url)
+	require.NoError(t, err)
+
+	_, err = ioutil.ReadAll(resp.Body)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+}
+
+func TestThrottledTransport_MaxInFlight(t *testing.T) {
+	var mu sync.Mutex
+	inFlight, maxInFlight := 0, 0
+
+	client, url := testThrottledClient(t, func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		inFlight++
+		if inFlight > maxInFlight {
+			maxInFlight = inFlight
+		}
+		mu.Unlock()
+
+		time.Sleep(20 * time.Millisecond)
+
+		mu.Lock()
+		inFlight--
+		mu.Unlock()
+	}, map[string]RateLimit{
+		apiNerdGraph: {MaxInFlight: 2},
+	})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			testThrottledGet(t, client, url+"/graphql")
+		}()
+	}
+	wg.Wait()
+
+	require.Equal(t, 2, maxInFlight)
+}
+
+func TestThrottledTransport_RequestsPerSecond(t *testing.T) {
+	client, url := testThrottledClient(t, func(w http.ResponseWriter, r *http.Request) {}, map[string]RateLimit{
+		apiREST: {RequestsPerSecond: 20},
+	})
+
+	start := time.Now()
+	for i := 0; i < 5; i++ {
+		testThrottledGet(t, client, url+"/v2/applications.json")
+	}
+	require.True(t, time.Since(start) >= 200*time.Millisecond)
+
+	// Other APIs are not throttled.
+	start = time.Now()
+	for i := 0; i < 5; i++ {
+		testThrottledGet(t, client, url+"/graphql")
+	}
+	require.True(t, time.Since(start) < 200*time.Millisecond)
+}
+
+func TestThrottledTransport_MaxInFlightAndRequestsPerSecond(t *testing.T) {
+	var mu sync.Mutex
+	var sent []time.Time
+
+	client, url := testThrottledClient(t, func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		sent = append(sent, time.Now())
+		first := len(sent) == 1
+		mu.Unlock()
+
+		if first {
+			time.Sleep(300 * time.Millisecond)
+		}
+	}, map[string]RateLimit{
+		apiNerdGraph: {MaxInFlight: 1, RequestsPerSecond: 10},
+	})
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		testThrottledGet(t, client, url+"/graphql")
+	}()
+	time.Sleep(20 * time.Millisecond)
+
+	// The requests waiting for the first one don't use up their time slots
+	// meanwhile, so they are still sent apart once it is done.
+	for i := 0; i < 3; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			testThrottledGet(t, client, url+"/graphql")
+		}()
+	}
+	wg.Wait()
+
+	require.Len(t, sent, 4)
+	for i := 2; i < len(sent); i++ {
+		require.True(t, sent[i].Sub(sent[i-1]) >= 90*time.Millisecond)
+	}
+}
+
+func TestThrottledTransport_SharedBetweenRegions(t *testing.T) {
+	client, url := testThrottledClient(t, func(w http.ResponseWriter, r *http.Request) {}, map[string]RateLimit{
+		apiNerdGraph: {RequestsPerSecond: 20},
+	})
+
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		testThrottledGet(t, client, url+"/graphql")
+		testThrottledGet(t, client, url+"/eu/graphql")
+	}
+	require.True(t, time.Since(start) >= 250*time.Millisecond)
+}
+
+func TestThrottledTransport_Cancelled(t *testing.T) {
+	client, url := testThrottledClient(t, func(w http.ResponseWriter, r *http.Request) {}, map[string]RateLimit{
+		apiREST: {RequestsPerSecond: 2},
+	})
+
+	start := time.Now()
+	testThrottledGet(t, client, url+"/v2/applications.json")
+
+	// The cancelled request leaves its slot to the next one.
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url+"/v2/applications.json", nil)
+	require.NoError(t, err)
+
+	_, err = client.Do(req)
+	require.Error(t, err)
+
+	testThrottledGet(t, client, url+"/v2/applications.json")
+	require.True(t, time.Since(start) < 900*time.Millisecond)
+}
+
+func TestThrottledTransport_RetryAfter(t *testing.T) {
+	var mu sync.Mutex
+	requests := 0
+
+	client, url := testThrottledClient(t, func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		requests++
+		if requests == 1 {
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+		}
+	}, nil)
+
+	testThrottledGet(t, client, url+"/graphql")
+
+	start := time.Now()
+	testThrottledGet(t, client, url+"/graphql")
+	require.True(t, time.Since(start) >= 900*time.Millisecond)
+
+	// Other APIs are not held back.
+	start = time.Now()
+	testThrottledGet(t, client, url+"/v2/applications.json")
+	require.True(t, time.Since(start) < 500*time.Millisecond)
+}
+
+func TestExpandRateLimits(t *testing.T) {
+	limits, err := expandRateLimits([]interface{}{
+		map[string]interface{}{"api": apiNerdGraph, "requests_per_second": 2.5, "max_in_flight": 4},
+		map[string]interface{}{"api": apiREST, "requests_per_second": 0.0, "max_in_flight": 2},
+	})
+	require.NoError(t, err)
+	require.Equal(t, RateLimit{RequestsPerSecond: 2.5, MaxInFlight: 4}, limits[apiNerdGraph])
+	require.Equal(t, RateLimit{MaxInFlight: 2}, limits[apiREST])
+
+	_, err = expandRateLimits([]interface{}{
+		map[string]interface{}{"api": apiREST, "requests_per_second": 1.0, "max_in_flight": 0},
+		map[string]interface{}{"api": apiREST, "requests_per_second": 2.0, "max_in_flight": 0},
+	})
+	require.Error(t, err)
+}
@@ -2,6 +2,11 @@ package newrelic
 
 import (
 	"fmt"
+	"time"
+
+	// Time zones are validated against the IANA database embedded in the
+	// provider, as not every system running it has one installed.
+	_ "time/tzdata"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 )
@@ -60,3 +65,43 @@ func float64AtLeast(min float64) schema.SchemaValidateFunc {
 		return
 	}
 }
+
+// naiveDateTimeFormat is a date and time without a time zone offset, as used
+// by schedules that carry their time zone separately.
+const naiveDateTimeFormat = "2006-01-02T15:04:05"
+
+// validateNaiveDateTime returns a SchemaValidateFunc which tests if the provided
+// value is a string in the format YYYY-MM-DDThh:mm:ss
+func validateNaiveDateTime(i interface{}, k string) (s []string, es []error) {
+	v, ok := i.(string)
+	if !ok {
+		es = append(es, fmt.Errorf("expected type of %s to be string", k))
+		return
+	}
+
+	if _, err := time.Parse(naiveDateTimeFormat, v); err != nil {
+		es = append(es, fmt.Errorf("expected %s to be in the format YYYY-MM-DDThh:mm:ss without a time zone offset, got %s", k, v))
+		return
+	}
+
+	return
+}
+
+// validateTimeZone returns a SchemaValidateFunc which tests if the provided
+// value is a time zone name of the IANA database, e.g. America/Los_Angeles
+func validateTimeZone(i interface{}, k string) (s []string, es []error) {
+	v, ok := i.(string)
+	if !ok {
+		es = append(es, fmt.Errorf("expected type of %s to be string", k))
+		return
+	}
+
+	// LoadLocation also accepts the empty string and Local, which are not
+	// names of the database.
+	if _, err := time.LoadLocation(v); err != nil || v == "" || v == "Local" {
+		es = append(es, fmt.Errorf("expected %s to be an IANA time zone name such as America/Los_Angeles, got %s", k, v))
+		return
+	}
+
+	return
+}
@@ -104,3 +104,60 @@ func runTestCases(t *testing.T, cases []testCase) {
 		}
 	}
 }
+
+func TestValidationNaiveDateTime(t *testing.T) {
+	runTestCases(t, []testCase{
+		{
+			val: "2021-01-21T15:30:00",
+			f:   validateNaiveDateTime,
+		},
+		{
+			val:         "2021-01-21T15:30:00Z",
+			f:           validateNaiveDateTime,
+			expectedErr: regexp.MustCompile(`expected [\w]+ to be in the format YYYY-MM-DDThh:mm:ss`),
+		},
+		{
+			val:         "2021-01-21",
+			f:           validateNaiveDateTime,
+			expectedErr: regexp.MustCompile(`expected [\w]+ to be in the format YYYY-MM-DDThh:mm:ss`),
+		},
+		{
+			val:         1,
+			f:           validateNaiveDateTime,
+			expectedErr: regexp.MustCompile(`expected type of [\w]+ to be string`),
+		},
+	})
+}
+
+func TestValidationTimeZone(t *testing.T) {
+	runTestCases(t, []testCase{
+		{
+			val: "America/Los_Angeles",
+			f:   validateTimeZone,
+		},
+		{
+			val: "UTC",
+			f:   validateTimeZone,
+		},
+		{
+			val:         "America/Springfield",
+			f:           validateTimeZone,
+			expectedErr: regexp.MustCompile(`expected [\w]+ to be an IANA time zone name`),
+		},
+		{
+			val:         "Local",
+			f:           validateTimeZone,
+			expectedErr: regexp.MustCompile(`expected [\w]+ to be an IANA time zone name`),
+		},
+		{
+			val:         "",
+			f:           validateTimeZone,
+			expectedErr: regexp.MustCompile(`expected [\w]+ to be an IANA time zone name`),
+		},
+		{
+			val:         1,
+			f:           validateTimeZone,
+			expectedErr: regexp.MustCompile(`expected type of [\w]+ to be string`),
+		},
+	})
+}